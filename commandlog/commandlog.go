@@ -0,0 +1,111 @@
+// Package commandlog records the actuations commanded of the robot, like moving a motor, servo or
+// arm, with when they were commanded and their arguments, so that a session can be audited or
+// replayed. Nothing is recorded until a log is enabled.
+package commandlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// An Entry is one recorded command.
+type Entry struct {
+	Time      time.Time              `json:"time"`
+	Component string                 `json:"component"`
+	Method    string                 `json:"method"`
+	Args      map[string]interface{} `json:"args,omitempty"`
+}
+
+// A Log writes entries to a writer as JSON, one per line.
+type Log struct {
+	mu  sync.Mutex
+	w   io.Writer
+	enc *json.Encoder
+}
+
+// New returns a log writing to w.
+func New(w io.Writer) *Log {
+	return &Log{w: w, enc: json.NewEncoder(w)}
+}
+
+// Open returns a log appending to the file at path, which is created if needed.
+func Open(path string) (*Log, error) {
+	//nolint:gosec
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return New(f), nil
+}
+
+// Record writes an entry for the method of the component commanded now with the given arguments.
+func (l *Log) Record(component, method string, args map[string]interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enc.Encode(Entry{Time: time.Now(), Component: component, Method: method, Args: args})
+}
+
+// Close closes the writer of the log, if it can be closed.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c, ok := l.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+var enabled atomic.Pointer[Log]
+
+// Enable makes Record write to l, or stop recording when l is nil, and returns the log it was
+// writing to before.
+func Enable(l *Log) *Log {
+	return enabled.Swap(l)
+}
+
+// Record writes an entry to the enabled log, if any. Recording must not get in the way of the
+// command, so errors are not returned.
+func Record(component, method string, args map[string]interface{}) {
+	if l := enabled.Load(); l != nil {
+		//nolint:errcheck
+		l.Record(component, method, args)
+	}
+}
+
+// Read returns every entry of a log written by a Log, in the order they were recorded.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// ReadFile returns every entry of the log file at path.
+func ReadFile(path string) (entries []Entry, err error) {
+	//nolint:gosec
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = multierr.Combine(err, f.Close())
+	}()
+	return Read(f)
+}
@@ -0,0 +1,58 @@
+package commandlog_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/commandlog"
+)
+
+func TestCommandLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.jsonl")
+
+	t.Run("nothing is recorded until enabled", func(t *testing.T) {
+		commandlog.Record("rdk:component:motor/m1", "SetPower", map[string]interface{}{"power_pct": 0.5})
+		_, err := commandlog.ReadFile(path)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	before := time.Now()
+	t.Run("record", func(t *testing.T) {
+		l, err := commandlog.Open(path)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, commandlog.Enable(l), test.ShouldBeNil)
+		commandlog.Record("rdk:component:motor/m1", "GoFor", map[string]interface{}{"rpm": 60., "revolutions": 2.})
+		commandlog.Record("rdk:component:servo/s1", "Move", map[string]interface{}{"angle_deg": 90})
+		test.That(t, commandlog.Enable(nil), test.ShouldEqual, l)
+		commandlog.Record("rdk:component:servo/s1", "Stop", nil)
+		test.That(t, l.Close(), test.ShouldBeNil)
+	})
+
+	t.Run("reopening appends", func(t *testing.T) {
+		l, err := commandlog.Open(path)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, l.Record("rdk:component:motor/m1", "Stop", nil), test.ShouldBeNil)
+		test.That(t, l.Close(), test.ShouldBeNil)
+	})
+
+	entries, err := commandlog.ReadFile(path)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, entries, test.ShouldHaveLength, 3)
+	test.That(t, entries[0].Component, test.ShouldEqual, "rdk:component:motor/m1")
+	test.That(t, entries[0].Method, test.ShouldEqual, "GoFor")
+	test.That(t, entries[0].Args, test.ShouldResemble, map[string]interface{}{"rpm": 60., "revolutions": 2.})
+	test.That(t, entries[1].Component, test.ShouldEqual, "rdk:component:servo/s1")
+	test.That(t, entries[1].Method, test.ShouldEqual, "Move")
+	test.That(t, entries[1].Args, test.ShouldResemble, map[string]interface{}{"angle_deg": 90.})
+	test.That(t, entries[2].Method, test.ShouldEqual, "Stop")
+	test.That(t, entries[2].Args, test.ShouldBeNil)
+	for i, e := range entries {
+		test.That(t, e.Time.Before(before), test.ShouldBeFalse)
+		if i > 0 {
+			test.That(t, e.Time.Before(entries[i-1].Time), test.ShouldBeFalse)
+		}
+	}
+}
@@ -4,6 +4,9 @@ package arm
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"sync"
 
 	"github.com/edaniels/golog"
@@ -386,11 +389,12 @@ func Plan(
 		if err != nil {
 			return nil, err
 		}
-		return motionplan.PlanFrameMotion(ctx, r.Logger(), dst, armFrame, armFrame.InputFromProtobuf(jp), defaultArmPlannerOptions)
+		solution, err := motionplan.PlanFrameMotion(ctx, r.Logger(), dst, armFrame, armFrame.InputFromProtobuf(jp), defaultArmPlannerOptions)
+		return solution, WrapPlanningError(err)
 	}
 	solutionMap, err := motionplan.PlanRobotMotion(ctx, destination, a.ModelFrame(), r, fs, worldState, defaultArmPlannerOptions)
 	if err != nil {
-		return nil, err
+		return nil, WrapPlanningError(err)
 	}
 	return motionplan.FrameStepsFromRobotPath(a.ModelFrame().Name(), solutionMap)
 }
@@ -410,3 +414,62 @@ func GoToWaypoints(ctx context.Context, a Arm, waypoints [][]referenceframe.Inpu
 	}
 	return nil
 }
+
+// TrajectoryDeviation reports how far an arm strayed from a recorded joint trajectory.
+type TrajectoryDeviation struct {
+	// MaxDegrees holds the largest difference, per joint, between a recorded waypoint and
+	// the joint position the arm reported after moving to it.
+	MaxDegrees []float64
+	// Waypoint holds, per joint, the index of the waypoint where MaxDegrees happened.
+	Waypoint []int
+}
+
+// Max returns the largest deviation across all joints.
+func (td *TrajectoryDeviation) Max() float64 {
+	var largest float64
+	for _, d := range td.MaxDegrees {
+		largest = math.Max(largest, d)
+	}
+	return largest
+}
+
+// String summarizes where each joint diverged the most.
+func (td *TrajectoryDeviation) String() string {
+	var sb strings.Builder
+	for i, d := range td.MaxDegrees {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "joint %d: %.2f degrees at waypoint %d", i, d, td.Waypoint[i])
+	}
+	return sb.String()
+}
+
+// ReplayTrajectory moves the arm through each recorded joint position in turn and compares it
+// with the joint positions the arm reports once there, which catches calibration drift.
+func ReplayTrajectory(ctx context.Context, a Arm, trajectory []*pb.JointPositions) (*TrajectoryDeviation, error) {
+	td := &TrajectoryDeviation{}
+	for i, recorded := range trajectory {
+		if err := a.MoveToJointPositions(ctx, recorded, nil); err != nil {
+			return nil, err
+		}
+		actual, err := a.JointPositions(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(actual.Values) != len(recorded.Values) {
+			return nil, fmt.Errorf("waypoint %d has %d joints but the arm reported %d", i, len(recorded.Values), len(actual.Values))
+		}
+		if td.MaxDegrees == nil {
+			td.MaxDegrees = make([]float64, len(recorded.Values))
+			td.Waypoint = make([]int, len(recorded.Values))
+		}
+		for j, want := range recorded.Values {
+			if d := math.Abs(actual.Values[j] - want); d > td.MaxDegrees[j] {
+				td.MaxDegrees[j] = d
+				td.Waypoint[j] = i
+			}
+		}
+	}
+	return td, nil
+}
@@ -393,3 +393,50 @@ func (m *mockLocal) Close(ctx context.Context) error { m.reconfCount++; return n
 func (m *mockLocal) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	return cmd, nil
 }
+
+func TestReplayTrajectory(t *testing.T) {
+	recorded := []*pb.JointPositions{
+		{Values: []float64{0, 10, 20}},
+		{Values: []float64{5, 15, 25}},
+		{Values: []float64{10, 20, 30}},
+	}
+
+	var offset []float64
+	var current *pb.JointPositions
+	injectArm := &inject.Arm{}
+	injectArm.MoveToJointPositionsFunc = func(ctx context.Context, pos *pb.JointPositions, extra map[string]interface{}) error {
+		current = pos
+		return nil
+	}
+	waypoint := -1
+	injectArm.JointPositionsFunc = func(ctx context.Context, extra map[string]interface{}) (*pb.JointPositions, error) {
+		waypoint++
+		values := append([]float64{}, current.Values...)
+		if waypoint == 1 {
+			for i := range values {
+				values[i] += offset[i]
+			}
+		}
+		return &pb.JointPositions{Values: values}, nil
+	}
+
+	offset = []float64{0, 0, 0}
+	td, err := arm.ReplayTrajectory(context.Background(), injectArm, recorded)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, td.Max(), test.ShouldEqual, 0)
+
+	waypoint = -1
+	offset = []float64{0, -3, 1.5}
+	td, err = arm.ReplayTrajectory(context.Background(), injectArm, recorded)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, td.MaxDegrees, test.ShouldResemble, []float64{0, 3, 1.5})
+	test.That(t, td.Waypoint, test.ShouldResemble, []int{0, 1, 1})
+	test.That(t, td.Max(), test.ShouldEqual, 3)
+	test.That(t, td.String(), test.ShouldContainSubstring, "joint 1: 3.00 degrees at waypoint 1")
+
+	injectArm.JointPositionsFunc = func(ctx context.Context, extra map[string]interface{}) (*pb.JointPositions, error) {
+		return &pb.JointPositions{Values: []float64{0}}, nil
+	}
+	_, err = arm.ReplayTrajectory(context.Background(), injectArm, recorded)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -11,6 +11,7 @@ import (
 	"go.viam.com/utils/protoutils"
 	"go.viam.com/utils/rpc"
 
+	"go.viam.com/rdk/commandlog"
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/referenceframe"
@@ -72,13 +73,15 @@ func (c *client) MoveToPosition(
 	if err != nil {
 		return err
 	}
+	to := spatialmath.PoseToProtobuf(pose)
+	commandlog.Record(Named(c.name).String(), "MoveToPosition", map[string]interface{}{"to": to})
 	_, err = c.client.MoveToPosition(ctx, &pb.MoveToPositionRequest{
 		Name:       c.name,
-		To:         spatialmath.PoseToProtobuf(pose),
+		To:         to,
 		WorldState: worldStateMsg,
 		Extra:      ext,
 	})
-	return err
+	return fromStatusError(err)
 }
 
 func (c *client) MoveToJointPositions(ctx context.Context, positions *pb.JointPositions, extra map[string]interface{}) error {
@@ -86,12 +89,13 @@ func (c *client) MoveToJointPositions(ctx context.Context, positions *pb.JointPo
 	if err != nil {
 		return err
 	}
+	commandlog.Record(Named(c.name).String(), "MoveToJointPositions", map[string]interface{}{"positions": positions.GetValues()})
 	_, err = c.client.MoveToJointPositions(ctx, &pb.MoveToJointPositionsRequest{
 		Name:      c.name,
 		Positions: positions,
 		Extra:     ext,
 	})
-	return err
+	return fromStatusError(err)
 }
 
 func (c *client) JointPositions(ctx context.Context, extra map[string]interface{}) (*pb.JointPositions, error) {
@@ -114,11 +118,12 @@ func (c *client) Stop(ctx context.Context, extra map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
+	commandlog.Record(Named(c.name).String(), "Stop", nil)
 	_, err = c.client.Stop(ctx, &pb.StopRequest{
 		Name:  c.name,
 		Extra: ext,
 	})
-	return err
+	return fromStatusError(err)
 }
 
 func (c *client) ModelFrame() referenceframe.Model {
@@ -1,6 +1,7 @@
 package arm_test
 
 import (
+	"bytes"
 	"context"
 	"math"
 	"net"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
 	componentpb "go.viam.com/api/component/arm/v1"
 	robotpb "go.viam.com/api/robot/v1"
 	"go.viam.com/test"
@@ -15,8 +17,12 @@ import (
 	"go.viam.com/utils/rpc"
 	gotestutils "go.viam.com/utils/testutils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"go.viam.com/rdk/commandlog"
 	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/arm/fake"
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/config"
 	viamgrpc "go.viam.com/rdk/grpc"
@@ -181,6 +187,88 @@ func TestClient(t *testing.T) {
 
 		test.That(t, conn.Close(), test.ShouldBeNil)
 	})
+
+	t.Run("command log", func(t *testing.T) {
+		var buf bytes.Buffer
+		commandlog.Enable(commandlog.New(&buf))
+		defer commandlog.Enable(nil)
+
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		arm2Client := arm.NewClientFromConn(context.Background(), conn, testArmName2, logger)
+		test.That(t, arm2Client.MoveToJointPositions(context.Background(), jointPos1, nil), test.ShouldBeNil)
+		test.That(t, arm2Client.MoveToPosition(context.Background(), pos1, &referenceframe.WorldState{}, nil), test.ShouldBeNil)
+		test.That(t, arm2Client.Stop(context.Background(), nil), test.ShouldBeNil)
+		_, err = arm2Client.EndPosition(context.Background(), nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, conn.Close(), test.ShouldBeNil)
+
+		entries, err := commandlog.Read(&buf)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, entries, test.ShouldHaveLength, 3)
+		for _, e := range entries {
+			test.That(t, e.Component, test.ShouldEqual, arm.Named(testArmName2).String())
+		}
+		test.That(t, entries[0].Method, test.ShouldEqual, "MoveToJointPositions")
+		test.That(t, entries[0].Args, test.ShouldResemble, map[string]interface{}{"positions": []interface{}{1., 2., 3.}})
+		test.That(t, entries[1].Method, test.ShouldEqual, "MoveToPosition")
+		test.That(t, entries[1].Args, test.ShouldResemble, map[string]interface{}{
+			"to": map[string]interface{}{"x": 1., "y": 2., "z": 3., "o_z": 1.},
+		})
+		test.That(t, entries[2].Method, test.ShouldEqual, "Stop")
+		test.That(t, entries[2].Args, test.ShouldBeNil)
+	})
+
+	t.Run("typed errors", func(t *testing.T) {
+		moveToJointPositions, moveToPosition, stop := injectArm2.MoveToJointPositionsFunc, injectArm2.MoveToPositionFunc, injectArm2.StopFunc
+		defer func() {
+			injectArm2.MoveToJointPositionsFunc, injectArm2.MoveToPositionFunc, injectArm2.StopFunc = moveToJointPositions, moveToPosition, stop
+		}()
+		fakeArm, err := fake.NewArm(config.Component{Name: testArmName2}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		injectArm2.MoveToPositionFunc = func(
+			ctx context.Context,
+			pose spatialmath.Pose,
+			worldState *referenceframe.WorldState,
+			extra map[string]interface{},
+		) error {
+			return fakeArm.MoveToPosition(ctx, pose, worldState, extra)
+		}
+		injectArm2.MoveToJointPositionsFunc = func(ctx context.Context, jp *componentpb.JointPositions, extra map[string]interface{}) error {
+			return errors.Wrap(arm.ErrBusy, "still moving to the box")
+		}
+		injectArm2.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+			return errors.New("stuck")
+		}
+
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		arm2Client := arm.NewClientFromConn(context.Background(), conn, testArmName2, logger)
+
+		err = arm2Client.MoveToJointPositions(context.Background(), jointPos1, nil)
+		test.That(t, errors.Is(err, arm.ErrBusy), test.ShouldBeTrue)
+		test.That(t, errors.Is(err, arm.ErrJointLimit), test.ShouldBeFalse)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "still moving to the box: arm is busy with another move")
+		// the status error from the server is wrapped
+		test.That(t, status.Code(errors.Unwrap(err)), test.ShouldEqual, codes.Unavailable)
+
+		// a pose the planner finds no solution to is unreachable
+		err = arm2Client.MoveToPosition(context.Background(), spatialmath.NewPoseFromPoint(r3.Vector{X: 1e5}), &referenceframe.WorldState{}, nil)
+		test.That(t, errors.Is(err, arm.ErrUnreachablePose), test.ShouldBeTrue)
+		test.That(t, status.Code(errors.Unwrap(err)), test.ShouldEqual, codes.InvalidArgument)
+
+		err = arm2Client.Stop(context.Background(), nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, errors.Is(err, arm.ErrBusy), test.ShouldBeFalse)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "stuck")
+
+		// lookup failures on the server keep their codes
+		missingClient := arm.NewClientFromConn(context.Background(), conn, missingArmName, logger)
+		_, err = missingClient.EndPosition(context.Background(), nil)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.NotFound)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "no arm with name ("+missingArmName+")")
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	})
 }
 
 func TestClientDialerOption(t *testing.T) {
@@ -0,0 +1,104 @@
+package arm
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/motionplan"
+)
+
+// Errors arms return that callers may want to act on, like retrying a move when the arm is busy.
+// Arms return them wrapped with their details, and clients return them from remote arms, so check
+// for them with errors.Is.
+var (
+	ErrUnreachablePose = errors.New("pose is unreachable")
+	ErrJointLimit      = errors.New("joint position is beyond its limit")
+	ErrBusy            = errors.New("arm is busy with another move")
+)
+
+// errorDomain scopes the reasons of the status details that carry the errors above over gRPC.
+const errorDomain = "rdk.component.arm"
+
+var typedErrors = []struct {
+	err    error
+	reason string
+	code   codes.Code
+}{
+	{ErrUnreachablePose, "UNREACHABLE_POSE", codes.InvalidArgument},
+	{ErrJointLimit, "JOINT_LIMIT", codes.OutOfRange},
+	{ErrBusy, "BUSY", codes.Unavailable},
+}
+
+// toStatusError returns err as a gRPC status error carrying which of the typed errors it is, if
+// any, for the client to get it back. Other errors are returned as they are.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, typed := range typedErrors {
+		if !errors.Is(err, typed.err) {
+			continue
+		}
+		st, detailErr := status.New(typed.code, err.Error()).WithDetails(&errdetails.ErrorInfo{
+			Reason: typed.reason,
+			Domain: errorDomain,
+		})
+		if detailErr != nil {
+			return err
+		}
+		return st.Err()
+	}
+	return err
+}
+
+// fromStatusError returns the typed error a gRPC status error from the server carries, wrapping
+// the status error, or err as it is.
+func fromStatusError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != errorDomain {
+			continue
+		}
+		for _, typed := range typedErrors {
+			if typed.reason == info.Reason {
+				return &typedError{typed: typed.err, err: err}
+			}
+		}
+	}
+	return err
+}
+
+// WrapPlanningError returns err, from planning a move of an arm, as ErrUnreachablePose if the
+// planner found no solution to the pose, or as it is otherwise.
+func WrapPlanningError(err error) error {
+	if !motionplan.IsNoSolution(err) {
+		return err
+	}
+	return &typedError{typed: ErrUnreachablePose, err: err}
+}
+
+// A typedError is an error, like one from a remote arm or the planner, that is one of the typed
+// errors.
+type typedError struct {
+	typed error
+	err   error
+}
+
+func (e *typedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *typedError) Unwrap() error {
+	return e.err
+}
+
+func (e *typedError) Is(target error) bool {
+	return target == e.typed
+}
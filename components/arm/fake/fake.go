@@ -141,7 +141,7 @@ func (a *Arm) MoveToPosition(
 	}
 	solution, err := motionplan.PlanFrameMotion(ctx, a.logger, pos, a.model, a.model.InputFromProtobuf(joints), nil)
 	if err != nil {
-		return err
+		return arm.WrapPlanningError(err)
 	}
 	return arm.GoToWaypoints(ctx, a, solution)
 }
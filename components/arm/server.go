@@ -4,13 +4,13 @@ package arm
 import (
 	"context"
 
-	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/arm/v1"
 
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
 // subtypeServer implements the ArmService from arm.proto.
@@ -28,11 +28,11 @@ func NewServer(s subtype.Service) pb.ArmServiceServer {
 func (s *subtypeServer) getArm(name string) (Arm, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no arm with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("arm", name)
 	}
 	arm, ok := resource.(Arm)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not an arm", name)
+		return nil, utils.NewWrongTypeByNameError("an arm", name)
 	}
 	return arm, nil
 }
@@ -81,12 +81,12 @@ func (s *subtypeServer) MoveToPosition(ctx context.Context, req *pb.MoveToPositi
 	if err != nil {
 		return nil, err
 	}
-	return &pb.MoveToPositionResponse{}, arm.MoveToPosition(
+	return &pb.MoveToPositionResponse{}, toStatusError(arm.MoveToPosition(
 		ctx,
 		spatialmath.NewPoseFromProtobuf(req.GetTo()),
 		worldState,
 		req.Extra.AsMap(),
-	)
+	))
 }
 
 // MoveToJointPositions moves an arm of the underlying robot to the requested joint positions.
@@ -99,7 +99,7 @@ func (s *subtypeServer) MoveToJointPositions(
 	if err != nil {
 		return nil, err
 	}
-	return &pb.MoveToJointPositionsResponse{}, arm.MoveToJointPositions(ctx, req.Positions, req.Extra.AsMap())
+	return &pb.MoveToJointPositionsResponse{}, toStatusError(arm.MoveToJointPositions(ctx, req.Positions, req.Extra.AsMap()))
 }
 
 // Stop stops the arm specified.
@@ -109,5 +109,5 @@ func (s *subtypeServer) Stop(ctx context.Context, req *pb.StopRequest) (*pb.Stop
 	if err != nil {
 		return nil, err
 	}
-	return &pb.StopResponse{}, arm.Stop(ctx, req.Extra.AsMap())
+	return &pb.StopResponse{}, toStatusError(arm.Stop(ctx, req.Extra.AsMap()))
 }
@@ -10,6 +10,8 @@ import (
 	pb "go.viam.com/api/component/arm/v1"
 	"go.viam.com/test"
 	"go.viam.com/utils/protoutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/referenceframe"
@@ -105,10 +107,12 @@ func TestServer(t *testing.T) {
 		_, err := armServer.GetEndPosition(context.Background(), &pb.GetEndPositionRequest{Name: missingArmName})
 		test.That(t, err, test.ShouldNotBeNil)
 		test.That(t, err.Error(), test.ShouldContainSubstring, "no arm")
+		test.That(t, status.Code(err), test.ShouldEqual, codes.NotFound)
 
 		_, err = armServer.GetEndPosition(context.Background(), &pb.GetEndPositionRequest{Name: fakeArmName})
 		test.That(t, err, test.ShouldNotBeNil)
 		test.That(t, err.Error(), test.ShouldContainSubstring, "not an arm")
+		test.That(t, status.Code(err), test.ShouldEqual, codes.InvalidArgument)
 
 		ext, err := protoutils.StructToStructPb(map[string]interface{}{"foo": "EndPosition"})
 		test.That(t, err, test.ShouldBeNil)
@@ -0,0 +1,108 @@
+package trossen
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	pb "go.viam.com/api/component/arm/v1"
+	"go.viam.com/utils"
+)
+
+// defaultTeachInterval is how often the joints are read while teaching when no interval is given.
+const defaultTeachInterval = 100 * time.Millisecond
+
+// A teacher records joint positions read at an interval until it is stopped.
+type teacher struct {
+	cancel                  func()
+	activeBackgroundWorkers sync.WaitGroup
+
+	mu         sync.Mutex
+	trajectory []*pb.JointPositions
+	err        error
+}
+
+// startTeaching reads the joint positions every interval until stop is called, recording each
+// reading that differs from the one before it.
+func startTeaching(read func() (*pb.JointPositions, error), interval time.Duration) *teacher {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &teacher{cancel: cancel}
+	t.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		for {
+			jp, err := read()
+			t.mu.Lock()
+			if err != nil {
+				t.err = err
+				t.mu.Unlock()
+				return
+			}
+			if n := len(t.trajectory); n == 0 || !sameJointPositions(t.trajectory[n-1], jp) {
+				t.trajectory = append(t.trajectory, jp)
+			}
+			t.mu.Unlock()
+			if !utils.SelectContextOrWait(ctx, interval) {
+				return
+			}
+		}
+	}, t.activeBackgroundWorkers.Done)
+	return t
+}
+
+// stop stops recording and returns the recorded trajectory, or why reading the joints failed.
+func (t *teacher) stop() ([]*pb.JointPositions, error) {
+	t.cancel()
+	t.activeBackgroundWorkers.Wait()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.trajectory, t.err
+}
+
+func sameJointPositions(a, b *pb.JointPositions) bool {
+	if len(a.Values) != len(b.Values) {
+		return false
+	}
+	for i := range a.Values {
+		if a.Values[i] != b.Values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// StartTeach turns torque off, so that an operator can pose the arm by hand, and records its joint
+// positions every interval until StopTeach is called. An interval of 0 uses a default of 100ms.
+func (a *Arm) StartTeach(ctx context.Context, interval time.Duration) error {
+	a.teachMu.Lock()
+	defer a.teachMu.Unlock()
+	if a.teach != nil {
+		return errors.New("already teaching")
+	}
+	if interval <= 0 {
+		interval = defaultTeachInterval
+	}
+	a.opMgr.CancelRunning(ctx)
+	if err := a.TorqueOff(); err != nil {
+		return err
+	}
+	a.teach = startTeaching(func() (*pb.JointPositions, error) {
+		return a.JointPositions(context.Background(), nil)
+	}, interval)
+	return nil
+}
+
+// StopTeach stops recording, turns torque back on to hold the arm where the operator left it, and
+// returns the joint positions recorded since StartTeach in degrees. The trajectory can be played
+// back with arm.ReplayTrajectory.
+func (a *Arm) StopTeach(ctx context.Context) ([]*pb.JointPositions, error) {
+	a.teachMu.Lock()
+	defer a.teachMu.Unlock()
+	if a.teach == nil {
+		return nil, errors.New("not teaching")
+	}
+	trajectory, err := a.teach.stop()
+	a.teach = nil
+	return trajectory, multierr.Combine(err, a.TorqueOn())
+}
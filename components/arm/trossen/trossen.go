@@ -44,8 +44,18 @@ const (
 	ModelNameVX300S = "vx300s"
 )
 
+// HomeAngles are the angles we go to before going to sleep.
+var HomeAngles = map[string]ServoPos{
+	"Waist":       2048,
+	"Shoulder":    2048,
+	"Elbow":       2048,
+	"Forearm_rot": 2048,
+	"Wrist":       2048,
+	"Wrist_rot":   2048,
+}
+
 // SleepAngles are the angles we go to to prepare to turn off torque.
-var SleepAngles = map[string]float64{
+var SleepAngles = map[string]ServoPos{
 	"Waist":       2048,
 	"Shoulder":    840,
 	"Elbow":       3090,
@@ -55,7 +65,7 @@ var SleepAngles = map[string]float64{
 }
 
 // OffAngles are the angles the arm falls into after torque is off.
-var OffAngles = map[string]float64{
+var OffAngles = map[string]ServoPos{
 	"Waist":       2048,
 	"Shoulder":    795,
 	"Elbow":       3091,
@@ -64,6 +74,10 @@ var OffAngles = map[string]float64{
 	"Wrist_rot":   2048,
 }
 
+// defaultPairedServoToleranceDegs is how far apart the two servos of the shoulder or elbow may be
+// before we warn about it.
+const defaultPairedServoToleranceDegs Degrees = 3
+
 // Arm TODO.
 type Arm struct {
 	generic.Unimplemented
@@ -73,17 +87,31 @@ type Arm struct {
 	robot    robot.Robot
 	model    referenceframe.Model
 	opMgr    operation.SingleOperationManager
-}
 
-// servoPosToValues takes a 360 degree 0-4096 servo position, centered at 2048,
-// and converts it to degrees, centered at 0.
-func servoPosToValues(pos float64) float64 {
-	return ((pos - 2048) * 180) / 2048
+	pairedServoToleranceDegs Degrees
+	settleDelay              time.Duration
+	deviationTolerance       Degrees
+
+	// movePolicy is what a move does when another is in progress. Unless it is to preempt, a move
+	// holds the slot of moveSlot while it runs.
+	movePolicy string
+	moveSlot   chan struct{}
+
+	homeAngles, sleepAngles, offAngles map[string]ServoPos
+
+	teachMu sync.Mutex
+	teach   *teacher
 }
 
-// degreeToServoPos takes a 0-centered radian and converts to a 360 degree 0-4096 servo position, centered at 2048.
-func degreeToServoPos(pos float64) int {
-	return int(2048 + (pos/180)*2048)
+var jointNames = []string{"Waist", "Shoulder", "Elbow", "Forearm_rot", "Wrist", "Wrist_rot"}
+
+func isJoint(name string) bool {
+	for _, joint := range jointNames {
+		if joint == name {
+			return true
+		}
+	}
+	return false
 }
 
 var (
@@ -107,7 +135,31 @@ type AttrConfig struct {
 	UsbPort       string `json:"serial_path"`
 	BaudRate      int    `json:"serial_baud_rate"`
 	ArmServoCount int    `json:"arm_servo_count"`
-}
+	// PairedServoToleranceDegs is how far apart, in degrees, the two servos driving the same
+	// joint may read before a warning is logged. Defaults to 3.
+	PairedServoToleranceDegs float64 `json:"paired_servo_tolerance_degs,omitempty"`
+	// HomePose, SleepPose and OffPose map every joint to a 0-4095 servo position and replace
+	// HomeAngles, SleepAngles and OffAngles, for arms with a different mounting or payload.
+	HomePose  map[string]float64 `json:"home_pose,omitempty"`
+	SleepPose map[string]float64 `json:"sleep_pose,omitempty"`
+	OffPose   map[string]float64 `json:"off_pose,omitempty"`
+	// SettleDelayMs is how long to wait after the servos stop moving, for the arm to stop
+	// vibrating, before a move returns. Defaults to 0.
+	SettleDelayMs int `json:"settle_delay_ms,omitempty"`
+	// MovePolicy is what a move does when another is in progress: preempt it, queue behind it, or
+	// reject, failing with arm.ErrBusy. Defaults to preempt.
+	MovePolicy string `json:"move_policy,omitempty"`
+	// DeviationToleranceDegs is how far, in degrees, a joint may stray from the path to its target
+	// during a blocking move before the arm is halted where it is. Defaults to 0, not watching moves.
+	DeviationToleranceDegs float64 `json:"deviation_tolerance_degs,omitempty"`
+}
+
+// The policies for a move started while another is in progress.
+const (
+	MovePolicyPreempt = "preempt"
+	MovePolicyQueue   = "queue"
+	MovePolicyReject  = "reject"
+)
 
 // Validate ensures all parts of the config are valid.
 func (config *AttrConfig) Validate(path string) error {
@@ -120,10 +172,65 @@ func (config *AttrConfig) Validate(path string) error {
 	if config.ArmServoCount == 0 {
 		return errors.New("expected nonempty arm_servo_count")
 	}
+	if config.PairedServoToleranceDegs < 0 {
+		return errors.New("paired_servo_tolerance_degs cannot be negative")
+	}
+	if config.SettleDelayMs < 0 {
+		return errors.New("settle_delay_ms cannot be negative")
+	}
+	if config.DeviationToleranceDegs < 0 {
+		return errors.New("deviation_tolerance_degs cannot be negative")
+	}
+	switch config.MovePolicy {
+	case "", MovePolicyPreempt, MovePolicyQueue, MovePolicyReject:
+	default:
+		return errors.Errorf("move_policy must be one of %s, %s or %s, not %q",
+			MovePolicyPreempt, MovePolicyQueue, MovePolicyReject, config.MovePolicy)
+	}
+	for name, pose := range map[string]map[string]float64{
+		"home_pose":  config.HomePose,
+		"sleep_pose": config.SleepPose,
+		"off_pose":   config.OffPose,
+	} {
+		if err := validatePose(pose); err != nil {
+			return errors.Wrapf(err, "bad %s", name)
+		}
+	}
+
+	return nil
+}
 
+func validatePose(pose map[string]float64) error {
+	if pose == nil {
+		return nil
+	}
+	if len(pose) != len(jointNames) {
+		return errors.Errorf("expected positions for exactly the joints %v", jointNames)
+	}
+	for _, joint := range jointNames {
+		pos, ok := pose[joint]
+		if !ok {
+			return errors.Errorf("missing position for joint %s", joint)
+		}
+		if pos < 0 || pos > maxServoPos {
+			return errors.Errorf("position %.0f for joint %s is outside of 0-4095", pos, joint)
+		}
+	}
 	return nil
 }
 
+// poseOrDefault returns the configured pose, if any.
+func poseOrDefault(pose map[string]float64, defaultPose map[string]ServoPos) map[string]ServoPos {
+	if pose == nil {
+		return defaultPose
+	}
+	servoPositions := make(map[string]ServoPos, len(pose))
+	for joint, pos := range pose {
+		servoPositions[joint] = ServoPos(pos)
+	}
+	return servoPositions
+}
+
 //go:embed trossen_wx250s_kinematics.json
 var wx250smodeljson []byte
 
@@ -173,6 +280,11 @@ func NewArm(r robot.Robot, cfg config.Component, logger golog.Logger, json []byt
 		return nil, err
 	}
 
+	tolerance := Degrees(attributes.PairedServoToleranceDegs)
+	if tolerance == 0 {
+		tolerance = defaultPairedServoToleranceDegs
+	}
+
 	return &Arm{
 		Joints: map[string][]*servo.Servo{
 			"Waist":       {servos[0]},
@@ -182,10 +294,18 @@ func NewArm(r robot.Robot, cfg config.Component, logger golog.Logger, json []byt
 			"Wrist":       {servos[6]},
 			"Wrist_rot":   {servos[7]},
 		},
-		moveLock: getPortMutex(usbPort),
-		logger:   logger,
-		robot:    r,
-		model:    model,
+		moveLock:                 getPortMutex(usbPort),
+		logger:                   logger,
+		robot:                    r,
+		model:                    model,
+		pairedServoToleranceDegs: tolerance,
+		settleDelay:              time.Duration(attributes.SettleDelayMs) * time.Millisecond,
+		deviationTolerance:       Degrees(attributes.DeviationToleranceDegs),
+		movePolicy:               attributes.MovePolicy,
+		moveSlot:                 make(chan struct{}, 1),
+		homeAngles:               poseOrDefault(attributes.HomePose, HomeAngles),
+		sleepAngles:              poseOrDefault(attributes.SleepPose, SleepAngles),
+		offAngles:                poseOrDefault(attributes.OffPose, OffAngles),
 	}, nil
 }
 
@@ -205,29 +325,152 @@ func (a *Arm) MoveToPosition(
 	worldState *referenceframe.WorldState,
 	extra map[string]interface{},
 ) error {
-	ctx, done := a.opMgr.New(ctx)
+	ctx, done, err := a.startMove(ctx)
+	if err != nil {
+		return err
+	}
 	defer done()
 	return arm.Move(ctx, a.robot, a, pos, worldState)
 }
 
 // MoveToJointPositions takes a list of degrees and sets the corresponding joints to that position.
+// It waits for the arm to get there unless extra["block"] = false is given, in which case it returns
+// as soon as the joints have been commanded.
 func (a *Arm) MoveToJointPositions(ctx context.Context, jp *pb.JointPositions, extra map[string]interface{}) error {
-	ctx, done := a.opMgr.New(ctx)
+	ctx, done, err := a.startMove(ctx)
+	if err != nil {
+		return err
+	}
 	defer done()
 	if len(jp.Values) > len(a.JointOrder()) {
 		return errors.New("passed in too many positions")
 	}
 
+	servoPositions := make(map[string]ServoPos, len(jp.Values))
+	for i, pos := range jp.Values {
+		servoPos, err := Degrees(pos).ServoPos()
+		if err != nil {
+			return errors.Wrapf(err, "bad position for %s", a.JointOrder()[i])
+		}
+		servoPositions[a.JointOrder()[i]] = servoPos
+	}
+	return a.jointsTo(ctx, servoPositions, extra)
+}
+
+// MoveJointsBy moves each of the named joints by its delta in degrees, for fine adjustments. The
+// other joints hold where they are. Like MoveToJointPositions it waits for the arm unless
+// extra["block"] = false is given.
+func (a *Arm) MoveJointsBy(ctx context.Context, deltas map[string]Degrees, extra map[string]interface{}) error {
+	ctx, done, err := a.startMove(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+	current, err := a.GetAllAngles()
+	if err != nil {
+		return err
+	}
+	targets, err := relativeTargets(current, deltas)
+	if err != nil {
+		return err
+	}
+	return a.jointsTo(ctx, targets, extra)
+}
+
+// movingKey marks the context of a move holding the move slot, so that the moves it makes itself,
+// like MoveToPosition going through the planned joint positions, do not wait on it.
+type movingKey struct{}
+
+// startMove starts a move as the move policy says, returning its context and the func to call
+// once it is done.
+func (a *Arm) startMove(ctx context.Context) (context.Context, func(), error) {
+	if a.movePolicy == "" || a.movePolicy == MovePolicyPreempt || ctx.Value(movingKey{}) != nil {
+		ctx, done := a.opMgr.New(ctx)
+		return ctx, done, nil
+	}
+	if a.movePolicy == MovePolicyReject {
+		select {
+		case a.moveSlot <- struct{}{}:
+		default:
+			return nil, nil, arm.ErrBusy
+		}
+	} else {
+		select {
+		case a.moveSlot <- struct{}{}:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+	ctx, done := a.opMgr.New(context.WithValue(ctx, movingKey{}, true))
+	return ctx, func() {
+		done()
+		<-a.moveSlot
+	}, nil
+}
+
+// relativeTargets returns the positions of the joints with deltas once moved by them from their
+// current positions.
+func relativeTargets(current map[string]ServoPos, deltas map[string]Degrees) (map[string]ServoPos, error) {
+	targets := make(map[string]ServoPos, len(deltas))
+	for joint, delta := range deltas {
+		if !isJoint(joint) {
+			return nil, errors.Errorf("unknown joint %q, expected one of %v", joint, jointNames)
+		}
+		pos, ok := current[joint]
+		if !ok {
+			return nil, errors.Errorf("no position read for %s", joint)
+		}
+		target, err := (pos.Degrees() + delta).ServoPos()
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot move %s by %v degrees", joint, delta)
+		}
+		targets[joint] = target
+	}
+	return targets, nil
+}
+
+// jointsTo commands the joints to the positions, leaving any other joint where it is, and waits
+// for the arm to get there unless extra["block"] = false is given. With a deviation tolerance the
+// wait halts the arm should a joint stray from its path.
+func (a *Arm) jointsTo(ctx context.Context, positions map[string]ServoPos, extra map[string]interface{}) error {
+	block, ok := extra["block"].(bool)
+	block = !ok || block
 	a.moveLock.Lock()
 
-	// TODO(pl): make block configurable
-	block := false
-	for i, pos := range jp.Values {
-		a.JointTo(a.JointOrder()[i], degreeToServoPos(pos), block)
+	var watchdog *deviationWatchdog
+	if block && a.deviationTolerance > 0 {
+		start, err := a.allAngles()
+		if err != nil {
+			a.moveLock.Unlock()
+			return err
+		}
+		watchdog = &deviationWatchdog{
+			tolerance: a.deviationTolerance,
+			start:     start,
+			target:    positions,
+			read:      a.allAngles,
+			halt:      a.holdAt,
+		}
+	}
+
+	// joints are commanded without waiting so that they all move at once
+	for joint, servoPos := range positions {
+		a.JointTo(joint, servoPos, false)
 	}
 
 	a.moveLock.Unlock()
-	return a.WaitForMovement(ctx)
+	if !block {
+		return nil
+	}
+	return a.waitForMovement(ctx, watchdog)
+}
+
+// holdAt halts the arm by commanding the joints to stay at their current positions.
+func (a *Arm) holdAt(current map[string]ServoPos) {
+	a.logger.Warnw("joints deviated from their commanded path, halting the arm", "positions", current)
+	for joint, pos := range current {
+		a.JointTo(joint, pos, false)
+	}
 }
 
 // JointPositions returns an empty struct, because the wx250s should use joint angles from kinematics.
@@ -239,7 +482,7 @@ func (a *Arm) JointPositions(ctx context.Context, extra map[string]interface{})
 
 	positions := make([]float64, 0, len(a.JointOrder()))
 	for _, jointName := range a.JointOrder() {
-		positions = append(positions, servoPosToValues(angleMap[jointName]))
+		positions = append(positions, float64(angleMap[jointName].Degrees()))
 	}
 
 	return &pb.JointPositions{Values: positions}, nil
@@ -251,6 +494,82 @@ func (a *Arm) Stop(ctx context.Context, extra map[string]interface{}) error {
 	return arm.ErrStopUnimplemented
 }
 
+// DoCommand runs the commands of the arm. See commands for what they are.
+func (a *Arm) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return a.commands().DoCommand(ctx, cmd)
+}
+
+// commands are the custom commands of the arm. "torque_off" cancels any movement and releases
+// every servo right away so that an operator can free the arm. Unlike Stop it does not hold
+// position. Use "torque_on" to hold position again. "sleep" moves the arm to its sleep pose.
+// "move_joints_by" runs MoveJointsBy with the "deltas" in degrees by joint name, and an optional
+// "block". "start_teach" and "stop_teach" run StartTeach, with an optional "interval_ms", and
+// StopTeach, which returns the recorded joint positions in degrees as "trajectory".
+func (a *Arm) commands() generic.Commands {
+	return generic.Commands{
+		"torque_off": {
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				a.opMgr.CancelRunning(ctx)
+				return nil, a.TorqueOff()
+			},
+		},
+		"torque_on": {
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				return nil, a.TorqueOn()
+			},
+		},
+		"sleep": {
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				ctx, done, err := a.startMove(ctx)
+				if err != nil {
+					return nil, err
+				}
+				defer done()
+				return nil, a.SleepPosition(ctx)
+			},
+		},
+		"move_joints_by": {
+			Args: []generic.Arg{{Name: "deltas", Type: generic.ArgMap, Required: true}, {Name: "block", Type: generic.ArgBool}},
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				rawDeltas := cmd["deltas"].(map[string]interface{})
+				deltas := make(map[string]Degrees, len(rawDeltas))
+				for joint, raw := range rawDeltas {
+					delta, ok := raw.(float64)
+					if !ok {
+						return nil, errors.Errorf("delta for %s must be a number of degrees", joint)
+					}
+					deltas[joint] = Degrees(delta)
+				}
+				return nil, a.MoveJointsBy(ctx, deltas, cmd)
+			},
+		},
+		"start_teach": {
+			Args: []generic.Arg{{Name: "interval_ms", Type: generic.ArgNumber}},
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				intervalMs, _ := cmd["interval_ms"].(float64)
+				return nil, a.StartTeach(ctx, time.Duration(intervalMs*float64(time.Millisecond)))
+			},
+		},
+		"stop_teach": {
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				trajectory, err := a.StopTeach(ctx)
+				if err != nil {
+					return nil, err
+				}
+				positions := make([]interface{}, 0, len(trajectory))
+				for _, jp := range trajectory {
+					values := make([]interface{}, 0, len(jp.Values))
+					for _, v := range jp.Values {
+						values = append(values, v)
+					}
+					positions = append(positions, values)
+				}
+				return map[string]interface{}{"trajectory": positions}, nil
+			},
+		},
+	}
+}
+
 // IsMoving returns whether the arm is moving.
 func (a *Arm) IsMoving(ctx context.Context) (bool, error) {
 	return a.opMgr.OpRunning(), nil
@@ -258,6 +577,14 @@ func (a *Arm) IsMoving(ctx context.Context) (bool, error) {
 
 // Close will get the arm ready to be turned off.
 func (a *Arm) Close() {
+	a.teachMu.Lock()
+	if a.teach != nil {
+		if _, err := a.teach.stop(); err != nil {
+			a.logger.Errorf("teach error: %s", err)
+		}
+		a.teach = nil
+	}
+	a.teachMu.Unlock()
 	// First, check if we are approximately in the sleep position
 	// If so, we can just turn off torque
 	// If not, let's move through the home position first
@@ -265,13 +592,7 @@ func (a *Arm) Close() {
 	if err != nil {
 		a.logger.Errorf("failed to get angles: %s", err)
 	}
-	alreadyAtSleep := true
-	for _, joint := range a.JointOrder() {
-		if !within(angles[joint], SleepAngles[joint], 15) && !within(angles[joint], OffAngles[joint], 15) {
-			alreadyAtSleep = false
-		}
-	}
-	if !alreadyAtSleep {
+	if !a.atSleep(angles) {
 		err = a.HomePosition(context.Background())
 		if err != nil {
 			a.logger.Errorf("Home position error: %s", err)
@@ -287,29 +608,65 @@ func (a *Arm) Close() {
 	}
 }
 
+// atSleep returns whether every joint is close to either its sleep or its off angle.
+func (a *Arm) atSleep(angles map[string]ServoPos) bool {
+	for _, joint := range a.JointOrder() {
+		if !within(float64(angles[joint]), float64(a.sleepAngles[joint]), 15) &&
+			!within(float64(angles[joint]), float64(a.offAngles[joint]), 15) {
+			return false
+		}
+	}
+	return true
+}
+
 // GetAllAngles will return a map of the angles of each joint, denominated in servo position.
-func (a *Arm) GetAllAngles() (map[string]float64, error) {
+func (a *Arm) GetAllAngles() (map[string]ServoPos, error) {
 	a.moveLock.Lock()
 	defer a.moveLock.Unlock()
-	angles := make(map[string]float64)
+	return a.allAngles()
+}
+
+// allAngles is GetAllAngles for callers already holding the move lock.
+func (a *Arm) allAngles() (map[string]ServoPos, error) {
+	angles := make(map[string]ServoPos)
 	for jointName, servos := range a.Joints {
-		angleSum := 0
+		positions := make([]int, 0, len(servos))
 		for _, s := range servos {
 			pos, err := s.PresentPosition()
 			if err != nil {
 				return angles, err
 			}
-			angleSum += pos
+			positions = append(positions, pos)
 		}
-		angleMean := float64(angleSum) / float64(len(servos))
-		angles[jointName] = angleMean
+		angles[jointName] = a.jointAngle(jointName, positions)
 	}
 	return angles, nil
 }
 
+// jointAngle averages the positions of the servos driving a joint. Averaging hides servos
+// that disagree, from belt slip or a failing servo, so that gets a warning.
+func (a *Arm) jointAngle(jointName string, positions []int) ServoPos {
+	lowest, highest, sum := positions[0], positions[0], 0
+	for _, pos := range positions {
+		sum += pos
+		if pos < lowest {
+			lowest = pos
+		}
+		if pos > highest {
+			highest = pos
+		}
+	}
+	spread := ServoPos(highest).Degrees() - ServoPos(lowest).Degrees()
+	if spread > a.pairedServoToleranceDegs {
+		a.logger.Warnw("servos of the same joint disagree, check for belt slip or a failing servo",
+			"joint", jointName, "positions", positions, "spread_degs", spread)
+	}
+	return ServoPos(sum) / ServoPos(len(positions))
+}
+
 // JointOrder TODO.
 func (a *Arm) JointOrder() []string {
-	return []string{"Waist", "Shoulder", "Elbow", "Forearm_rot", "Wrist", "Wrist_rot"}
+	return append([]string{}, jointNames...)
 }
 
 // PrintPositions prints positions of all servos.
@@ -321,7 +678,7 @@ func (a *Arm) PrintPositions() error {
 		if err != nil {
 			return err
 		}
-		posString = fmt.Sprintf("%s || %d : %d, %f degrees", posString, i, pos, servoPosToValues(float64(pos)))
+		posString = fmt.Sprintf("%s || %d : %d, %f degrees", posString, i, pos, ServoPos(pos).Degrees())
 	}
 	return nil
 }
@@ -396,14 +753,14 @@ func (a *Arm) TorqueOff() error {
 }
 
 // JointTo sets a joint to a position.
-func (a *Arm) JointTo(jointName string, pos int, block bool) {
-	if pos > 4095 {
-		pos = 4095
+func (a *Arm) JointTo(jointName string, pos ServoPos, block bool) {
+	if pos > maxServoPos {
+		pos = maxServoPos
 	} else if pos < 0 {
 		pos = 0
 	}
 
-	err := servo.GoalAndTrack(pos, block, a.GetServos(jointName)...)
+	err := servo.GoalAndTrack(int(pos), block, a.GetServos(jointName)...)
 	if err != nil {
 		a.logger.Errorf("%s jointTo error: %s", jointName, err)
 	}
@@ -413,12 +770,9 @@ func (a *Arm) JointTo(jointName string, pos int, block bool) {
 func (a *Arm) SleepPosition(ctx context.Context) error {
 	a.moveLock.Lock()
 	sleepWait := false
-	a.JointTo("Waist", 2048, sleepWait)
-	a.JointTo("Shoulder", 840, sleepWait)
-	a.JointTo("Wrist_rot", 2048, sleepWait)
-	a.JointTo("Wrist", 2509, sleepWait)
-	a.JointTo("Forearm_rot", 2048, sleepWait)
-	a.JointTo("Elbow", 3090, sleepWait)
+	for _, joint := range []string{"Waist", "Shoulder", "Wrist_rot", "Wrist", "Forearm_rot", "Elbow"} {
+		a.JointTo(joint, a.sleepAngles[joint], sleepWait)
+	}
 	a.moveLock.Unlock()
 	return a.WaitForMovement(ctx)
 }
@@ -434,7 +788,7 @@ func (a *Arm) HomePosition(ctx context.Context) error {
 
 	wait := false
 	for jointName := range a.Joints {
-		a.JointTo(jointName, 2048, wait)
+		a.JointTo(jointName, a.homeAngles[jointName], wait)
 	}
 	a.moveLock.Unlock()
 	return a.WaitForMovement(ctx)
@@ -454,8 +808,24 @@ func (a *Arm) GoToInputs(ctx context.Context, goal []referenceframe.Input) error
 	return a.MoveToJointPositions(ctx, a.model.ProtobufFromInput(goal), nil)
 }
 
-// WaitForMovement takes some servos, and will block until the servos are done moving.
+// WaitForMovement blocks until the servos are done moving, and then for the settle delay.
 func (a *Arm) WaitForMovement(ctx context.Context) error {
+	return a.waitForMovement(ctx, nil)
+}
+
+// waitForMovement is WaitForMovement checking the joints with the watchdog, if any, as they move.
+func (a *Arm) waitForMovement(ctx context.Context, watchdog *deviationWatchdog) error {
+	if err := a.waitForServos(ctx, watchdog); err != nil {
+		return err
+	}
+	if a.settleDelay > 0 && !utils.SelectContextOrWait(ctx, a.settleDelay) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// waitForServos blocks until the servos are done moving.
+func (a *Arm) waitForServos(ctx context.Context, watchdog *deviationWatchdog) error {
 	a.moveLock.Lock()
 	defer a.moveLock.Unlock()
 	allAtPos := false
@@ -475,6 +845,11 @@ func (a *Arm) WaitForMovement(ctx context.Context) error {
 				allAtPos = false
 			}
 		}
+		if watchdog != nil {
+			if err := watchdog.check(allAtPos); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
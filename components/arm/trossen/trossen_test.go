@@ -0,0 +1,340 @@
+package trossen
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	pb "go.viam.com/api/component/arm/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/utils"
+)
+
+func TestUnits(t *testing.T) {
+	for _, tc := range []struct {
+		degrees  Degrees
+		servoPos ServoPos
+	}{
+		{0, 2048},
+		{90, 3072},
+		{-90, 1024},
+		{-180, 0},
+		{179.9, 4094},
+	} {
+		servoPos, err := tc.degrees.ServoPos()
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, servoPos, test.ShouldEqual, tc.servoPos)
+		test.That(t, servoPos.Degrees(), test.ShouldAlmostEqual, tc.degrees, 0.1)
+	}
+
+	for _, degrees := range []Degrees{180, 200, -181, -720} {
+		_, err := degrees.ServoPos()
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "outside of 0-4095")
+		test.That(t, errors.Is(err, arm.ErrJointLimit), test.ShouldBeTrue)
+	}
+
+	// averaged positions fall between steps
+	test.That(t, ServoPos(2048.5).Degrees(), test.ShouldAlmostEqual, Degrees(0.044), 0.001)
+}
+
+func TestJointAngle(t *testing.T) {
+	logger, logs := golog.NewObservedTestLogger(t)
+	a := &Arm{logger: logger, pairedServoToleranceDegs: defaultPairedServoToleranceDegs}
+
+	test.That(t, a.jointAngle("Waist", []int{2048}), test.ShouldEqual, ServoPos(2048))
+	test.That(t, a.jointAngle("Shoulder", []int{2040, 2060}), test.ShouldEqual, ServoPos(2050))
+	test.That(t, logs.FilterMessageSnippet("disagree").Len(), test.ShouldEqual, 0)
+
+	// 100 servo steps is almost 9 degrees
+	test.That(t, a.jointAngle("Elbow", []int{2000, 2100}), test.ShouldEqual, ServoPos(2050))
+	warnings := logs.FilterMessageSnippet("disagree").All()
+	test.That(t, warnings, test.ShouldHaveLength, 1)
+	test.That(t, warnings[0].ContextMap()["joint"], test.ShouldEqual, "Elbow")
+}
+
+func TestTorqueOff(t *testing.T) {
+	a := &Arm{moveLock: &sync.Mutex{}, logger: golog.NewTestLogger(t)}
+
+	moveCtx, done := a.opMgr.New(context.Background())
+	defer done()
+	moving, err := a.IsMoving(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moving, test.ShouldBeTrue)
+
+	_, err = a.DoCommand(context.Background(), map[string]interface{}{"command": "torque_off"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moveCtx.Err(), test.ShouldNotBeNil)
+
+	_, err = a.DoCommand(context.Background(), map[string]interface{}{"command": "torque_on"})
+	test.That(t, err, test.ShouldBeNil)
+
+	_, err = a.DoCommand(context.Background(), map[string]interface{}{"command": "spin"})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = a.DoCommand(context.Background(), map[string]interface{}{})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestPoses(t *testing.T) {
+	sleep := map[string]float64{
+		"Waist":       1024,
+		"Shoulder":    900,
+		"Elbow":       3000,
+		"Forearm_rot": 2048,
+		"Wrist":       2400,
+		"Wrist_rot":   1024,
+	}
+	cfg := &AttrConfig{UsbPort: "/dev/ttyUSB0", BaudRate: 1000000, ArmServoCount: 9, SleepPose: sleep}
+	test.That(t, cfg.Validate("path"), test.ShouldBeNil)
+
+	a := &Arm{
+		homeAngles:  poseOrDefault(cfg.HomePose, HomeAngles),
+		sleepAngles: poseOrDefault(cfg.SleepPose, SleepAngles),
+		offAngles:   poseOrDefault(cfg.OffPose, OffAngles),
+	}
+	test.That(t, a.homeAngles, test.ShouldResemble, HomeAngles)
+	test.That(t, a.sleepAngles["Shoulder"], test.ShouldEqual, ServoPos(900))
+	test.That(t, a.atSleep(a.sleepAngles), test.ShouldBeTrue)
+	test.That(t, a.atSleep(OffAngles), test.ShouldBeTrue)
+	test.That(t, a.atSleep(SleepAngles), test.ShouldBeFalse)
+
+	delete(sleep, "Wrist")
+	err := cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "sleep_pose")
+
+	sleep["Wrist"] = 5000
+	test.That(t, cfg.Validate("path"), test.ShouldNotBeNil)
+
+	sleep["Wrist"] = 2400
+	sleep["Gripper"] = 2048
+	test.That(t, cfg.Validate("path"), test.ShouldNotBeNil)
+}
+
+func TestMoveToJointPositionsBlock(t *testing.T) {
+	a := &Arm{moveLock: &sync.Mutex{}, logger: golog.NewTestLogger(t)}
+	jp := &pb.JointPositions{Values: []float64{0, 10, -10}}
+
+	// WaitForMovement polls the servos every 200ms, even when there are none
+	start := time.Now()
+	test.That(t, a.MoveToJointPositions(context.Background(), jp, nil), test.ShouldBeNil)
+	test.That(t, time.Since(start), test.ShouldBeGreaterThanOrEqualTo, 200*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := a.MoveToJointPositions(ctx, jp, map[string]interface{}{"block": true})
+	test.That(t, err, test.ShouldBeError, context.Canceled)
+
+	err = a.MoveToJointPositions(ctx, jp, map[string]interface{}{"block": false})
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestTeach(t *testing.T) {
+	// an operator moves the waist and then the shoulder, with pauses in between
+	poses := [][]float64{
+		{0, 0, 0}, {0, 0, 0}, {10, 0, 0}, {20, 0, 0}, {20, 0, 0}, {20, 5, 0}, {20, 10, 0}, {20, 10, 0},
+	}
+	var mu sync.Mutex
+	reads := 0
+	teacher := startTeaching(func() (*pb.JointPositions, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		pose := poses[utils.MinInt(reads, len(poses)-1)]
+		reads++
+		return &pb.JointPositions{Values: pose}, nil
+	}, time.Millisecond)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		mu.Lock()
+		defer mu.Unlock()
+		test.That(tb, reads, test.ShouldBeGreaterThan, len(poses))
+	})
+	trajectory, err := teacher.stop()
+	test.That(t, err, test.ShouldBeNil)
+	var values [][]float64
+	for _, jp := range trajectory {
+		values = append(values, jp.Values)
+	}
+	// readings that did not change are recorded once
+	test.That(t, values, test.ShouldResemble, [][]float64{{0, 0, 0}, {10, 0, 0}, {20, 0, 0}, {20, 5, 0}, {20, 10, 0}})
+
+	// a failed reading ends the recording with its error
+	readErr := errors.New("servo not responding")
+	teacher = startTeaching(func() (*pb.JointPositions, error) {
+		return nil, readErr
+	}, time.Millisecond)
+	_, err = teacher.stop()
+	test.That(t, err, test.ShouldBeError, readErr)
+
+	a := &Arm{moveLock: &sync.Mutex{}, logger: golog.NewTestLogger(t)}
+	_, err = a.StopTeach(context.Background())
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, a.StartTeach(context.Background(), 0), test.ShouldBeNil)
+	test.That(t, a.StartTeach(context.Background(), 0), test.ShouldNotBeNil)
+	resp, err := a.DoCommand(context.Background(), map[string]interface{}{"command": "stop_teach"})
+	test.That(t, err, test.ShouldBeNil)
+	// the joints of an arm without servos never move
+	test.That(t, resp["trajectory"], test.ShouldHaveLength, 1)
+	test.That(t, resp["trajectory"].([]interface{})[0], test.ShouldHaveLength, len(jointNames))
+}
+
+func TestMoveJointsBy(t *testing.T) {
+	current := map[string]ServoPos{
+		"Waist":       2048,
+		"Shoulder":    1024,
+		"Elbow":       3072,
+		"Forearm_rot": 2048,
+		"Wrist":       2048,
+		"Wrist_rot":   2048,
+	}
+	targets, err := relativeTargets(current, map[string]Degrees{"Shoulder": 10, "Wrist": -45})
+	test.That(t, err, test.ShouldBeNil)
+	// only the two joints are commanded, the others hold where they are
+	test.That(t, targets, test.ShouldHaveLength, 2)
+	test.That(t, targets["Shoulder"].Degrees(), test.ShouldAlmostEqual, current["Shoulder"].Degrees()+10, 0.1)
+	test.That(t, targets["Wrist"].Degrees(), test.ShouldAlmostEqual, current["Wrist"].Degrees()-45, 0.1)
+	test.That(t, current["Shoulder"], test.ShouldEqual, ServoPos(1024))
+
+	_, err = relativeTargets(current, map[string]Degrees{"Shoulder": 10, "Gripper": 5})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `unknown joint "Gripper"`)
+
+	_, err = relativeTargets(current, map[string]Degrees{"Elbow": 100})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot move Elbow by 100 degrees")
+
+	// an arm without servos has no positions to move from
+	a := &Arm{moveLock: &sync.Mutex{}, logger: golog.NewTestLogger(t)}
+	_, err = a.DoCommand(context.Background(), map[string]interface{}{
+		"command": "move_joints_by",
+		"deltas":  map[string]interface{}{"Waist": 5.},
+	})
+	test.That(t, err, test.ShouldBeError, "no position read for Waist")
+	_, err = a.DoCommand(context.Background(), map[string]interface{}{
+		"command": "move_joints_by",
+		"deltas":  map[string]interface{}{"Waist": "left"},
+	})
+	test.That(t, err, test.ShouldBeError, "delta for Waist must be a number of degrees")
+	_, err = a.DoCommand(context.Background(), map[string]interface{}{"command": "move_joints_by", "deltas": 5.})
+	test.That(t, err, test.ShouldBeError, "'deltas' of move_joints_by must be a map, not float64")
+}
+
+func TestSettleDelay(t *testing.T) {
+	cfg := &AttrConfig{UsbPort: "/dev/ttyUSB0", BaudRate: 1000000, ArmServoCount: 9, SettleDelayMs: -1}
+	test.That(t, cfg.Validate("path"), test.ShouldBeError, "settle_delay_ms cannot be negative")
+
+	// WaitForMovement polls the servos every 200ms, even when there are none
+	a := &Arm{moveLock: &sync.Mutex{}, logger: golog.NewTestLogger(t), settleDelay: 300 * time.Millisecond}
+	jp := &pb.JointPositions{Values: []float64{0, 10, -10}}
+	start := time.Now()
+	test.That(t, a.MoveToJointPositions(context.Background(), jp, nil), test.ShouldBeNil)
+	test.That(t, time.Since(start), test.ShouldBeGreaterThanOrEqualTo, 500*time.Millisecond)
+
+	// the delay is not applied to moves that do not wait for the arm
+	start = time.Now()
+	test.That(t, a.MoveToJointPositions(context.Background(), jp, map[string]interface{}{"block": false}), test.ShouldBeNil)
+	test.That(t, time.Since(start), test.ShouldBeLessThan, 200*time.Millisecond)
+
+	// giving up on the move cuts the delay short
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	test.That(t, a.WaitForMovement(ctx), test.ShouldBeError, context.DeadlineExceeded)
+}
+
+func TestMovePolicy(t *testing.T) {
+	cfg := &AttrConfig{UsbPort: "/dev/ttyUSB0", BaudRate: 1000000, ArmServoCount: 9, MovePolicy: "shove"}
+	test.That(t, cfg.Validate("path"), test.ShouldBeError, `move_policy must be one of preempt, queue or reject, not "shove"`)
+
+	jp := &pb.JointPositions{Values: []float64{0, 10, -10}}
+	// each move waits for the servos at least once, 200ms, then settles for 300ms
+	concurrentMoves := func(policy string) (first, second error, took time.Duration) {
+		a := &Arm{
+			moveLock:    &sync.Mutex{},
+			logger:      golog.NewTestLogger(t),
+			settleDelay: 300 * time.Millisecond,
+			movePolicy:  policy,
+			moveSlot:    make(chan struct{}, 1),
+		}
+		start := time.Now()
+		firstDone := make(chan error, 1)
+		go func() {
+			firstDone <- a.MoveToJointPositions(context.Background(), jp, nil)
+		}()
+		for !a.opMgr.OpRunning() {
+			time.Sleep(time.Millisecond)
+		}
+		second = a.MoveToJointPositions(context.Background(), jp, nil)
+		first = <-firstDone
+		return first, second, time.Since(start)
+	}
+
+	t.Run("preempt", func(t *testing.T) {
+		first, second, took := concurrentMoves(MovePolicyPreempt)
+		test.That(t, first, test.ShouldBeError, context.Canceled)
+		test.That(t, second, test.ShouldBeNil)
+		test.That(t, took, test.ShouldBeLessThan, 900*time.Millisecond)
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		first, second, took := concurrentMoves(MovePolicyQueue)
+		test.That(t, first, test.ShouldBeNil)
+		test.That(t, second, test.ShouldBeNil)
+		test.That(t, took, test.ShouldBeGreaterThanOrEqualTo, time.Second)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		first, second, _ := concurrentMoves(MovePolicyReject)
+		test.That(t, first, test.ShouldBeNil)
+		test.That(t, second, test.ShouldBeError, arm.ErrBusy)
+	})
+
+	t.Run("queued moves give up with their context", func(t *testing.T) {
+		a := &Arm{moveLock: &sync.Mutex{}, logger: golog.NewTestLogger(t), movePolicy: MovePolicyQueue, moveSlot: make(chan struct{}, 1)}
+		a.moveSlot <- struct{}{}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		test.That(t, a.MoveToJointPositions(ctx, jp, nil), test.ShouldBeError, context.DeadlineExceeded)
+	})
+}
+
+func TestDeviationWatchdog(t *testing.T) {
+	cfg := &AttrConfig{UsbPort: "/dev/ttyUSB0", BaudRate: 1000000, ArmServoCount: 9, DeviationToleranceDegs: -1}
+	test.That(t, cfg.Validate("path"), test.ShouldBeError, "deviation_tolerance_degs cannot be negative")
+
+	// while moving, a joint may be anywhere between its start and target
+	test.That(t, deviation(2048, 3072, 2500, false), test.ShouldEqual, Degrees(0))
+	test.That(t, deviation(3072, 2048, 2500, false), test.ShouldEqual, Degrees(0))
+	test.That(t, deviation(2048, 3072, 1024, false), test.ShouldEqual, Degrees(90))
+	test.That(t, deviation(2048, 3072, 3584, false), test.ShouldEqual, Degrees(45))
+	test.That(t, deviation(2048, 3072, 2500, true), test.ShouldAlmostEqual, Degrees(50.27), 0.01)
+
+	// WaitForMovement polls the servos every 200ms, and there are none to wait for
+	a := &Arm{moveLock: &sync.Mutex{}, logger: golog.NewTestLogger(t)}
+	var halted map[string]ServoPos
+	watch := func(current map[string]ServoPos) *deviationWatchdog {
+		halted = nil
+		return &deviationWatchdog{
+			tolerance: 5,
+			start:     map[string]ServoPos{"Waist": 2048, "Shoulder": 2048},
+			target:    map[string]ServoPos{"Waist": 3072, "Shoulder": 2048},
+			read:      func() (map[string]ServoPos, error) { return current, nil },
+			halt:      func(current map[string]ServoPos) { halted = current },
+		}
+	}
+
+	test.That(t, a.waitForMovement(context.Background(), watch(map[string]ServoPos{"Waist": 3070, "Shoulder": 2050})), test.ShouldBeNil)
+	test.That(t, halted, test.ShouldBeNil)
+
+	// the waist was blocked on its way to the target
+	blocked := map[string]ServoPos{"Waist": 2500, "Shoulder": 2048}
+	err := a.waitForMovement(context.Background(), watch(blocked))
+	test.That(t, errors.Is(err, ErrDeviated), test.ShouldBeTrue)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "Waist is 50.3 degrees off")
+	test.That(t, halted, test.ShouldResemble, blocked)
+}
@@ -0,0 +1,41 @@
+package trossen
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/arm"
+)
+
+// The servos of the arm turn 360 degrees over 4096 steps and are centered at 2048, so that a joint
+// is at 0 degrees at servo position 2048 and can turn 180 degrees either way.
+const (
+	servoStepsPerTurn = 4096
+	servoCenter       = 2048
+	maxServoPos       = servoStepsPerTurn - 1
+)
+
+// ServoPos is the position of a servo in steps, 0-4095 centered at 2048. Positions read from
+// several servos of one joint are averaged, so they can fall between steps.
+type ServoPos float64
+
+// Degrees is the angle of a joint in degrees, 0 at the center of its servo. Joint positions of the
+// arm API are in degrees. The arm's model converts them to the radians of its kinematics.
+type Degrees float64
+
+// Degrees returns the joint angle of the servo position.
+func (p ServoPos) Degrees() Degrees {
+	return Degrees((p - servoCenter) * 360 / servoStepsPerTurn)
+}
+
+// ServoPos returns the servo position, truncated to a whole step, of the joint angle. Angles that
+// map outside of the servo's 0-4095 range are an error instead of being clamped by JointTo.
+func (d Degrees) ServoPos() (ServoPos, error) {
+	servoPos := ServoPos(math.Trunc(float64(servoCenter + d*servoStepsPerTurn/360)))
+	if servoPos < 0 || servoPos > maxServoPos {
+		return 0, errors.Wrapf(arm.ErrJointLimit, "%.2f degrees maps to servo position %.0f which is outside of 0-4095",
+			float64(d), float64(servoPos))
+	}
+	return servoPos, nil
+}
@@ -0,0 +1,61 @@
+package trossen
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// ErrDeviated is returned by moves that the arm was halted during because a joint strayed from
+// the path to its target, as when the arm hits something or is pushed.
+var ErrDeviated = errors.New("arm deviated from its commanded path and was halted")
+
+// A deviationWatchdog compares the joints of a move against the path from where they started to
+// their targets, and halts the arm when one strays further than the tolerance.
+type deviationWatchdog struct {
+	tolerance     Degrees
+	start, target map[string]ServoPos
+
+	read func() (map[string]ServoPos, error)
+	halt func(current map[string]ServoPos)
+}
+
+// deviation returns how far a joint at the given position is off its path. While the arm is moving
+// the joint may be anywhere between its start and target, once it has arrived only the target will do.
+func deviation(start, target, current ServoPos, arrived bool) Degrees {
+	low, high := math.Min(float64(start), float64(target)), math.Max(float64(start), float64(target))
+	if arrived {
+		low, high = float64(target), float64(target)
+	}
+	switch {
+	case float64(current) < low:
+		return ServoPos(low).Degrees() - current.Degrees()
+	case float64(current) > high:
+		return current.Degrees() - ServoPos(high).Degrees()
+	default:
+		return 0
+	}
+}
+
+// check reads the joints and, if one has strayed too far, halts the arm and returns ErrDeviated.
+func (w *deviationWatchdog) check(arrived bool) error {
+	current, err := w.read()
+	if err != nil {
+		return err
+	}
+	for joint, target := range w.target {
+		pos, ok := current[joint]
+		if !ok {
+			continue
+		}
+		start, ok := w.start[joint]
+		if !ok {
+			start = target
+		}
+		if off := deviation(start, target, pos, arrived); off > w.tolerance {
+			w.halt(current)
+			return errors.Wrapf(ErrDeviated, "%s is %.1f degrees off", joint, float64(off))
+		}
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"gopkg.in/src-d/go-billy.v4/memfs"
 
 	"go.viam.com/rdk/subtype"
+	rdkutils "go.viam.com/rdk/utils"
 )
 
 // HostEndian indicates the byte ordering this host natively uses.
@@ -55,11 +56,11 @@ func NewServer(s subtype.Service) pb.AudioInputServiceServer {
 func (s *subtypeServer) getAudioInput(name string) (AudioInput, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no audio input with name (%s)", name)
+		return nil, rdkutils.NewNotFoundByNameError("audio input", name)
 	}
 	audioInput, ok := resource.(AudioInput)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not an audio input", name)
+		return nil, rdkutils.NewWrongTypeByNameError("an audio input", name)
 	}
 	return audioInput, nil
 }
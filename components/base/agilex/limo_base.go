@@ -408,12 +408,10 @@ func (base *limoBase) SetPower(ctx context.Context, linear, angular r3.Vector, e
 
 func (base *limoBase) Stop(ctx context.Context, extra map[string]interface{}) error {
 	base.controller.logger.Debug("Stop()")
+	// cancel whatever is running even if zeroing the velocity fails so nothing restarts the motors
 	err := base.SetVelocity(ctx, r3.Vector{}, r3.Vector{}, extra)
-	if err != nil {
-		return err
-	}
 	base.opMgr.CancelRunning(ctx)
-	return nil
+	return err
 }
 
 func (base *limoBase) IsMoving(ctx context.Context) (bool, error) {
@@ -468,6 +466,16 @@ func (base *limoBase) Close(ctx context.Context) error {
 	return nil
 }
 
+// Properties reports that the limo can't spin in place while in ackermann mode.
+func (lb *limoBase) Properties(ctx context.Context, extra map[string]interface{}) (*base.Properties, error) {
+	return &base.Properties{
+		SpinSupported:     lb.driveMode != ACKERMANN.String(),
+		ArcSupported:      true,
+		VelocitySupported: true,
+		WidthMm:           lb.width,
+	}, nil
+}
+
 func (base *limoBase) Width(ctx context.Context) (int, error) {
 	return base.width, nil
 }
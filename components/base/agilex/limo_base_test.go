@@ -37,5 +37,11 @@ func TestLimoBaseConstructor(t *testing.T) {
 	test.That(t, ok, test.ShouldBeTrue)
 	width, _ := base.Width(ctx)
 	test.That(t, width, test.ShouldEqual, 172)
+
+	props, err := base.Properties(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, props.SpinSupported, test.ShouldBeFalse)
+	test.That(t, props.ArcSupported, test.ShouldBeTrue)
+	test.That(t, props.WidthMm, test.ShouldEqual, 172)
 	base.Close(ctx)
 }
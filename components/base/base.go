@@ -4,9 +4,11 @@ package base
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
+	"go.uber.org/multierr"
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/base/v1"
 	viamutils "go.viam.com/utils"
@@ -76,6 +78,8 @@ type Base interface {
 	SetVelocity(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error
 
 	// Stop stops the base. It is assumed the base stops immediately.
+	// Every motor is told to stop even when stopping another one fails, the errors are combined.
+	// A base wrapping another base must always forward Stop to it.
 	Stop(ctx context.Context, extra map[string]interface{}) error
 
 	generic.Generic
@@ -86,9 +90,25 @@ type LocalBase interface {
 	Base
 	// Width returns the width of the base in millimeters.
 	Width(ctx context.Context) (int, error)
+	// Properties returns what the base is able to do and its geometry.
+	Properties(ctx context.Context, extra map[string]interface{}) (*Properties, error)
 	resource.MovingCheckable
 }
 
+// Properties describes the capabilities and geometry of a base so that generic code,
+// like a UI, can tell which kinds of movement to offer.
+type Properties struct {
+	// SpinSupported is true if the base can turn in place.
+	SpinSupported bool
+	// ArcSupported is true if the base can move forward and turn at the same time.
+	ArcSupported bool
+	// VelocitySupported is true if SetVelocity is supported.
+	VelocitySupported bool
+	WidthMm           int
+	// WheelCircumferenceMm is zero when unknown or when the base has no wheels.
+	WheelCircumferenceMm int
+}
+
 var (
 	_ = Base(&reconfigurableBase{})
 	_ = LocalBase(&reconfigurableLocalBase{})
@@ -254,6 +274,12 @@ func (r *reconfigurableLocalBase) Width(ctx context.Context) (int, error) {
 	return r.actual.Width(ctx)
 }
 
+func (r *reconfigurableLocalBase) Properties(ctx context.Context, extra map[string]interface{}) (*Properties, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.actual.Properties(ctx, extra)
+}
+
 func (r *reconfigurableLocalBase) IsMoving(ctx context.Context) (bool, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -325,3 +351,19 @@ func DoMove(ctx context.Context, move Move, base Base) error {
 
 	return nil
 }
+
+// MoveFor drives the base at the given velocities for the given duration and then stops it.
+// The base is stopped even when ctx is cancelled before the duration is up, in which case
+// the error of ctx is returned.
+func MoveFor(ctx context.Context, base Base, linear, angular r3.Vector, duration time.Duration) error {
+	// stop with a fresh context since ctx may be the reason we are stopping
+	stop := func() error { return base.Stop(context.Background(), nil) }
+	if err := base.SetVelocity(ctx, linear, angular, nil); err != nil {
+		return multierr.Combine(err, stop())
+	}
+	var err error
+	if !viamutils.SelectContextOrWait(ctx, duration) {
+		err = ctx.Err()
+	}
+	return multierr.Combine(err, stop())
+}
@@ -3,7 +3,9 @@ package base_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/golang/geo/r3"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
@@ -357,3 +359,74 @@ func TestDoMove(t *testing.T) {
 		test.That(t, errors.Is(err, err1), test.ShouldBeTrue)
 	})
 }
+
+func TestMoveFor(t *testing.T) {
+	dev := &inject.Base{}
+	var setAt, stoppedAt time.Time
+	var velocity r3.Vector
+	dev.SetVelocityFunc = func(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+		setAt = time.Now()
+		velocity = linear
+		return nil
+	}
+	dev.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		test.That(t, ctx.Err(), test.ShouldBeNil)
+		stoppedAt = time.Now()
+		return nil
+	}
+
+	err := base.MoveFor(context.Background(), dev, r3.Vector{Y: 100}, r3.Vector{}, 50*time.Millisecond)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, velocity, test.ShouldResemble, r3.Vector{Y: 100})
+	test.That(t, stoppedAt.Sub(setAt), test.ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+
+	// cancelling stops the base early
+	stoppedAt = time.Time{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = base.MoveFor(ctx, dev, r3.Vector{Y: 100}, r3.Vector{}, time.Minute)
+	test.That(t, errors.Is(err, context.DeadlineExceeded), test.ShouldBeTrue)
+	test.That(t, stoppedAt.IsZero(), test.ShouldBeFalse)
+	test.That(t, stoppedAt.Sub(setAt), test.ShouldBeLessThan, time.Minute)
+
+	// the base is still stopped when setting the velocity fails
+	stoppedAt = time.Time{}
+	err1 := errors.New("oh no")
+	dev.SetVelocityFunc = func(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+		return err1
+	}
+	err = base.MoveFor(context.Background(), dev, r3.Vector{Y: 100}, r3.Vector{}, time.Minute)
+	test.That(t, errors.Is(err, err1), test.ShouldBeTrue)
+	test.That(t, stoppedAt.IsZero(), test.ShouldBeFalse)
+}
+
+func TestLimitSpeeds(t *testing.T) {
+	dev := &inject.Base{}
+	var linear, angular r3.Vector
+	dev.SetVelocityFunc = func(ctx context.Context, l, a r3.Vector, extra map[string]interface{}) error {
+		linear, angular = l, a
+		return nil
+	}
+	dev.WidthFunc = func(ctx context.Context) (int, error) {
+		return 300, nil
+	}
+	limiter := &base.SpeedLimiter{}
+	limited := base.LimitSpeeds(dev, limiter)
+	localBase, ok := limited.(base.LocalBase)
+	test.That(t, ok, test.ShouldBeTrue)
+	width, err := localBase.Width(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, width, test.ShouldEqual, 300)
+
+	// without caps velocities pass through
+	test.That(t, limited.SetVelocity(context.Background(), r3.Vector{X: 300, Y: 400}, r3.Vector{Z: -90}, nil), test.ShouldBeNil)
+	test.That(t, linear, test.ShouldResemble, r3.Vector{X: 300, Y: 400})
+	test.That(t, angular, test.ShouldResemble, r3.Vector{Z: -90})
+
+	// capped velocities keep their direction
+	limiter.SetLimits(100, 30)
+	test.That(t, limited.SetVelocity(context.Background(), r3.Vector{X: 300, Y: 400}, r3.Vector{Z: -90}, nil), test.ShouldBeNil)
+	test.That(t, linear.X, test.ShouldAlmostEqual, 60)
+	test.That(t, linear.Y, test.ShouldAlmostEqual, 80)
+	test.That(t, angular, test.ShouldResemble, r3.Vector{Z: -30})
+}
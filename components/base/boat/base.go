@@ -286,6 +286,16 @@ func (b *boat) Width(ctx context.Context) (int, error) {
 	return int(b.cfg.WidthMM), nil
 }
 
+// Properties reports velocity control only when the boat has an imu to close the loop with.
+func (b *boat) Properties(ctx context.Context, extra map[string]interface{}) (*base.Properties, error) {
+	return &base.Properties{
+		SpinSupported:     true,
+		ArcSupported:      true,
+		VelocitySupported: b.imu != nil,
+		WidthMm:           int(b.cfg.WidthMM),
+	}, nil
+}
+
 func (b *boat) IsMoving(ctx context.Context) (bool, error) {
 	for _, m := range b.motors {
 		isMoving, _, err := m.IsPowered(ctx, nil)
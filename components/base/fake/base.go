@@ -64,6 +64,16 @@ func (b *Base) Width(ctx context.Context) (int, error) {
 	return 600, nil
 }
 
+// Properties reports every capability and the same arbitrary width.
+func (b *Base) Properties(ctx context.Context, extra map[string]interface{}) (*base.Properties, error) {
+	return &base.Properties{
+		SpinSupported:     true,
+		ArcSupported:      true,
+		VelocitySupported: true,
+		WidthMm:           600,
+	}, nil
+}
+
 // Stop does nothing.
 func (b *Base) Stop(ctx context.Context, extra map[string]interface{}) error {
 	return nil
@@ -0,0 +1,258 @@
+// Package obstaclestop implements a base that refuses to drive into obstacles seen by a forward facing lidar.
+package obstaclestop
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/registry"
+)
+
+const modelname = "obstacle-stop"
+
+const defaultPollMS = 100
+
+// Config is how you configure an obstacle stopping base.
+type Config struct {
+	Base          string  `json:"base"`
+	Lidar         string  `json:"lidar"`
+	MinDistanceMM float64 `json:"min_distance_mm"`
+	// ForwardAxis is the axis of the lidar's point cloud that points the way the base drives,
+	// one of x, y or z. Defaults to x, cameras usually want z.
+	ForwardAxis string `json:"forward_axis,omitempty"`
+	// PollMS is how often the lidar is checked while the base moves forward.
+	PollMS int `json:"poll_ms,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (config *Config) Validate(path string) ([]string, error) {
+	if config.Base == "" {
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "base")
+	}
+	if config.Lidar == "" {
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "lidar")
+	}
+	if config.MinDistanceMM <= 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("min_distance_mm must be greater than 0"))
+	}
+	if _, err := forwardFunc(config.ForwardAxis); err != nil {
+		return nil, utils.NewConfigValidationError(path, err)
+	}
+	if config.PollMS < 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("poll_ms cannot be negative"))
+	}
+	return []string{config.Base, config.Lidar}, nil
+}
+
+func init() {
+	registry.RegisterComponent(base.Subtype, modelname, registry.Component{
+		Constructor: func(
+			ctx context.Context, deps registry.Dependencies, config config.Component, logger golog.Logger,
+		) (interface{}, error) {
+			conf := config.ConvertedAttributes.(*Config)
+			b, err := base.FromDependencies(deps, conf.Base)
+			if err != nil {
+				return nil, err
+			}
+			lidar, err := camera.FromDependencies(deps, conf.Lidar)
+			if err != nil {
+				return nil, err
+			}
+			forward, err := forwardFunc(conf.ForwardAxis)
+			if err != nil {
+				return nil, err
+			}
+			o := AugmentWithObstacleStop(b, lidar, conf.MinDistanceMM, logger).(*obstacleStop)
+			o.forward = forward
+			if conf.PollMS > 0 {
+				o.pollInterval = time.Duration(conf.PollMS) * time.Millisecond
+			}
+			return o, nil
+		},
+	})
+
+	config.RegisterComponentAttributeMapConverter(
+		base.SubtypeName,
+		modelname,
+		func(attributes config.AttributeMap) (interface{}, error) {
+			var conf Config
+			return config.TransformAttributeMapToStruct(&conf, attributes)
+		},
+		&Config{})
+}
+
+func forwardFunc(axis string) (func(r3.Vector) float64, error) {
+	switch axis {
+	case "", "x":
+		return func(p r3.Vector) float64 { return p.X }, nil
+	case "y":
+		return func(p r3.Vector) float64 { return p.Y }, nil
+	case "z":
+		return func(p r3.Vector) float64 { return p.Z }, nil
+	default:
+		return nil, fmt.Errorf("forward_axis must be one of x, y or z, not %q", axis)
+	}
+}
+
+// AugmentWithObstacleStop returns a base that refuses to move forward when the lidar sees
+// something closer than minDistanceMm in front of it, and stops when something shows up
+// while it is already moving forward. Spinning and reversing are never blocked since a
+// forward facing lidar can't see what is in the way. The lidar's x axis is taken to point forward.
+func AugmentWithObstacleStop(b base.Base, lidar camera.Camera, minDistanceMm float64, logger golog.Logger) base.Base {
+	return &obstacleStop{
+		actual:       b,
+		lidar:        lidar,
+		minDistance:  minDistanceMm,
+		forward:      func(p r3.Vector) float64 { return p.X },
+		pollInterval: defaultPollMS * time.Millisecond,
+		logger:       logger,
+	}
+}
+
+type obstacleStop struct {
+	generic.Unimplemented
+	actual       base.Base
+	lidar        camera.Camera
+	minDistance  float64
+	forward      func(r3.Vector) float64
+	pollInterval time.Duration
+	logger       golog.Logger
+
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	waitGroup sync.WaitGroup
+	blocked   error
+}
+
+// obstacleError returns an error if the lidar sees something in front of the base within the minimum distance.
+func (o *obstacleStop) obstacleError(ctx context.Context) error {
+	pc, err := o.lidar.NextPointCloud(ctx)
+	if err != nil {
+		return err
+	}
+	closest := math.Inf(1)
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		if o.forward(p) > 0 {
+			closest = math.Min(closest, p.Norm())
+		}
+		return true
+	})
+	if closest <= o.minDistance {
+		return fmt.Errorf("obstacle %.0fmm ahead is within the %.0fmm safety distance", closest, o.minDistance)
+	}
+	return nil
+}
+
+// watch stops any running watcher and, when the base is about to move forward, checks that the
+// way is clear and starts watching the lidar until the next command.
+func (o *obstacleStop) watch(ctx context.Context, forward bool) error {
+	o.stopWatching()
+	o.mu.Lock()
+	o.blocked = nil
+	o.mu.Unlock()
+	if !forward {
+		return nil
+	}
+	if err := o.obstacleError(ctx); err != nil {
+		return err
+	}
+
+	var cancelCtx context.Context
+	o.mu.Lock()
+	cancelCtx, o.cancel = context.WithCancel(context.Background())
+	o.mu.Unlock()
+
+	o.waitGroup.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer o.waitGroup.Done()
+		for utils.SelectContextOrWait(cancelCtx, o.pollInterval) {
+			err := o.obstacleError(cancelCtx)
+			if err == nil {
+				continue
+			}
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			o.logger.Warnw("stopping base", "error", err)
+			o.mu.Lock()
+			o.blocked = err
+			o.mu.Unlock()
+			if err := o.actual.Stop(context.Background(), nil); err != nil {
+				o.logger.Errorw("failed to stop base", "error", err)
+			}
+			return
+		}
+	})
+	return nil
+}
+
+func (o *obstacleStop) stopWatching() {
+	o.mu.Lock()
+	cancel := o.cancel
+	o.cancel = nil
+	o.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		o.waitGroup.Wait()
+	}
+}
+
+func (o *obstacleStop) MoveStraight(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+	if err := o.watch(ctx, float64(distanceMm)*mmPerSec > 0); err != nil {
+		return err
+	}
+	err := o.actual.MoveStraight(ctx, distanceMm, mmPerSec, extra)
+	o.stopWatching()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.blocked != nil {
+		return o.blocked
+	}
+	return err
+}
+
+func (o *obstacleStop) Spin(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
+	o.stopWatching()
+	return o.actual.Spin(ctx, angleDeg, degsPerSec, extra)
+}
+
+func (o *obstacleStop) SetPower(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+	if err := o.watch(ctx, linear.Y > 0); err != nil {
+		return err
+	}
+	return o.actual.SetPower(ctx, linear, angular, extra)
+}
+
+func (o *obstacleStop) SetVelocity(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+	if err := o.watch(ctx, linear.Y > 0); err != nil {
+		return err
+	}
+	return o.actual.SetVelocity(ctx, linear, angular, extra)
+}
+
+func (o *obstacleStop) Stop(ctx context.Context, extra map[string]interface{}) error {
+	o.stopWatching()
+	return o.actual.Stop(ctx, extra)
+}
+
+func (o *obstacleStop) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return o.actual.DoCommand(ctx, cmd)
+}
+
+func (o *obstacleStop) Close(ctx context.Context) error {
+	o.stopWatching()
+	return nil
+}
@@ -0,0 +1,175 @@
+package obstaclestop
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/base/fake"
+	"go.viam.com/rdk/components/base/wheeled"
+	"go.viam.com/rdk/components/motor"
+	fakemotor "go.viam.com/rdk/components/motor/fake"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+// fakeLidar sees a single point straight ahead at the given distance.
+type fakeLidar struct {
+	mu       sync.Mutex
+	distance float64
+}
+
+func (fl *fakeLidar) setDistance(d float64) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	fl.distance = d
+}
+
+func (fl *fakeLidar) nextPointCloud(ctx context.Context) (pointcloud.PointCloud, error) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	pc := pointcloud.New()
+	// something behind the lidar is always close, it must not block moving forward
+	if err := pc.Set(pointcloud.NewVector(-10, 0, 0), pointcloud.NewBasicData()); err != nil {
+		return nil, err
+	}
+	if err := pc.Set(pointcloud.NewVector(fl.distance, 0, 0), pointcloud.NewBasicData()); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+func TestObstacleStop(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	lidar := &fakeLidar{distance: 1000}
+	injectLidar := &inject.Camera{NextPointCloudFunc: lidar.nextPointCloud}
+
+	var mu sync.Mutex
+	var velocities []r3.Vector
+	stops := 0
+	injectBase := &inject.Base{LocalBase: &fake.Base{}}
+	injectBase.SetVelocityFunc = func(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		velocities = append(velocities, linear)
+		return nil
+	}
+	injectBase.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stops++
+		return nil
+	}
+
+	b := AugmentWithObstacleStop(injectBase, injectLidar, 300, logger).(*obstacleStop)
+	b.pollInterval = time.Millisecond
+	defer func() {
+		test.That(t, b.Close(ctx), test.ShouldBeNil)
+	}()
+
+	t.Run("clear path", func(t *testing.T) {
+		err := b.SetVelocity(ctx, r3.Vector{Y: 100}, r3.Vector{}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		mu.Lock()
+		defer mu.Unlock()
+		test.That(t, velocities, test.ShouldHaveLength, 1)
+	})
+
+	t.Run("stops when an obstacle appears", func(t *testing.T) {
+		lidar.setDistance(200)
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			mu.Lock()
+			defer mu.Unlock()
+			test.That(tb, stops, test.ShouldEqual, 1)
+		})
+	})
+
+	t.Run("refuses to move toward a close obstacle", func(t *testing.T) {
+		err := b.SetVelocity(ctx, r3.Vector{Y: 100}, r3.Vector{}, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "obstacle 200mm ahead")
+
+		err = b.MoveStraight(ctx, 100, 100, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		mu.Lock()
+		test.That(t, velocities, test.ShouldHaveLength, 1)
+		mu.Unlock()
+	})
+
+	t.Run("reversing and spinning are allowed", func(t *testing.T) {
+		err := b.SetVelocity(ctx, r3.Vector{Y: -100}, r3.Vector{}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, b.MoveStraight(ctx, -100, 100, nil), test.ShouldBeNil)
+		test.That(t, b.Spin(ctx, 90, 10, nil), test.ShouldBeNil)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		conf := &Config{Base: "base", Lidar: "lidar", MinDistanceMM: 300}
+		deps, err := conf.Validate("path")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, deps, test.ShouldResemble, []string{"base", "lidar"})
+
+		conf.ForwardAxis = "w"
+		_, err = conf.Validate("path")
+		test.That(t, err, test.ShouldNotBeNil)
+
+		conf.ForwardAxis = "z"
+		conf.MinDistanceMM = 0
+		_, err = conf.Validate("path")
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+func TestStopReachesMotors(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	var mu sync.Mutex
+	stopped := map[string]bool{}
+	deps := registry.Dependencies{}
+	for _, name := range []string{"fl", "fr", "bl", "br"} {
+		name := name
+		m := &inject.Motor{Motor: &fakemotor.Motor{MaxRPM: 60, Logger: logger}}
+		m.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped[name] = true
+			if name == "fl" {
+				return errors.New("fl is stuck")
+			}
+			return nil
+		}
+		deps[motor.Named(name)] = m
+	}
+	wheeledBase, err := wheeled.CreateWheeledBase(ctx, deps, &wheeled.Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 100,
+		Left:                 []string{"fl", "bl"},
+		Right:                []string{"fr", "br"},
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	b := AugmentWithObstacleStop(wheeledBase, &inject.Camera{}, 300, logger)
+	defer func() {
+		test.That(t, b.(*obstacleStop).Close(ctx), test.ShouldBeNil)
+	}()
+
+	// one motor failing to stop must not keep the others running
+	err = b.Stop(ctx, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "fl is stuck")
+	mu.Lock()
+	defer mu.Unlock()
+	test.That(t, stopped, test.ShouldResemble, map[string]bool{"fl": true, "fr": true, "bl": true, "br": true})
+}
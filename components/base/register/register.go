@@ -6,5 +6,6 @@ import (
 	_ "go.viam.com/rdk/components/base/agilex"
 	_ "go.viam.com/rdk/components/base/boat"
 	_ "go.viam.com/rdk/components/base/fake"
+	_ "go.viam.com/rdk/components/base/obstaclestop"
 	_ "go.viam.com/rdk/components/base/wheeled"
 )
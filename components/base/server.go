@@ -4,12 +4,12 @@ package base
 import (
 	"context"
 
-	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/base/v1"
 
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
 // subtypeServer implements the BaseService from base.proto.
@@ -27,11 +27,11 @@ func NewServer(s subtype.Service) pb.BaseServiceServer {
 func (s *subtypeServer) getBase(name string) (Base, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no base with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("base", name)
 	}
 	base, ok := resource.(Base)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a base", name)
+		return nil, utils.NewWrongTypeByNameError("a base", name)
 	}
 	return base, nil
 }
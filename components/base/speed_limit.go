@@ -0,0 +1,110 @@
+package base
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/golang/geo/r3"
+	viamutils "go.viam.com/utils"
+)
+
+// A SpeedLimiter holds the speed caps of the bases it limits. The caps can be changed or lifted
+// while the bases are in use, so a robot can turn a safe mode on and off without rebuilding them.
+type SpeedLimiter struct {
+	mu            sync.RWMutex
+	maxMMPerSec   float64
+	maxDegsPerSec float64
+}
+
+// SetLimits caps linear speeds at maxMMPerSec and angular speeds at maxDegsPerSec, a cap of 0 lifts it.
+func (l *SpeedLimiter) SetLimits(maxMMPerSec, maxDegsPerSec float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxMMPerSec = maxMMPerSec
+	l.maxDegsPerSec = maxDegsPerSec
+}
+
+// Limits returns the linear and angular speed caps, 0 when there is none.
+func (l *SpeedLimiter) Limits() (maxMMPerSec, maxDegsPerSec float64) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.maxMMPerSec, l.maxDegsPerSec
+}
+
+// capSpeed returns speed with its magnitude capped at limit, unless limit is 0.
+func capSpeed(speed, limit float64) float64 {
+	if limit == 0 || math.Abs(speed) <= limit {
+		return speed
+	}
+	return math.Copysign(limit, speed)
+}
+
+// capVelocity returns v scaled down to a norm of at most limit, unless limit is 0.
+func capVelocity(v r3.Vector, limit float64) r3.Vector {
+	if norm := v.Norm(); limit != 0 && norm > limit {
+		return v.Mul(limit / norm)
+	}
+	return v
+}
+
+// LimitSpeeds returns a base that never moves faster than the caps of the limiter, whatever speed it is
+// asked for. Moves still cover the distance or angle asked for, they just take longer. Spins skip any
+// speed floor of the base while angular speeds are capped. The returned base is a LocalBase if b is.
+func LimitSpeeds(b Base, limiter *SpeedLimiter) Base {
+	limited := &speedLimitedBase{Base: b, limiter: limiter}
+	if localBase, ok := b.(LocalBase); ok {
+		return &speedLimitedLocalBase{speedLimitedBase: limited, actual: localBase}
+	}
+	return limited
+}
+
+type speedLimitedBase struct {
+	Base
+	limiter *SpeedLimiter
+}
+
+func (b *speedLimitedBase) MoveStraight(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+	maxMMPerSec, _ := b.limiter.Limits()
+	return b.Base.MoveStraight(ctx, distanceMm, capSpeed(mmPerSec, maxMMPerSec), extra)
+}
+
+func (b *speedLimitedBase) Spin(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
+	_, maxDegsPerSec := b.limiter.Limits()
+	if maxDegsPerSec != 0 {
+		withoutFloor := map[string]interface{}{"no_spin_floor": true}
+		for k, v := range extra {
+			if k != "no_spin_floor" {
+				withoutFloor[k] = v
+			}
+		}
+		extra = withoutFloor
+	}
+	return b.Base.Spin(ctx, angleDeg, capSpeed(degsPerSec, maxDegsPerSec), extra)
+}
+
+func (b *speedLimitedBase) SetVelocity(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+	maxMMPerSec, maxDegsPerSec := b.limiter.Limits()
+	return b.Base.SetVelocity(ctx, capVelocity(linear, maxMMPerSec), capVelocity(angular, maxDegsPerSec), extra)
+}
+
+func (b *speedLimitedBase) Close(ctx context.Context) error {
+	return viamutils.TryClose(ctx, b.Base)
+}
+
+type speedLimitedLocalBase struct {
+	*speedLimitedBase
+	actual LocalBase
+}
+
+func (b *speedLimitedLocalBase) Width(ctx context.Context) (int, error) {
+	return b.actual.Width(ctx)
+}
+
+func (b *speedLimitedLocalBase) Properties(ctx context.Context, extra map[string]interface{}) (*Properties, error) {
+	return b.actual.Properties(ctx, extra)
+}
+
+func (b *speedLimitedLocalBase) IsMoving(ctx context.Context) (bool, error) {
+	return b.actual.IsMoving(ctx)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/edaniels/golog"
@@ -29,6 +30,25 @@ type Config struct {
 	SpinSlipFactor       float64  `json:"spin_slip_factor,omitempty"`
 	Left                 []string `json:"left"`
 	Right                []string `json:"right"`
+	// MinSpinRPM is the slowest the wheels turn while spinning. Wheels pushing sideways against
+	// the ground need more torque to get going than to keep going (stiction), so slow spins can
+	// stall without a floor. A spin can skip the floor with extra["no_spin_floor"] = true.
+	MinSpinRPM float64 `json:"min_spin_rpm,omitempty"`
+	// MinTurningRadiusMM is the tightest circle the center of the base can turn on, for bases
+	// that cannot turn in place, like ones with offset casters. When it is set Spin is refused
+	// and SetVelocity turns no tighter than it.
+	MinTurningRadiusMM float64 `json:"min_turning_radius_mm,omitempty"`
+	// RampDownMM is how far before the end of a straight move the base starts slowing down, so it
+	// stops smoothly and overshoots less instead of halting from full speed.
+	RampDownMM float64 `json:"ramp_down_mm,omitempty"`
+	// StraightToleranceMM turns on closed loop straight moves: after the move the encoders of the
+	// motors are read and each wheel is corrected until the average distance traveled is within
+	// this tolerance of the target. Every motor must report its position.
+	StraightToleranceMM float64 `json:"straight_tolerance_mm,omitempty"`
+	// SelfTest pulses each motor back and forth when the base is created, checking that the
+	// encoders of motors that report positions respond, so wiring faults are caught at startup
+	// rather than on the first move. The base is not created when a motor fails.
+	SelfTest bool `json:"self_test,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -43,6 +63,22 @@ func (config *Config) Validate(path string) ([]string, error) {
 		return nil, utils.NewConfigValidationFieldRequiredError(path, "wheel_circumference_mm")
 	}
 
+	if config.MinSpinRPM < 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("min_spin_rpm cannot be negative"))
+	}
+
+	if config.MinTurningRadiusMM < 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("min_turning_radius_mm cannot be negative"))
+	}
+
+	if config.RampDownMM < 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("ramp_down_mm cannot be negative"))
+	}
+
+	if config.StraightToleranceMM < 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("straight_tolerance_mm cannot be negative"))
+	}
+
 	if len(config.Left) == 0 {
 		return nil, utils.NewConfigValidationFieldRequiredError(path, "left")
 	}
@@ -87,40 +123,64 @@ type wheeledBase struct {
 	widthMm              int
 	wheelCircumferenceMm int
 	spinSlipFactor       float64
+	minSpinRPM           float64
+	minTurningRadiusMm   float64
+	rampDownMm           float64
+	straightToleranceMm  float64
 
-	left      []motor.Motor
-	right     []motor.Motor
-	allMotors []motor.Motor
+	left         []motor.Motor
+	right        []motor.Motor
+	allMotors    []motor.Motor
+	motorsByName map[string]motor.Motor
 
 	opMgr operation.SingleOperationManager
+	// mu serializes the motor commands of moves. A move preempts the one running by cancelling it,
+	// and only starts commanding the motors once the cancelled move has returned. Stop does not
+	// wait, a cancelled move stops the motors it started on its way out.
+	mu sync.Mutex
 }
 
-func (base *wheeledBase) Spin(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
+func (base *wheeledBase) Spin(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) (err error) {
+	callerCtx := ctx
 	ctx, done := base.opMgr.New(ctx)
 	defer done()
+	defer func() { err = unlessPreempted(callerCtx, ctx, err) }()
+	base.mu.Lock()
+	defer base.mu.Unlock()
 
 	// Stop the motors if the speed is 0
 	if math.Abs(degsPerSec) < 0.0001 {
-		err := base.Stop(ctx, nil)
+		err := base.stopMotors(ctx, nil)
 		if err != nil {
 			return errors.Errorf("error when trying to spin at a speed of 0: %v", err)
 		}
 		return err
 	}
 
+	if base.minTurningRadiusMm > 0 {
+		return errors.Errorf("cannot spin in place with a minimum turning radius of %v mm", base.minTurningRadiusMm)
+	}
+
 	// Spin math
 	rpm, revolutions := base.spinMath(angleDeg, degsPerSec)
+	if noFloor, ok := extra["no_spin_floor"].(bool); !(ok && noFloor) && rpm != 0 && math.Abs(rpm) < base.minSpinRPM {
+		rpm = math.Copysign(base.minSpinRPM, rpm)
+	}
 
 	return base.runAll(ctx, -rpm, revolutions, rpm, revolutions)
 }
 
-func (base *wheeledBase) MoveStraight(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+func (base *wheeledBase) MoveStraight(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) (err error) {
+	callerCtx := ctx
 	ctx, done := base.opMgr.New(ctx)
 	defer done()
+	defer func() { err = unlessPreempted(callerCtx, ctx, err) }()
+	base.mu.Lock()
+	defer base.mu.Unlock()
 
 	// Stop the motors if the speed or distance are 0
 	if math.Abs(mmPerSec) < 0.0001 || distanceMm == 0 {
-		err := base.Stop(ctx, nil)
+		err := base.stopMotors(ctx, nil)
 		if err != nil {
 			return errors.Errorf("error when trying to move straight at a speed and/or distance of 0: %v", err)
 		}
@@ -130,22 +190,170 @@ func (base *wheeledBase) MoveStraight(ctx context.Context, distanceMm int, mmPer
 	// Straight math
 	rpm, rotations := base.straightDistanceToMotorInfo(distanceMm, mmPerSec)
 
-	return base.runAll(ctx, rpm, rotations, rpm, rotations)
+	var start []float64
+	if base.straightToleranceMm > 0 {
+		var err error
+		if start, err = base.positions(ctx); err != nil {
+			return err
+		}
+	}
+	if err := base.driveStraight(ctx, base.rampDown(rpm, rotations)); err != nil {
+		return err
+	}
+	if base.straightToleranceMm > 0 {
+		// the wheels move forward when rpm and rotations have the same sign
+		if rpm < 0 {
+			rotations = -rotations
+		}
+		return base.correctStraight(ctx, start, rotations, math.Abs(rpm))
+	}
+	return nil
+}
+
+// unlessPreempted returns the error of a move, unless the move was cancelled by Stop or another
+// move rather than by its caller, which is not a failure of the move.
+func unlessPreempted(callerCtx, opCtx context.Context, err error) error {
+	if err != nil && opCtx.Err() != nil && callerCtx.Err() == nil {
+		return nil
+	}
+	return err
+}
+
+// maxStraightCorrections bounds how many times the wheels are corrected after a closed loop straight move.
+const maxStraightCorrections = 10
+
+// correctStraight drives each wheel the rest of the way to the target rotations from its start
+// position until the average of what the wheels traveled is within tolerance of the target. While
+// the average is off, at least one wheel is off by more than the tolerance and is driven.
+func (base *wheeledBase) correctStraight(ctx context.Context, start []float64, target, rpm float64) error {
+	for i := 0; ; i++ {
+		current, err := base.positions(ctx)
+		if err != nil {
+			return err
+		}
+		traveled := 0.
+		for j := range current {
+			traveled += current[j] - start[j]
+		}
+		traveled /= float64(len(current))
+		if math.Abs(target-traveled)*float64(base.wheelCircumferenceMm) <= base.straightToleranceMm {
+			return nil
+		}
+		if i == maxStraightCorrections {
+			return errors.Errorf("straight move is %.1f mm off after %d corrections",
+				(target-traveled)*float64(base.wheelCircumferenceMm), maxStraightCorrections)
+		}
+
+		// a wheel that is already within tolerance is left alone, and never asked to go 0 rotations,
+		// which motors take as going on forever
+		fs := []rdkutils.SimpleFunc{}
+		for j, m := range base.allMotors {
+			m := m
+			remaining := target - (current[j] - start[j])
+			if math.Abs(remaining)*float64(base.wheelCircumferenceMm) <= base.straightToleranceMm {
+				continue
+			}
+			fs = append(fs, func(ctx context.Context) error { return m.GoFor(ctx, rpm, remaining, nil) })
+		}
+		if _, err := rdkutils.RunInParallel(ctx, fs); err != nil {
+			return multierr.Combine(err, base.stopMotors(ctx, nil))
+		}
+	}
+}
+
+// positions returns the encoder position of every motor, in the order of allMotors.
+func (base *wheeledBase) positions(ctx context.Context) ([]float64, error) {
+	positions := make([]float64, 0, len(base.allMotors))
+	for _, m := range base.allMotors {
+		pos, err := m.Position(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, pos)
+	}
+	return positions, nil
+}
+
+// rampDownSteps is how many progressively slower segments the ramp down of a straight move is split into.
+const rampDownSteps = 4
+
+type straightSegment struct {
+	rpm, rotations float64
 }
 
+// rampDown splits a straight move into a segment at full speed followed by segments that cover
+// the ramp down distance at decreasing speeds. Without a ramp down the move is a single segment.
+func (base *wheeledBase) rampDown(rpm, rotations float64) []straightSegment {
+	if base.rampDownMm == 0 {
+		return []straightSegment{{rpm, rotations}}
+	}
+	rampRotations := math.Min(base.rampDownMm/float64(base.wheelCircumferenceMm), math.Abs(rotations))
+	var segments []straightSegment
+	if cruise := math.Abs(rotations) - rampRotations; cruise > 0 {
+		segments = append(segments, straightSegment{rpm, math.Copysign(cruise, rotations)})
+	}
+	for i := 0; i < rampDownSteps; i++ {
+		segments = append(segments, straightSegment{
+			rpm * float64(rampDownSteps-i) / float64(rampDownSteps+1),
+			math.Copysign(rampRotations/rampDownSteps, rotations),
+		})
+	}
+	return segments
+}
+
+// driveStraight drives the segments of a straight move without stopping the wheels in between. Every
+// segment but the last sets the speed of the running wheels, like SetVelocity, for as long as covering
+// its rotations takes at that speed. The last segment is a single GoFor, which stops the wheels at the
+// end of the move. Segments are timed rather than measured, straight_tolerance_mm corrects the distance.
+func (base *wheeledBase) driveStraight(ctx context.Context, segments []straightSegment) error {
+	last := segments[len(segments)-1]
+	for _, segment := range segments[:len(segments)-1] {
+		// a wheel running without a distance goes the way its rpm points
+		rpm := segment.rpm
+		if segment.rotations < 0 {
+			rpm = -rpm
+		}
+		if err := base.runAll(ctx, rpm, 0, rpm, 0); err != nil {
+			return err
+		}
+		if !utils.SelectContextOrWait(ctx, time.Duration(math.Abs(segment.rotations/segment.rpm)*float64(time.Minute))) {
+			return multierr.Combine(ctx.Err(), base.stopMotors(ctx, nil))
+		}
+	}
+	return base.runAll(ctx, last.rpm, last.rotations, last.rpm, last.rotations)
+}
+
+// runAll drives every motor at once. The first motor to fail stops every motor right away, rather
+// than once the others finish, so that a single motor fault does not drive the base in a circle.
 func (base *wheeledBase) runAll(ctx context.Context, leftRPM, leftRotations, rightRPM, rightRotations float64) error {
-	fs := []rdkutils.SimpleFunc{}
+	// motors that have yet to start are cancelled before the others are stopped
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var stopOnce sync.Once
+	var stopErr error
+	goFor := func(m motor.Motor, rpm, rotations float64) rdkutils.SimpleFunc {
+		return func(context.Context) error {
+			err := m.GoFor(runCtx, rpm, rotations, nil)
+			if err != nil {
+				stopOnce.Do(func() {
+					cancel()
+					stopErr = base.stopMotors(ctx, nil)
+				})
+			}
+			return err
+		}
+	}
 
+	fs := []rdkutils.SimpleFunc{}
 	for _, m := range base.left {
-		fs = append(fs, func(ctx context.Context) error { return m.GoFor(ctx, leftRPM, leftRotations, nil) })
+		fs = append(fs, goFor(m, leftRPM, leftRotations))
 	}
-
 	for _, m := range base.right {
-		fs = append(fs, func(ctx context.Context) error { return m.GoFor(ctx, rightRPM, rightRotations, nil) })
+		fs = append(fs, goFor(m, rightRPM, rightRotations))
 	}
 
 	if _, err := rdkutils.RunInParallel(ctx, fs); err != nil {
-		return multierr.Combine(err, base.Stop(ctx, nil))
+		return multierr.Combine(err, stopErr)
 	}
 	return nil
 }
@@ -191,12 +399,24 @@ func (base *wheeledBase) differentialDrive(forward, left float64) (float64, floa
 
 func (base *wheeledBase) SetVelocity(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
 	base.opMgr.CancelRunning(ctx)
-	l, r := base.velocityMath(linear.Y, angular.Z)
+	base.mu.Lock()
+	defer base.mu.Unlock()
+	// a zero velocity means stop, motors refuse to go at zero rpm
+	if linear.Y == 0 && angular.Z == 0 {
+		return base.stopMotors(ctx, extra)
+	}
+	degsPerSec, err := base.limitTurn(linear.Y, angular.Z)
+	if err != nil {
+		return err
+	}
+	l, r := base.velocityMath(linear.Y, degsPerSec)
 	return base.runAll(ctx, l, 0, r, 0)
 }
 
 func (base *wheeledBase) SetPower(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
 	base.opMgr.CancelRunning(ctx)
+	base.mu.Lock()
+	defer base.mu.Unlock()
 
 	lPower, rPower := base.differentialDrive(linear.Y, angular.Z)
 
@@ -211,12 +431,75 @@ func (base *wheeledBase) SetPower(ctx context.Context, linear, angular r3.Vector
 	}
 
 	if err != nil {
-		return multierr.Combine(err, base.Stop(ctx, nil))
+		return multierr.Combine(err, base.stopMotors(ctx, nil))
 	}
 
 	return nil
 }
 
+// SetWheelPowers sets the power of each named wheel motor directly, bypassing the drive math, for
+// diagnosing wiring and tuning controllers. Powers are clamped to [-1, 1], and wheels not named are
+// left as they are.
+func (base *wheeledBase) SetWheelPowers(ctx context.Context, powers map[string]float64, extra map[string]interface{}) error {
+	for name := range powers {
+		if _, ok := base.motorsByName[name]; !ok {
+			return errors.Errorf("no wheel motor named %q", name)
+		}
+	}
+
+	base.opMgr.CancelRunning(ctx)
+	base.mu.Lock()
+	defer base.mu.Unlock()
+
+	var err error
+	for name, power := range powers {
+		power = math.Max(-1, math.Min(1, power))
+		err = multierr.Combine(err, base.motorsByName[name].SetPower(ctx, power, extra))
+	}
+	if err != nil {
+		return multierr.Combine(err, base.stopMotors(ctx, nil))
+	}
+	return nil
+}
+
+// DoCommand runs the commands of the wheeled base that have no place in its API:
+// "set_wheel_powers" with "powers", a map of wheel motor name to power.
+func (base *wheeledBase) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return generic.Commands{
+		"set_wheel_powers": {
+			Args: []generic.Arg{{Name: "powers", Type: generic.ArgMap, Required: true}},
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				raw := cmd["powers"].(map[string]interface{})
+				powers := make(map[string]float64, len(raw))
+				for wheel, v := range raw {
+					power, ok := v.(float64)
+					if !ok {
+						return nil, errors.Errorf("power of %q must be a number, not %T", wheel, v)
+					}
+					powers[wheel] = power
+				}
+				return nil, base.SetWheelPowers(ctx, powers, nil)
+			},
+		},
+	}.DoCommand(ctx, cmd)
+}
+
+// limitTurn returns the angular velocity closest to degsPerSec that turns no tighter than the
+// minimum turning radius while moving at mmPerSec.
+func (base *wheeledBase) limitTurn(mmPerSec, degsPerSec float64) (float64, error) {
+	if base.minTurningRadiusMm == 0 || degsPerSec == 0 {
+		return degsPerSec, nil
+	}
+	if mmPerSec == 0 {
+		return 0, errors.Errorf("cannot turn in place with a minimum turning radius of %v mm", base.minTurningRadiusMm)
+	}
+	maxDegsPerSec := rdkutils.RadToDeg(math.Abs(mmPerSec) / base.minTurningRadiusMm)
+	if math.Abs(degsPerSec) > maxDegsPerSec {
+		return math.Copysign(maxDegsPerSec, degsPerSec), nil
+	}
+	return degsPerSec, nil
+}
+
 // returns rpm, revolutions for a spin motion.
 func (base *wheeledBase) spinMath(angleDeg, degsPerSec float64) (float64, float64) {
 	wheelTravel := base.spinSlipFactor * float64(base.widthMm) * math.Pi * angleDeg / 360.0
@@ -283,12 +566,21 @@ func (base *wheeledBase) WaitForMotorsToStop(ctx context.Context) error {
 
 		if anyOff {
 			// once one motor turns off, we turn them all off
-			return base.Stop(ctx, nil)
+			return base.stopMotors(ctx, nil)
 		}
 	}
 }
 
+// Stop stops the motors and cancels the move in progress, if any, so that the move does not go on
+// to start them again, for example to ramp down. It does not wait for the move to return.
 func (base *wheeledBase) Stop(ctx context.Context, extra map[string]interface{}) error {
+	err := base.stopMotors(ctx, extra)
+	base.opMgr.CancelRunning(ctx)
+	return err
+}
+
+// stopMotors stops every motor, for moves to stop themselves.
+func (base *wheeledBase) stopMotors(ctx context.Context, extra map[string]interface{}) error {
 	var err error
 	for _, m := range base.allMotors {
 		err = multierr.Combine(err, m.Stop(ctx, extra))
@@ -313,6 +605,16 @@ func (base *wheeledBase) Close(ctx context.Context) error {
 	return base.Stop(ctx, nil)
 }
 
+func (wb *wheeledBase) Properties(ctx context.Context, extra map[string]interface{}) (*base.Properties, error) {
+	return &base.Properties{
+		SpinSupported:        wb.minTurningRadiusMm == 0,
+		ArcSupported:         true,
+		VelocitySupported:    true,
+		WidthMm:              wb.widthMm,
+		WheelCircumferenceMm: wb.wheelCircumferenceMm,
+	}, nil
+}
+
 func (base *wheeledBase) Width(ctx context.Context) (int, error) {
 	return base.widthMm, nil
 }
@@ -328,6 +630,11 @@ func CreateWheeledBase(
 		widthMm:              config.WidthMM,
 		wheelCircumferenceMm: config.WheelCircumferenceMM,
 		spinSlipFactor:       config.SpinSlipFactor,
+		minSpinRPM:           config.MinSpinRPM,
+		minTurningRadiusMm:   config.MinTurningRadiusMM,
+		rampDownMm:           config.RampDownMM,
+		straightToleranceMm:  config.StraightToleranceMM,
+		motorsByName:         map[string]motor.Motor{},
 	}
 
 	if base.spinSlipFactor == 0 {
@@ -340,6 +647,7 @@ func CreateWheeledBase(
 			return nil, errors.Wrapf(err, "no left motor named (%s)", name)
 		}
 		base.left = append(base.left, m)
+		base.motorsByName[name] = m
 	}
 
 	for _, name := range config.Right {
@@ -348,10 +656,75 @@ func CreateWheeledBase(
 			return nil, errors.Wrapf(err, "no right motor named (%s)", name)
 		}
 		base.right = append(base.right, m)
+		base.motorsByName[name] = m
 	}
 
 	base.allMotors = append(base.allMotors, base.left...)
 	base.allMotors = append(base.allMotors, base.right...)
 
+	if base.straightToleranceMm > 0 {
+		for _, m := range base.allMotors {
+			features, err := m.Properties(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			if !features[motor.PositionReporting] {
+				return nil, errors.New("straight_tolerance_mm needs every motor to report its position")
+			}
+		}
+	}
+
+	if config.SelfTest {
+		if err := base.selfTest(ctx, append(append([]string{}, config.Left...), config.Right...)); err != nil {
+			return nil, err
+		}
+	}
+
 	return base, nil
 }
+
+const (
+	selfTestRPM         = 10
+	selfTestRevolutions = 0.05
+)
+
+// selfTest pulses every motor forward and back again, and reports every motor that fails to move
+// or whose encoder does not change while it moves. names are the names of allMotors.
+func (base *wheeledBase) selfTest(ctx context.Context, names []string) error {
+	var errs error
+	for i, m := range base.allMotors {
+		if err := selfTestMotor(ctx, m); err != nil {
+			errs = multierr.Combine(errs, errors.Wrapf(err, "self test of motor %q failed", names[i]))
+		}
+	}
+	return errs
+}
+
+func selfTestMotor(ctx context.Context, m motor.Motor) error {
+	features, err := m.Properties(ctx, nil)
+	if err != nil {
+		return err
+	}
+	var start float64
+	if features[motor.PositionReporting] {
+		if start, err = m.Position(ctx, nil); err != nil {
+			return err
+		}
+	}
+	if err := m.GoFor(ctx, selfTestRPM, selfTestRevolutions, nil); err != nil {
+		return multierr.Combine(err, m.Stop(ctx, nil))
+	}
+	if features[motor.PositionReporting] {
+		pos, err := m.Position(ctx, nil)
+		if err != nil {
+			return multierr.Combine(err, m.Stop(ctx, nil))
+		}
+		if pos == start {
+			return multierr.Combine(errors.New("encoder did not change while the motor moved"), m.Stop(ctx, nil))
+		}
+	}
+	if err := m.GoFor(ctx, selfTestRPM, -selfTestRevolutions, nil); err != nil {
+		return multierr.Combine(err, m.Stop(ctx, nil))
+	}
+	return nil
+}
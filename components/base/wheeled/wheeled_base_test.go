@@ -3,15 +3,22 @@ package wheeled
 import (
 	"context"
 	"math"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
 	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
 
+	fakeencoder "go.viam.com/rdk/components/encoder/fake"
 	"go.viam.com/rdk/components/motor"
 	"go.viam.com/rdk/components/motor/fake"
 	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/testutils/inject"
 )
 
 func fakeMotorDependencies(t *testing.T, deps []string) registry.Dependencies {
@@ -51,6 +58,14 @@ func TestWheelBaseMath(t *testing.T) {
 		temp, err := base.Width(ctx)
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, temp, test.ShouldEqual, 100)
+
+		props, err := base.Properties(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, props.SpinSupported, test.ShouldBeTrue)
+		test.That(t, props.ArcSupported, test.ShouldBeTrue)
+		test.That(t, props.VelocitySupported, test.ShouldBeTrue)
+		test.That(t, props.WidthMm, test.ShouldEqual, 100)
+		test.That(t, props.WheelCircumferenceMm, test.ShouldEqual, 1000)
 	})
 
 	t.Run("math_straight", func(t *testing.T) {
@@ -210,6 +225,39 @@ func TestWheelBaseMath(t *testing.T) {
 		test.That(t, r, test.ShouldAlmostEqual, -59.476, 0.01)
 	})
 
+	t.Run("set velocity", func(t *testing.T) {
+		powers := func() []float64 {
+			var pcts []float64
+			for _, motors := range [][]motor.Motor{base.left, base.right} {
+				for _, m := range motors {
+					_, powerPct, err := m.IsPowered(ctx, nil)
+					test.That(t, err, test.ShouldBeNil)
+					pcts = append(pcts, powerPct)
+				}
+			}
+			return pcts
+		}
+
+		err := base.SetVelocity(ctx, r3.Vector{Y: 1000}, r3.Vector{}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, powers(), test.ShouldResemble, []float64{1, 1, 1, 1})
+
+		err = base.SetVelocity(ctx, r3.Vector{Y: 500}, r3.Vector{Z: -10}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		l, r := base.velocityMath(500, -10)
+		test.That(t, l, test.ShouldBeGreaterThan, r)
+		pcts := powers()
+		test.That(t, pcts[0], test.ShouldAlmostEqual, l/60)
+		test.That(t, pcts[3], test.ShouldAlmostEqual, r/60)
+
+		err = base.SetVelocity(ctx, r3.Vector{}, r3.Vector{}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, powers(), test.ShouldResemble, []float64{0, 0, 0, 0})
+		moving, err := base.IsMoving(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, moving, test.ShouldBeFalse)
+	})
+
 	t.Run("arc math zero angle", func(t *testing.T) {
 		l, r := base.velocityMath(1000, 0)
 		test.That(t, l, test.ShouldEqual, 60.0)
@@ -350,3 +398,564 @@ func TestValidate(t *testing.T) {
 	test.That(t, deps, test.ShouldResemble, []string{"fl-m", "bl-m", "fr-m", "br-m"})
 	test.That(t, err, test.ShouldBeNil)
 }
+
+func TestSpinFloor(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 1000,
+		Left:                 []string{"l-m"},
+		Right:                []string{"r-m"},
+	}
+	var mu sync.Mutex
+	var rpms []float64
+	deps := registry.Dependencies{}
+	for _, name := range []string{"l-m", "r-m"} {
+		m := &inject.Motor{Motor: &fake.Motor{MaxRPM: 60, Logger: logger}}
+		m.GoForFunc = func(ctx context.Context, rpm, revolutions float64, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			rpms = append(rpms, rpm)
+			return nil
+		}
+		deps[motor.Named(name)] = m
+	}
+	// spinRPMs returns the rpm each motor was run at, in increasing order
+	spinRPMs := func(b *wheeledBase, extra map[string]interface{}) []float64 {
+		mu.Lock()
+		rpms = nil
+		mu.Unlock()
+		test.That(t, b.Spin(ctx, 90, 1, extra), test.ShouldBeNil)
+		mu.Lock()
+		defer mu.Unlock()
+		sort.Float64s(rpms)
+		return rpms
+	}
+
+	// without a floor a slow spin turns the wheels slowly
+	created, err := CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	for _, rpm := range spinRPMs(created.(*wheeledBase), nil) {
+		test.That(t, math.Abs(rpm), test.ShouldBeLessThan, 1)
+	}
+
+	cfg.MinSpinRPM = 5
+	created, err = CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	b := created.(*wheeledBase)
+	test.That(t, spinRPMs(b, nil), test.ShouldResemble, []float64{-5, 5})
+	for _, rpm := range spinRPMs(b, map[string]interface{}{"no_spin_floor": true}) {
+		test.That(t, math.Abs(rpm), test.ShouldBeLessThan, 1)
+	}
+
+	cfg.MinSpinRPM = -1
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "min_spin_rpm cannot be negative")
+}
+
+func TestMinTurningRadius(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 1000,
+		Left:                 []string{"l-m"},
+		Right:                []string{"r-m"},
+		MinTurningRadiusMM:   500,
+	}
+	var mu sync.Mutex
+	goForCalls := 0
+	deps := registry.Dependencies{}
+	for _, name := range []string{"l-m", "r-m"} {
+		m := &inject.Motor{Motor: &fake.Motor{MaxRPM: 60, Logger: logger}}
+		m.GoForFunc = func(ctx context.Context, rpm, revolutions float64, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			goForCalls++
+			return nil
+		}
+		deps[motor.Named(name)] = m
+	}
+	created, err := CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	b := created.(*wheeledBase)
+
+	err = b.Spin(ctx, 90, 10, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot spin in place with a minimum turning radius of 500 mm")
+	test.That(t, goForCalls, test.ShouldEqual, 0)
+	props, err := b.Properties(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, props.SpinSupported, test.ShouldBeFalse)
+
+	// 100 mm/s on a 500 mm circle turns at most 0.2 rad/s
+	maxDegsPerSec := 0.2 * 180 / math.Pi
+	degsPerSec, err := b.limitTurn(100, 90)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, degsPerSec, test.ShouldAlmostEqual, maxDegsPerSec)
+	degsPerSec, err = b.limitTurn(-100, -90)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, degsPerSec, test.ShouldAlmostEqual, -maxDegsPerSec)
+	degsPerSec, err = b.limitTurn(100, 5)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, degsPerSec, test.ShouldEqual, 5)
+	_, err = b.limitTurn(0, 5)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	err = b.SetVelocity(ctx, r3.Vector{Y: 100}, r3.Vector{Z: 90}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, goForCalls, test.ShouldEqual, 2)
+	test.That(t, b.SetVelocity(ctx, r3.Vector{}, r3.Vector{Z: 90}, nil), test.ShouldNotBeNil)
+
+	cfg.MinTurningRadiusMM = -1
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "min_turning_radius_mm cannot be negative")
+}
+
+func TestRampDown(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 100,
+		Left:                 []string{"l-m"},
+		Right:                []string{"r-m"},
+		RampDownMM:           200,
+	}
+	deps := registry.Dependencies{}
+	left := &fake.Motor{MaxRPM: 6000, Logger: logger}
+	deps[motor.Named("l-m")] = left
+	deps[motor.Named("r-m")] = &fake.Motor{MaxRPM: 6000, Logger: logger}
+	created, err := CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	b := created.(*wheeledBase)
+
+	segments := b.rampDown(b.straightDistanceToMotorInfo(1000, 100))
+	test.That(t, segments, test.ShouldHaveLength, rampDownSteps+1)
+	test.That(t, segments[0].rpm, test.ShouldAlmostEqual, 60)
+	test.That(t, segments[0].rotations, test.ShouldAlmostEqual, 8)
+	total := segments[0].rotations
+	for i := 1; i < len(segments); i++ {
+		test.That(t, segments[i].rpm, test.ShouldBeLessThan, segments[i-1].rpm)
+		test.That(t, segments[i].rpm, test.ShouldBeGreaterThan, 0)
+		total += segments[i].rotations
+	}
+	test.That(t, total, test.ShouldAlmostEqual, 10)
+
+	// a move shorter than the ramp down slows down the whole way, backwards too
+	segments = b.rampDown(b.straightDistanceToMotorInfo(-100, 100))
+	test.That(t, segments, test.ShouldHaveLength, rampDownSteps)
+	test.That(t, segments[0].rpm, test.ShouldBeLessThan, 60)
+	total = 0
+	for _, segment := range segments {
+		total += segment.rotations
+	}
+	test.That(t, total, test.ShouldAlmostEqual, -1)
+
+	// the wheels slow down without stopping until the end of the move
+	for _, distanceMm := range []int{300, -300} {
+		moved := make(chan error, 1)
+		start := time.Now()
+		go func() { moved <- b.MoveStraight(ctx, distanceMm, 2000, nil) }()
+		stops := 0
+		powered := false
+		done := false
+		for !done {
+			if on, _, err := left.IsPowered(ctx, nil); err == nil && on {
+				powered = true
+				select {
+				case <-left.Stopped():
+					stops++
+				case err := <-moved:
+					test.That(t, err, test.ShouldBeNil)
+					done = true
+				}
+				continue
+			}
+			select {
+			case err := <-moved:
+				test.That(t, err, test.ShouldBeNil)
+				done = true
+			case <-time.After(time.Millisecond):
+			}
+		}
+		test.That(t, powered, test.ShouldBeTrue)
+		test.That(t, stops, test.ShouldBeLessThanOrEqualTo, 1)
+		on, _, err := left.IsPowered(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, on, test.ShouldBeFalse)
+		// the cruise takes 50ms and the ramp down 260ms more
+		test.That(t, time.Since(start), test.ShouldBeGreaterThan, 250*time.Millisecond)
+	}
+
+	cfg.RampDownMM = -1
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "ramp_down_mm cannot be negative")
+}
+
+func TestClosedLoopStraight(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 100,
+		Left:                 []string{"l-m"},
+		Right:                []string{"r-m"},
+		StraightToleranceMM:  1,
+	}
+	var mu sync.Mutex
+	positions := map[string]float64{}
+	deps := registry.Dependencies{}
+	// the left wheel slips and only travels 80% of what it is asked to, the right one 95%
+	for name, efficiency := range map[string]float64{"l-m": 0.8, "r-m": 0.95} {
+		name, efficiency := name, efficiency
+		m := &inject.Motor{Motor: &fake.Motor{MaxRPM: 60, Logger: logger}}
+		m.GoForFunc = func(ctx context.Context, rpm, revolutions float64, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if rpm < 0 {
+				revolutions = -revolutions
+			}
+			positions[name] += revolutions * efficiency
+			return nil
+		}
+		m.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return positions[name], nil
+		}
+		m.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
+			return map[motor.Feature]bool{motor.PositionReporting: true}, nil
+		}
+		deps[motor.Named(name)] = m
+	}
+	created, err := CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	b := created.(*wheeledBase)
+
+	test.That(t, b.MoveStraight(ctx, 1000, 100, nil), test.ShouldBeNil)
+	average := (positions["l-m"] + positions["r-m"]) / 2
+	test.That(t, math.Abs(average-10)*100, test.ShouldBeLessThanOrEqualTo, 1)
+
+	test.That(t, b.MoveStraight(ctx, -500, 100, nil), test.ShouldBeNil)
+	average = (positions["l-m"] + positions["r-m"]) / 2
+	test.That(t, math.Abs(average-5)*100, test.ShouldBeLessThanOrEqualTo, 1)
+
+	// motors without encoders cannot be corrected
+	_, err = CreateWheeledBase(ctx, fakeMotorDependencies(t, []string{"l-m", "r-m"}), cfg, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "needs every motor to report its position")
+
+	cfg.StraightToleranceMM = -1
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "straight_tolerance_mm cannot be negative")
+}
+
+func TestCorrectStraightSkipsFinishedWheels(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 100,
+		Left:                 []string{"l-m"},
+		Right:                []string{"r-m"},
+		StraightToleranceMM:  1,
+	}
+	deps := registry.Dependencies{}
+	motors := map[string]*fake.Motor{}
+	for _, name := range []string{"l-m", "r-m"} {
+		m := &fake.Motor{
+			MaxRPM:            6000,
+			Logger:            logger,
+			Encoder:           &fakeencoder.Encoder{},
+			TicksPerRotation:  100,
+			PositionReporting: true,
+		}
+		motors[name] = m
+		deps[motor.Named(name)] = m
+	}
+	created, err := CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	b := created.(*wheeledBase)
+
+	// the left wheel already made it, only the right one has to catch up
+	test.That(t, motors["l-m"].Encoder.SetPosition(ctx, 100), test.ShouldBeNil)
+	test.That(t, b.correctStraight(ctx, []float64{0, 0}, 1, 600), test.ShouldBeNil)
+	for _, m := range motors {
+		pos, err := m.Position(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pos, test.ShouldAlmostEqual, 1)
+		on, _, err := m.IsPowered(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, on, test.ShouldBeFalse)
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 100,
+		Left:                 []string{"fl-m", "bl-m"},
+		Right:                []string{"fr-m", "br-m"},
+		SelfTest:             true,
+	}
+	var mu sync.Mutex
+	positions := map[string]float64{}
+	newMotor := func(name string, encoder bool, goForErr error) *inject.Motor {
+		m := &inject.Motor{Motor: &fake.Motor{MaxRPM: 60, Logger: logger}}
+		m.GoForFunc = func(ctx context.Context, rpm, revolutions float64, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if goForErr != nil {
+				return goForErr
+			}
+			if encoder {
+				positions[name] += revolutions
+			}
+			return nil
+		}
+		m.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return positions[name], nil
+		}
+		m.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
+			return map[motor.Feature]bool{motor.PositionReporting: true}, nil
+		}
+		m.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+			return nil
+		}
+		return m
+	}
+	deps := registry.Dependencies{
+		motor.Named("fl-m"): newMotor("fl-m", true, nil),
+		motor.Named("bl-m"): newMotor("bl-m", true, nil),
+		motor.Named("fr-m"): newMotor("fr-m", true, nil),
+		motor.Named("br-m"): newMotor("br-m", true, nil),
+	}
+	_, err := CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	for _, pos := range positions {
+		test.That(t, pos, test.ShouldAlmostEqual, 0)
+	}
+
+	deps[motor.Named("bl-m")] = newMotor("bl-m", false, nil)
+	deps[motor.Named("fr-m")] = newMotor("fr-m", true, errors.New("no power"))
+	_, err = CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `self test of motor "bl-m" failed: encoder did not change`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `self test of motor "fr-m" failed: no power`)
+	test.That(t, err.Error(), test.ShouldNotContainSubstring, "fl-m")
+	test.That(t, err.Error(), test.ShouldNotContainSubstring, "br-m")
+
+	// without the self test the faulty motors go unnoticed
+	cfg.SelfTest = false
+	_, err = CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestConcurrentCommands(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 1000,
+		RampDownMM:           500,
+		Left:                 []string{"fl-m", "bl-m"},
+		Right:                []string{"fr-m", "br-m"},
+	}
+	deps, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	b, err := CreateWheeledBase(ctx, fakeMotorDependencies(t, deps), cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	t.Run("stop preempts a move", func(t *testing.T) {
+		// 10m at 100mm/s, ramping down over the last 500mm
+		moved := make(chan error, 1)
+		go func() {
+			moved <- b.MoveStraight(ctx, 10000, 100, nil)
+		}()
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			moving, err := b.IsMoving(ctx)
+			test.That(tb, err, test.ShouldBeNil)
+			test.That(tb, moving, test.ShouldBeTrue)
+		})
+		test.That(t, b.Stop(ctx, nil), test.ShouldBeNil)
+		select {
+		case <-moved:
+		case <-time.After(5 * time.Second):
+			t.Fatal("move did not return after stop")
+		}
+		// the rest of the move, like its ramp down, does not start the motors again
+		time.Sleep(100 * time.Millisecond)
+		moving, err := b.IsMoving(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, moving, test.ShouldBeFalse)
+	})
+
+	// moves preempted by others return errors, but never leave motors commanded by two moves at once
+	t.Run("concurrent commands", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(4)
+			go func() {
+				defer wg.Done()
+				_ = b.MoveStraight(ctx, 1000, 100, nil)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = b.Spin(ctx, 90, 45, nil)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = b.SetVelocity(ctx, r3.Vector{Y: 100}, r3.Vector{Z: 10}, nil)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = b.Stop(ctx, nil)
+			}()
+		}
+		wg.Wait()
+		test.That(t, b.Stop(ctx, nil), test.ShouldBeNil)
+		moving, err := b.IsMoving(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, moving, test.ShouldBeFalse)
+	})
+}
+
+func TestMotorFailure(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 100,
+		Left:                 []string{"fl-m", "bl-m"},
+		Right:                []string{"fr-m", "br-m"},
+	}
+	var mu sync.Mutex
+	running := map[string]chan struct{}{}
+	// newMotor returns a motor that, once started, runs until it is stopped whether or not its
+	// context is cancelled, unless it fails to start
+	newMotor := func(name string, goForErr error) *inject.Motor {
+		m := &inject.Motor{Motor: &fake.Motor{MaxRPM: 60, Logger: logger}}
+		m.GoForFunc = func(ctx context.Context, rpm, revolutions float64, extra map[string]interface{}) error {
+			if goForErr != nil {
+				return goForErr
+			}
+			stopped := make(chan struct{})
+			mu.Lock()
+			if err := ctx.Err(); err != nil {
+				mu.Unlock()
+				return err
+			}
+			running[name] = stopped
+			mu.Unlock()
+			<-stopped
+			return nil
+		}
+		m.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if stopped, ok := running[name]; ok {
+				close(stopped)
+				delete(running, name)
+			}
+			return nil
+		}
+		return m
+	}
+	deps := registry.Dependencies{
+		motor.Named("fl-m"): newMotor("fl-m", nil),
+		motor.Named("bl-m"): newMotor("bl-m", nil),
+		motor.Named("fr-m"): newMotor("fr-m", errors.New("motor fault")),
+		motor.Named("br-m"): newMotor("br-m", nil),
+	}
+	b, err := CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	for name, move := range map[string]func() error{
+		"straight": func() error { return b.MoveStraight(ctx, 1000, 100, nil) },
+		"spin":     func() error { return b.Spin(ctx, 90, 45, nil) },
+	} {
+		moved := make(chan error, 1)
+		go func() {
+			moved <- move()
+		}()
+		select {
+		case err := <-moved:
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, "motor fault")
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s kept running on the healthy motors", name)
+		}
+		mu.Lock()
+		test.That(t, running, test.ShouldBeEmpty)
+		mu.Unlock()
+	}
+}
+
+func TestSetWheelPowers(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 100,
+		Left:                 []string{"fl-m", "bl-m"},
+		Right:                []string{"fr-m", "br-m"},
+	}
+	deps := fakeMotorDependencies(t, []string{"fl-m", "bl-m", "fr-m", "br-m"})
+	b, err := CreateWheeledBase(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	wb := b.(*wheeledBase)
+
+	powerOf := func(name string) float64 {
+		m, err := motor.FromDependencies(deps, name)
+		test.That(t, err, test.ShouldBeNil)
+		_, power, err := m.IsPowered(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		return power
+	}
+
+	err = wb.SetWheelPowers(ctx, map[string]float64{"fl-m": 0.5, "bl-m": -0.25, "fr-m": 2, "br-m": -3}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, powerOf("fl-m"), test.ShouldEqual, 0.5)
+	test.That(t, powerOf("bl-m"), test.ShouldEqual, -0.25)
+	test.That(t, powerOf("fr-m"), test.ShouldEqual, 1)
+	test.That(t, powerOf("br-m"), test.ShouldEqual, -1)
+
+	t.Run("do command", func(t *testing.T) {
+		_, err := wb.DoCommand(ctx, map[string]interface{}{
+			"command": "set_wheel_powers",
+			"powers":  map[string]interface{}{"fl-m": 0.75},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, powerOf("fl-m"), test.ShouldEqual, 0.75)
+		test.That(t, powerOf("bl-m"), test.ShouldEqual, -0.25)
+
+		_, err = wb.DoCommand(ctx, map[string]interface{}{"command": "set_wheel_powers"})
+		test.That(t, err, test.ShouldBeError, "set_wheel_powers needs 'powers', a map")
+		_, err = wb.DoCommand(ctx, map[string]interface{}{"command": "fly"})
+		test.That(t, err, test.ShouldBeError, "no such command: fly")
+	})
+
+	t.Run("unknown wheels set nothing", func(t *testing.T) {
+		err := wb.SetWheelPowers(ctx, map[string]float64{"fl-m": 0.1, "tail-m": 0.1}, nil)
+		test.That(t, err, test.ShouldBeError, `no wheel motor named "tail-m"`)
+		test.That(t, powerOf("fl-m"), test.ShouldEqual, 0.75)
+	})
+}
@@ -35,6 +35,7 @@ type Config struct {
 	SPIs              []board.SPIConfig              `json:"spis,omitempty"`
 	Analogs           []board.AnalogConfig           `json:"analogs,omitempty"`
 	DigitalInterrupts []board.DigitalInterruptConfig `json:"digital_interrupts,omitempty"`
+	InitialStates     []board.GPIOInitialStateConfig `json:"initial_states,omitempty"`
 	Attributes        config.AttributeMap            `json:"attributes,omitempty"`
 }
 
@@ -80,7 +81,7 @@ func RegisterBoard(modelName string, gpioMappings map[int]GPIOBoardMapping) {
 			}
 
 			cancelCtx, cancelFunc := context.WithCancel(context.Background())
-			return &sysfsBoard{
+			b := &sysfsBoard{
 				gpioMappings: gpioMappings,
 				spis:         spis,
 				analogs:      analogs,
@@ -88,7 +89,12 @@ func RegisterBoard(modelName string, gpioMappings map[int]GPIOBoardMapping) {
 				logger:       logger,
 				cancelCtx:    cancelCtx,
 				cancelFunc:   cancelFunc,
-			}, nil
+			}
+			if err := board.SetInitialStates(ctx, b, conf.InitialStates); err != nil {
+				b.Close()
+				return nil, err
+			}
+			return b, nil
 		}})
 	config.RegisterComponentAttributeMapConverter(
 		board.SubtypeName,
@@ -122,6 +128,16 @@ func (config *Config) Validate(path string) error {
 			return err
 		}
 	}
+	pins := map[string]bool{}
+	for idx, conf := range config.InitialStates {
+		if err := conf.Validate(fmt.Sprintf("%s.%s.%d", path, "initial_states", idx)); err != nil {
+			return err
+		}
+		if pins[conf.Pin] {
+			return goutils.NewConfigValidationError(path, errors.Errorf("more than one initial state for pin %s", conf.Pin))
+		}
+		pins[conf.Pin] = true
+	}
 	return nil
 }
 
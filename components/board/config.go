@@ -71,3 +71,18 @@ func (config *DigitalInterruptConfig) Validate(path string) error {
 	}
 	return nil
 }
+
+// GPIOInitialStateConfig describes the level a GPIO output is set to as soon as its board is
+// built, so that whatever it drives comes up in a known, safe state.
+type GPIOInitialStateConfig struct {
+	Pin  string `json:"pin"`
+	High bool   `json:"high"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (config *GPIOInitialStateConfig) Validate(path string) error {
+	if config.Pin == "" {
+		return utils.NewConfigValidationFieldRequiredError(path, "pin")
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ type Config struct {
 	SPIs              []board.SPIConfig              `json:"spis,omitempty"`
 	Analogs           []board.AnalogConfig           `json:"analogs,omitempty"`
 	DigitalInterrupts []board.DigitalInterruptConfig `json:"digital_interrupts,omitempty"`
+	InitialStates     []board.GPIOInitialStateConfig `json:"initial_states,omitempty"`
 	Attributes        config.AttributeMap            `json:"attributes,omitempty"`
 	FailNew           bool                           `json:"fail_new"`
 }
@@ -52,6 +53,16 @@ func (config *Config) Validate(path string) error {
 			return err
 		}
 	}
+	pins := map[string]bool{}
+	for idx, conf := range config.InitialStates {
+		if err := conf.Validate(fmt.Sprintf("%s.%s.%d", path, "initial_states", idx)); err != nil {
+			return err
+		}
+		if pins[conf.Pin] {
+			return utils.NewConfigValidationError(path, errors.Errorf("more than one initial state for pin %s", conf.Pin))
+		}
+		pins[conf.Pin] = true
+	}
 
 	if config.FailNew {
 		return errors.New("whoops")
@@ -121,6 +132,10 @@ func NewBoard(ctx context.Context, config config.Component, logger golog.Logger)
 		}
 	}
 
+	if err := board.SetInitialStates(ctx, b, boardConfig.InitialStates); err != nil {
+		return nil, err
+	}
+
 	return b, nil
 }
 
@@ -5,10 +5,12 @@ import (
 	"testing"
 
 	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
 	"go.viam.com/test"
 
 	"go.viam.com/rdk/components/board"
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/testutils/inject"
 )
 
 func TestFakeBoard(t *testing.T) {
@@ -90,3 +92,43 @@ func TestConfigValidate(t *testing.T) {
 	validConfig.DigitalInterrupts = []board.DigitalInterruptConfig{{Name: "bar", Pin: "3"}}
 	test.That(t, validConfig.Validate("path"), test.ShouldBeNil)
 }
+
+func TestInitialStates(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	boardConfig := Config{
+		InitialStates: []board.GPIOInitialStateConfig{
+			{Pin: "11", High: true},
+			{Pin: "12", High: false},
+		},
+	}
+	test.That(t, boardConfig.Validate("path"), test.ShouldBeNil)
+
+	cfg := config.Component{Name: "board1", ConvertedAttributes: &boardConfig}
+	b, err := NewBoard(context.Background(), cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	for pin, high := range map[string]bool{"11": true, "12": false} {
+		p, err := b.GPIOPinByName(pin)
+		test.That(t, err, test.ShouldBeNil)
+		state, err := p.Get(context.Background(), nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, state, test.ShouldEqual, high)
+	}
+
+	boardConfig.InitialStates = append(boardConfig.InitialStates, board.GPIOInitialStateConfig{Pin: "11"})
+	err = boardConfig.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "more than one initial state for pin 11")
+
+	boardConfig.InitialStates = []board.GPIOInitialStateConfig{{High: true}}
+	err = boardConfig.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "pin")
+
+	// boards without the pin fail to build
+	injectBoard := &inject.Board{}
+	injectBoard.GPIOPinByNameFunc = func(name string) (board.GPIOPin, error) {
+		return nil, errors.Errorf("no pin %s", name)
+	}
+	err = board.SetInitialStates(context.Background(), injectBoard, []board.GPIOInitialStateConfig{{Pin: "99", High: true}})
+	test.That(t, err, test.ShouldBeError, "cannot set initial state of pin 99: no pin 99")
+}
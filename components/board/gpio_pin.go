@@ -1,6 +1,10 @@
 package board
 
-import "context"
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
 
 // A GPIOPin represents an individual GPIO pin on a board.
 type GPIOPin interface {
@@ -22,3 +26,18 @@ type GPIOPin interface {
 	// SetPWMFreq sets the given pin to the given PWM frequency. 0 will use the board's default PWM frequency.
 	SetPWMFreq(ctx context.Context, freqHz uint, extra map[string]interface{}) error
 }
+
+// SetInitialStates sets each pin to its configured initial level, failing on the first pin the
+// board does not have.
+func SetInitialStates(ctx context.Context, b Board, states []GPIOInitialStateConfig) error {
+	for _, state := range states {
+		pin, err := b.GPIOPinByName(state.Pin)
+		if err != nil {
+			return errors.Wrapf(err, "cannot set initial state of pin %s", state.Pin)
+		}
+		if err := pin.Set(ctx, state.High, nil); err != nil {
+			return errors.Wrapf(err, "cannot set initial state of pin %s", state.Pin)
+		}
+	}
+	return nil
+}
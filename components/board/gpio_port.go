@@ -0,0 +1,76 @@
+package board
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// maxPortPins is how many pins fit in the bits of a port value.
+const maxPortPins = 64
+
+// A PortBoard is a board whose GPIO pins are grouped into ports, like the bytes of a
+// microcontroller's registers, that it can read and write in a single operation.
+type PortBoard interface {
+	Board
+
+	// ReadPins returns the levels of the given pins, bit i being the level of pins[i].
+	ReadPins(ctx context.Context, pins []string, extra map[string]interface{}) (uint64, error)
+
+	// WritePins sets each of the given pins whose bit is set in mask to the level of that bit in
+	// value, bit i being pins[i], and leaves the rest untouched. Pins on the same port are updated
+	// at once.
+	WritePins(ctx context.Context, pins []string, value, mask uint64, extra map[string]interface{}) error
+}
+
+// ReadPins returns the levels of the given pins, bit i being the level of pins[i]. Boards that
+// have ports read them all at once, others are read one pin at a time.
+func ReadPins(ctx context.Context, b Board, pins []string, extra map[string]interface{}) (uint64, error) {
+	if len(pins) > maxPortPins {
+		return 0, errors.Errorf("cannot read more than %d pins at once, not %d", maxPortPins, len(pins))
+	}
+	if pb, ok := b.(PortBoard); ok {
+		return pb.ReadPins(ctx, pins, extra)
+	}
+	var value uint64
+	for i, name := range pins {
+		pin, err := b.GPIOPinByName(name)
+		if err != nil {
+			return 0, err
+		}
+		high, err := pin.Get(ctx, extra)
+		if err != nil {
+			return 0, err
+		}
+		if high {
+			value |= 1 << i
+		}
+	}
+	return value, nil
+}
+
+// WritePins sets each of the given pins whose bit is set in mask to the level of that bit in
+// value, bit i being pins[i], and leaves the rest untouched. Boards that have ports update the
+// pins of a port at once, others are set one pin at a time so other writers may see some of the
+// pins changed before the rest.
+func WritePins(ctx context.Context, b Board, pins []string, value, mask uint64, extra map[string]interface{}) error {
+	if len(pins) > maxPortPins {
+		return errors.Errorf("cannot write more than %d pins at once, not %d", maxPortPins, len(pins))
+	}
+	if pb, ok := b.(PortBoard); ok {
+		return pb.WritePins(ctx, pins, value, mask, extra)
+	}
+	for i, name := range pins {
+		if mask&(1<<i) == 0 {
+			continue
+		}
+		pin, err := b.GPIOPinByName(name)
+		if err != nil {
+			return err
+		}
+		if err := pin.Set(ctx, value&(1<<i) != 0, extra); err != nil {
+			return err
+		}
+	}
+	return nil
+}
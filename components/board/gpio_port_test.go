@@ -0,0 +1,62 @@
+package board_test
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/components/board/fake"
+)
+
+type portBoard struct {
+	*fake.Board
+	port uint64
+}
+
+func (b *portBoard) ReadPins(ctx context.Context, pins []string, extra map[string]interface{}) (uint64, error) {
+	return b.port, nil
+}
+
+func (b *portBoard) WritePins(ctx context.Context, pins []string, value, mask uint64, extra map[string]interface{}) error {
+	b.port = b.port&^mask | value&mask
+	return nil
+}
+
+func TestWritePins(t *testing.T) {
+	ctx := context.Background()
+	pins := []string{"p0", "p1", "p2", "p3"}
+
+	t.Run("emulated per pin", func(t *testing.T) {
+		b := &fake.Board{GPIOPins: map[string]*fake.GPIOPin{}}
+		test.That(t, board.WritePins(ctx, b, pins, 0b1111, 0b1111, nil), test.ShouldBeNil)
+		value, err := board.ReadPins(ctx, b, pins, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, value, test.ShouldEqual, 0b1111)
+
+		// only p1 and p2 are in the mask, so p0 stays high even though its bit is low
+		test.That(t, board.WritePins(ctx, b, pins, 0b0100, 0b0110, nil), test.ShouldBeNil)
+		value, err = board.ReadPins(ctx, b, pins, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, value, test.ShouldEqual, 0b1101)
+		high, err := b.GPIOPins["p1"].Get(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, high, test.ShouldBeFalse)
+	})
+
+	t.Run("port board", func(t *testing.T) {
+		b := &portBoard{Board: &fake.Board{GPIOPins: map[string]*fake.GPIOPin{}}, port: 0b1111}
+		test.That(t, board.WritePins(ctx, b, pins, 0b0100, 0b0110, nil), test.ShouldBeNil)
+		value, err := board.ReadPins(ctx, b, pins, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, value, test.ShouldEqual, 0b1101)
+		// the pins were not touched one at a time
+		test.That(t, b.GPIOPins, test.ShouldBeEmpty)
+	})
+
+	tooMany := make([]string, 65)
+	_, err := board.ReadPins(ctx, &fake.Board{}, tooMany, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, board.WritePins(ctx, &fake.Board{}, tooMany, 0, 0, nil), test.ShouldNotBeNil)
+}
@@ -171,6 +171,10 @@ func NewPigpio(ctx context.Context, cfg *commonsysfs.Config, logger golog.Logger
 		C.setupInterrupt(C.int(bcom))
 	}
 
+	if err := board.SetInitialStates(ctx, piInstance, cfg.InitialStates); err != nil {
+		return nil, err
+	}
+
 	instanceMu.Lock()
 	instances[piInstance] = struct{}{}
 	instanceMu.Unlock()
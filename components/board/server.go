@@ -7,9 +7,15 @@ import (
 	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/board/v1"
 
+	"go.viam.com/rdk/commandlog"
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
+var gpioCommandsCounter = metrics.NewCounter(
+	"rdk_board_gpio_commands_total", "Commands received by the GPIO pins of boards.", "board", "method")
+
 // subtypeServer implements the BoardService from board.proto.
 type subtypeServer struct {
 	pb.UnimplementedBoardServiceServer
@@ -25,11 +31,11 @@ func NewServer(s subtype.Service) pb.BoardServiceServer {
 func (s *subtypeServer) getBoard(name string) (Board, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no board with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("board", name)
 	}
 	board, ok := resource.(Board)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a board", name)
+		return nil, utils.NewWrongTypeByNameError("a board", name)
 	}
 	return board, nil
 }
@@ -55,12 +61,14 @@ func (s *subtypeServer) SetGPIO(ctx context.Context, req *pb.SetGPIORequest) (*p
 	if err != nil {
 		return nil, err
 	}
+	gpioCommandsCounter.Inc(req.Name, "SetGPIO")
 
 	p, err := b.GPIOPinByName(req.Pin)
 	if err != nil {
 		return nil, err
 	}
 
+	commandlog.Record(Named(req.Name).String(), "SetGPIO", map[string]interface{}{"pin": req.Pin, "high": req.High})
 	return &pb.SetGPIOResponse{}, p.Set(ctx, req.High, req.Extra.AsMap())
 }
 
@@ -108,12 +116,14 @@ func (s *subtypeServer) SetPWM(ctx context.Context, req *pb.SetPWMRequest) (*pb.
 	if err != nil {
 		return nil, err
 	}
+	gpioCommandsCounter.Inc(req.Name, "SetPWM")
 
 	p, err := b.GPIOPinByName(req.Pin)
 	if err != nil {
 		return nil, err
 	}
 
+	commandlog.Record(Named(req.Name).String(), "SetPWM", map[string]interface{}{"pin": req.Pin, "duty_cycle_pct": req.DutyCyclePct})
 	return &pb.SetPWMResponse{}, p.SetPWM(ctx, req.DutyCyclePct, req.Extra.AsMap())
 }
 
@@ -146,12 +156,14 @@ func (s *subtypeServer) SetPWMFrequency(
 	if err != nil {
 		return nil, err
 	}
+	gpioCommandsCounter.Inc(req.Name, "SetPWMFrequency")
 
 	p, err := b.GPIOPinByName(req.Pin)
 	if err != nil {
 		return nil, err
 	}
 
+	commandlog.Record(Named(req.Name).String(), "SetPWMFrequency", map[string]interface{}{"pin": req.Pin, "frequency_hz": req.FrequencyHz})
 	return &pb.SetPWMFrequencyResponse{}, p.SetPWMFreq(ctx, uint(req.FrequencyHz), req.Extra.AsMap())
 }
 
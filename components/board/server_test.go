@@ -9,11 +9,14 @@ import (
 	pb "go.viam.com/api/component/board/v1"
 	"go.viam.com/test"
 	"go.viam.com/utils/protoutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.viam.com/rdk/components/board"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/subtype"
 	"go.viam.com/rdk/testutils/inject"
+	rutils "go.viam.com/rdk/utils"
 )
 
 var errFoo = errors.New("whoops")
@@ -31,6 +34,27 @@ func newServer() (pb.BoardServiceServer, *inject.Board, error) {
 	return board.NewServer(boardSvc), injectBoard, nil
 }
 
+func TestServerLookupErrors(t *testing.T) {
+	ctx := context.Background()
+	server, _, err := newServer()
+	test.That(t, err, test.ShouldBeNil)
+
+	_, err = server.SetGPIO(ctx, &pb.SetGPIORequest{Name: missingBoardName})
+	var notFound *rutils.NotFoundByNameError
+	test.That(t, errors.As(err, &notFound), test.ShouldBeTrue)
+	test.That(t, notFound.Name, test.ShouldEqual, missingBoardName)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.NotFound)
+	test.That(t, errors.Is(err, rutils.ErrResourceNotFound), test.ShouldBeTrue)
+
+	_, err = server.GetGPIO(ctx, &pb.GetGPIORequest{Name: fakeBoardName})
+	var wrongType *rutils.WrongTypeByNameError
+	test.That(t, errors.As(err, &wrongType), test.ShouldBeTrue)
+	test.That(t, wrongType.Name, test.ShouldEqual, fakeBoardName)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.InvalidArgument)
+	test.That(t, errors.Is(err, rutils.ErrResourceWrongType), test.ShouldBeTrue)
+	test.That(t, errors.As(err, &notFound), test.ShouldBeFalse)
+}
+
 func TestServerStatus(t *testing.T) {
 	type request = pb.StatusRequest
 	type response = pb.StatusResponse
@@ -302,6 +302,29 @@ func (s *simpleSourceWithPCD) NextPointCloud(ctx context.Context) (pointcloud.Po
 	return nil, nil
 }
 
+type simpleSourceWithDo struct {
+	simpleSource
+}
+
+func (s *simpleSourceWithDo) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return cmd, nil
+}
+
+func TestNewCameraDoCommand(t *testing.T) {
+	cam, err := camera.NewFromReader(
+		context.Background(), &simpleSourceWithDo{simpleSource{"rimage/board1_small"}}, nil, camera.UnspecifiedStream)
+	test.That(t, err, test.ShouldBeNil)
+	ret, err := cam.DoCommand(context.Background(), generic.TestCommand)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ret, test.ShouldEqual, generic.TestCommand)
+
+	cam, err = camera.NewFromReader(
+		context.Background(), &simpleSource{"rimage/board1_small"}, nil, camera.UnspecifiedStream)
+	test.That(t, err, test.ShouldBeNil)
+	_, err = cam.DoCommand(context.Background(), generic.TestCommand)
+	test.That(t, err, test.ShouldEqual, generic.ErrUnimplemented)
+}
+
 func TestNewCamera(t *testing.T) {
 	intrinsics1 := &transform.PinholeCameraIntrinsics{Width: 128, Height: 72}
 	intrinsics2 := &transform.PinholeCameraIntrinsics{Width: 100, Height: 100}
@@ -0,0 +1,170 @@
+package camera
+
+import (
+	"context"
+	"image"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/edaniels/gostream"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+)
+
+// FrameDropPolicy decides which frame a FrameLimiter drops when its consumer falls behind.
+type FrameDropPolicy string
+
+// The policies a FrameLimiter can drop frames with.
+const (
+	// DropOldestFrame drops the oldest buffered frame so the consumer always gets the latest ones.
+	DropOldestFrame = FrameDropPolicy("drop_oldest")
+	// DropNewestFrame drops newly captured frames until the consumer catches up.
+	DropNewestFrame = FrameDropPolicy("drop_newest")
+)
+
+type limitedFrame struct {
+	img     image.Image
+	release func()
+	err     error
+}
+
+// The capture of a stream that keeps failing backs off from minCaptureBackoff, doubling up to
+// maxCaptureBackoff, instead of retrying as fast as the limit allows.
+const (
+	minCaptureBackoff = 10 * time.Millisecond
+	maxCaptureBackoff = time.Second
+)
+
+var errFrameLimiterClosed = errors.New("frame limiter is closed")
+
+// A FrameLimiter is a gostream.VideoStream that captures the frames of another stream in the
+// background, no faster than a maximum rate, and buffers a bounded number of them until they
+// are read. When the buffer is full frames are dropped by its policy instead of queueing up.
+type FrameLimiter struct {
+	stream                  gostream.VideoStream
+	policy                  FrameDropPolicy
+	frames                  chan limitedFrame
+	dropped                 int64
+	cancel                  func()
+	closeOnce               sync.Once
+	closed                  chan struct{}
+	activeBackgroundWorkers sync.WaitGroup
+}
+
+// NewFrameLimiter starts capturing the frames of the stream at no more than maxFPS frames per
+// second, or as fast as the stream produces them when maxFPS is 0. The limiter owns the stream
+// and closes it when it is closed.
+func NewFrameLimiter(stream gostream.VideoStream, maxFPS float64, bufferSize int, policy FrameDropPolicy) (*FrameLimiter, error) {
+	if maxFPS < 0 {
+		return nil, errors.New("max fps cannot be negative")
+	}
+	if bufferSize < 1 {
+		return nil, errors.New("frame buffer size must be at least 1")
+	}
+	if policy != DropOldestFrame && policy != DropNewestFrame {
+		return nil, errors.Errorf("unknown frame drop policy %q", policy)
+	}
+	var period time.Duration
+	if maxFPS > 0 {
+		period = time.Duration(float64(time.Second) / maxFPS)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fl := &FrameLimiter{
+		stream: stream,
+		policy: policy,
+		frames: make(chan limitedFrame, bufferSize),
+		cancel: cancel,
+		closed: make(chan struct{}),
+	}
+	fl.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		var backoff time.Duration
+		for {
+			start := time.Now()
+			img, release, err := fl.stream.Next(ctx)
+			if release == nil {
+				release = func() {}
+			}
+			if ctx.Err() != nil {
+				release()
+				return
+			}
+			fl.push(limitedFrame{img, release, err})
+			wait := period - time.Since(start)
+			if err != nil {
+				backoff *= 2
+				if backoff < minCaptureBackoff {
+					backoff = minCaptureBackoff
+				}
+				if backoff > maxCaptureBackoff {
+					backoff = maxCaptureBackoff
+				}
+				if wait < backoff {
+					wait = backoff
+				}
+			} else {
+				backoff = 0
+			}
+			if !utils.SelectContextOrWait(ctx, wait) {
+				return
+			}
+		}
+	}, fl.activeBackgroundWorkers.Done)
+	return fl, nil
+}
+
+// push buffers a frame, dropping one by the policy when the buffer is full. It is only called
+// from the capture goroutine, so once a frame is taken out there is room for the new one.
+func (fl *FrameLimiter) push(f limitedFrame) {
+	select {
+	case fl.frames <- f:
+		return
+	default:
+	}
+	atomic.AddInt64(&fl.dropped, 1)
+	if fl.policy == DropNewestFrame {
+		f.release()
+		return
+	}
+	select {
+	case oldest := <-fl.frames:
+		oldest.release()
+	default:
+	}
+	fl.frames <- f
+}
+
+// Next returns the oldest buffered frame, waiting for one to be captured if there is none. It
+// returns an error once the limiter is closed.
+func (fl *FrameLimiter) Next(ctx context.Context) (image.Image, func(), error) {
+	select {
+	case <-ctx.Done():
+		return nil, func() {}, ctx.Err()
+	case <-fl.closed:
+		return nil, func() {}, errFrameLimiterClosed
+	case f := <-fl.frames:
+		return f.img, f.release, f.err
+	}
+}
+
+// Dropped returns how many frames were dropped because the consumer fell behind.
+func (fl *FrameLimiter) Dropped() int {
+	return int(atomic.LoadInt64(&fl.dropped))
+}
+
+// Close stops capturing, releases the frames that were not read and closes the stream.
+func (fl *FrameLimiter) Close(ctx context.Context) error {
+	fl.closeOnce.Do(func() { close(fl.closed) })
+	fl.cancel()
+	fl.activeBackgroundWorkers.Wait()
+	for {
+		select {
+		case f := <-fl.frames:
+			f.release()
+		default:
+			return fl.stream.Close(ctx)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package camera_test
+
+import (
+	"context"
+	"image"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+)
+
+// countingStream produces frames numbered from 1 as fast as it is read, counting how many of
+// them are still held by someone.
+type countingStream struct {
+	mu      sync.Mutex
+	next    int
+	held    int
+	maxHeld int
+	closed  bool
+	failing bool
+}
+
+type numberedFrame struct {
+	image.Image
+	n int
+}
+
+func (s *countingStream) Next(ctx context.Context) (image.Image, func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	if s.failing {
+		return nil, nil, errors.New("no frame")
+	}
+	s.held++
+	if s.held > s.maxHeld {
+		s.maxHeld = s.held
+	}
+	return numberedFrame{image.NewGray(image.Rect(0, 0, 1, 1)), s.next}, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.held--
+	}, nil
+}
+
+func (s *countingStream) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func TestFrameLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := camera.NewFrameLimiter(&countingStream{}, -1, 1, camera.DropOldestFrame)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = camera.NewFrameLimiter(&countingStream{}, 10, 0, camera.DropOldestFrame)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = camera.NewFrameLimiter(&countingStream{}, 10, 1, camera.FrameDropPolicy("drop_all"))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// readFrames reads frames slower than they are captured and returns their numbers
+	readFrames := func(t *testing.T, fl *camera.FrameLimiter) []int {
+		t.Helper()
+		var read []int
+		for i := 0; i < 3; i++ {
+			time.Sleep(50 * time.Millisecond)
+			img, release, err := fl.Next(ctx)
+			test.That(t, err, test.ShouldBeNil)
+			read = append(read, img.(numberedFrame).n)
+			release()
+		}
+		return read
+	}
+
+	t.Run("drop oldest", func(t *testing.T) {
+		stream := &countingStream{}
+		fl, err := camera.NewFrameLimiter(stream, 200, 2, camera.DropOldestFrame)
+		test.That(t, err, test.ShouldBeNil)
+		read := readFrames(t, fl)
+		test.That(t, fl.Dropped(), test.ShouldBeGreaterThan, 0)
+		// the frames read are recent ones, well past the first few captured
+		test.That(t, read[0], test.ShouldBeGreaterThan, 2)
+		test.That(t, fl.Close(ctx), test.ShouldBeNil)
+
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		// at most the buffer, the frame being pushed and the frame being read are held
+		test.That(t, stream.maxHeld, test.ShouldBeLessThanOrEqualTo, 4)
+		test.That(t, stream.held, test.ShouldEqual, 0)
+		test.That(t, stream.closed, test.ShouldBeTrue)
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		stream := &countingStream{}
+		fl, err := camera.NewFrameLimiter(stream, 200, 2, camera.DropNewestFrame)
+		test.That(t, err, test.ShouldBeNil)
+		read := readFrames(t, fl)
+		test.That(t, fl.Dropped(), test.ShouldBeGreaterThan, 0)
+		// the first frames captured are kept and the ones after them dropped
+		test.That(t, read[:2], test.ShouldResemble, []int{1, 2})
+		test.That(t, fl.Close(ctx), test.ShouldBeNil)
+
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		test.That(t, stream.maxHeld, test.ShouldBeLessThanOrEqualTo, 4)
+		test.That(t, stream.held, test.ShouldEqual, 0)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		stream := &countingStream{}
+		fl, err := camera.NewFrameLimiter(stream, 20, 100, camera.DropOldestFrame)
+		test.That(t, err, test.ShouldBeNil)
+		time.Sleep(200 * time.Millisecond)
+		test.That(t, fl.Close(ctx), test.ShouldBeNil)
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		// 20 fps for 200ms captures about 4 frames, without a limit it would be thousands
+		test.That(t, stream.next, test.ShouldBeLessThanOrEqualTo, 6)
+		test.That(t, stream.next, test.ShouldBeGreaterThan, 0)
+	})
+
+	t.Run("failing stream", func(t *testing.T) {
+		stream := &countingStream{failing: true}
+		fl, err := camera.NewFrameLimiter(stream, 0, 1, camera.DropOldestFrame)
+		test.That(t, err, test.ShouldBeNil)
+		_, _, err = fl.Next(ctx)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "no frame")
+		time.Sleep(200 * time.Millisecond)
+		test.That(t, fl.Close(ctx), test.ShouldBeNil)
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		// retries back off from 10ms, without backing off it would be millions
+		test.That(t, stream.next, test.ShouldBeLessThanOrEqualTo, 6)
+	})
+
+	t.Run("next after close", func(t *testing.T) {
+		fl, err := camera.NewFrameLimiter(&countingStream{failing: true}, 1, 1, camera.DropOldestFrame)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, fl.Close(ctx), test.ShouldBeNil)
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, _, err = fl.Next(timeoutCtx)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "closed")
+		test.That(t, fl.Close(ctx), test.ShouldBeNil)
+	})
+}
@@ -0,0 +1,49 @@
+package camera
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/pointcloud"
+)
+
+// StreamPointClouds reads point clouds from the source one after another and sends each one
+// encoded as binary PCD, which pointcloud.ReadPCD decodes, until the context is done or sending
+// fails. When maxPointsPerSec is greater than 0 it waits between clouds so that no more points
+// than that are sent per second on average, otherwise clouds are sent as fast as they are read.
+func StreamPointClouds(
+	ctx context.Context,
+	src PointCloudSource,
+	maxPointsPerSec float64,
+	send func(pcd []byte) error,
+) error {
+	if maxPointsPerSec < 0 {
+		return errors.New("max points per second cannot be negative")
+	}
+	for {
+		start := time.Now()
+		pc, err := src.NextPointCloud(ctx)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := pointcloud.ToPCD(pc, &buf, pointcloud.PCDBinary); err != nil {
+			return err
+		}
+		if err := send(buf.Bytes()); err != nil {
+			return err
+		}
+
+		var wait time.Duration
+		if maxPointsPerSec > 0 {
+			wait = time.Duration(float64(pc.Size())/maxPointsPerSec*float64(time.Second)) - time.Since(start)
+		}
+		if !utils.SelectContextOrWait(ctx, wait) {
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package camera_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/pointcloud"
+)
+
+type staticCloudSource struct {
+	pc pointcloud.PointCloud
+}
+
+func (s *staticCloudSource) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, error) {
+	return s.pc, nil
+}
+
+func TestStreamPointClouds(t *testing.T) {
+	pc := pointcloud.New()
+	test.That(t, pc.Set(r3.Vector{X: 1, Y: 2, Z: 3}, pointcloud.NewColoredData(color.NRGBA{255, 0, 0, 255})), test.ShouldBeNil)
+	test.That(t, pc.Set(r3.Vector{X: -4, Y: 5, Z: 6}, nil), test.ShouldBeNil)
+	src := &staticCloudSource{pc}
+
+	err := camera.StreamPointClouds(context.Background(), src, -1, func([]byte) error { return nil })
+	test.That(t, err, test.ShouldNotBeNil)
+
+	t.Run("decodes to the cloud sent", func(t *testing.T) {
+		stop := errors.New("stop")
+		var received []pointcloud.PointCloud
+		err := camera.StreamPointClouds(context.Background(), src, 0, func(pcd []byte) error {
+			decoded, err := pointcloud.ReadPCD(bytes.NewReader(pcd))
+			test.That(t, err, test.ShouldBeNil)
+			received = append(received, decoded)
+			if len(received) == 3 {
+				return stop
+			}
+			return nil
+		})
+		test.That(t, err, test.ShouldEqual, stop)
+		for _, decoded := range received {
+			test.That(t, decoded.Size(), test.ShouldEqual, 2)
+			_, got := decoded.At(1, 2, 3)
+			test.That(t, got, test.ShouldBeTrue)
+			_, got = decoded.At(-4, 5, 6)
+			test.That(t, got, test.ShouldBeTrue)
+		}
+	})
+
+	t.Run("max point rate", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+		defer cancel()
+		sent := 0
+		// 2 points per cloud at 20 points per second sends a cloud every 100ms
+		err := camera.StreamPointClouds(ctx, src, 20, func(pcd []byte) error {
+			sent++
+			return nil
+		})
+		test.That(t, err, test.ShouldBeError, context.DeadlineExceeded)
+		// sent at 0, 100 and 200ms
+		test.That(t, sent, test.ShouldBeBetweenOrEqual, 2, 3)
+	})
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/edaniels/golog"
 	"github.com/edaniels/gostream"
-	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 	pb "go.viam.com/api/component/camera/v1"
 	"google.golang.org/genproto/googleapis/api/httpbody"
@@ -36,11 +35,11 @@ func NewServer(s subtype.Service) pb.CameraServiceServer {
 func (s *subtypeServer) getCamera(name string) (Camera, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no camera with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("camera", name)
 	}
 	cam, ok := resource.(Camera)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a camera", name)
+		return nil, utils.NewWrongTypeByNameError("a camera", name)
 	}
 	return cam, nil
 }
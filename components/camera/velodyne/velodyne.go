@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
+	"net"
 	"sync"
 	"time"
 
@@ -16,7 +18,7 @@ import (
 	gutils "go.viam.com/utils"
 
 	"go.viam.com/rdk/components/camera"
-	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/movementsensor"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/pointcloud"
 	"go.viam.com/rdk/registry"
@@ -72,21 +74,136 @@ var allProductData = map[vlp16.ProductID]productConfig{
 type AttrConfig struct {
 	Port  int `json:"port"`
 	TTLMS int `json:"ttl_ms"`
+	// MaxReconnectAttempts bounds how many times in a row we try to reconnect to the
+	// lidar after it stops responding. Zero means retry forever.
+	MaxReconnectAttempts int `json:"max_reconnect_attempts,omitempty"`
+	// AngularMask lists the azimuth ranges whose returns are dropped, such as the
+	// sectors blocked by the robot's own body.
+	AngularMask []AngleRange `json:"angular_mask,omitempty"`
+	// MinDistanceMM and MaxDistanceMM drop returns closer or further away than them,
+	// which are usually noise. A MaxDistanceMM of zero means there is no maximum.
+	MinDistanceMM float64 `json:"min_distance_mm,omitempty"`
+	MaxDistanceMM float64 `json:"max_distance_mm,omitempty"`
+	// MovementSensor names a movement sensor that moves with the lidar, with its axes aligned to
+	// the lidar's. When set, scans are deskewed: returns are timed by the packet they arrived in
+	// and moved to where they would have been seen at the time of the newest packet, assuming
+	// the sensor's linear and angular velocities held during the scan.
+	MovementSensor string `json:"movement_sensor,omitempty"`
+	// Convention is how azimuths map to the axes of the point cloud, so clouds can be matched to
+	// other tools. By default an azimuth of 0 points along +x and azimuths grow counterclockwise.
+	Convention Convention `json:"convention"`
+}
+
+// Convention describes how the azimuths of returns map to the axes of a point cloud. Elevation
+// is always toward +z.
+type Convention struct {
+	// ZeroAxis is the axis an azimuth of 0 points along, one of +x, -x, +y and -y. Empty means +x.
+	ZeroAxis string `json:"zero_axis,omitempty"`
+	// Clockwise is whether azimuths grow clockwise seen from above, as in Velodyne's manuals.
+	Clockwise bool `json:"clockwise,omitempty"`
+}
+
+// zeroAxisYaws are the angles, counterclockwise from +x in degrees, of the axes an azimuth of 0 can point along.
+var zeroAxisYaws = map[string]float64{"": 0, "+x": 0, "+y": 90, "-x": 180, "-y": 270}
+
+// Validate ensures the convention is one we know.
+func (conv Convention) Validate() error {
+	if _, ok := zeroAxisYaws[conv.ZeroAxis]; !ok {
+		return errors.Errorf("zero_axis must be one of +x, -x, +y and -y, not %q", conv.ZeroAxis)
+	}
+	return nil
+}
+
+// yaw returns the angle counterclockwise from +x, in degrees, of the given azimuth.
+func (conv Convention) yaw(azimuth float64) float64 {
+	if conv.Clockwise {
+		return zeroAxisYaws[conv.ZeroAxis] - azimuth
+	}
+	return zeroAxisYaws[conv.ZeroAxis] + azimuth
+}
+
+// azimuth returns the azimuth of the given angle counterclockwise from +x, both in degrees.
+func (conv Convention) azimuth(yaw float64) float64 {
+	if conv.Clockwise {
+		return zeroAxisYaws[conv.ZeroAxis] - yaw
+	}
+	return yaw - zeroAxisYaws[conv.ZeroAxis]
+}
+
+// ConvertScan returns the points of a scan taken with one convention as they would have been
+// placed by another, keeping the azimuth, elevation and distance of every return.
+func ConvertScan(pc pointcloud.PointCloud, from, to Convention) (pointcloud.PointCloud, error) {
+	if err := from.Validate(); err != nil {
+		return nil, err
+	}
+	if err := to.Validate(); err != nil {
+		return nil, err
+	}
+	converted := pointcloud.NewWithPrealloc(pc.Size())
+	var err error
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		yaw := utils.DegToRad(to.yaw(from.azimuth(utils.RadToDeg(math.Atan2(p.Y, p.X)))))
+		radius := math.Hypot(p.X, p.Y)
+		err = converted.Set(r3.Vector{X: radius * math.Cos(yaw), Y: radius * math.Sin(yaw), Z: p.Z}, d)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+// AngleRange is a range of azimuths in degrees, from MinDegrees to MaxDegrees. When
+// MinDegrees is greater than MaxDegrees the range wraps around through 0.
+type AngleRange struct {
+	MinDegrees float64 `json:"min_degrees"`
+	MaxDegrees float64 `json:"max_degrees"`
+}
+
+func (ar AngleRange) contains(degrees float64) bool {
+	if ar.MinDegrees <= ar.MaxDegrees {
+		return degrees >= ar.MinDegrees && degrees <= ar.MaxDegrees
+	}
+	return degrees >= ar.MinDegrees || degrees <= ar.MaxDegrees
 }
 
 // Validate ensures all parts of the config are valid.
-func (config *AttrConfig) Validate(path string) error {
+func (config *AttrConfig) Validate(path string) ([]string, error) {
 	if config.Port == 0 {
-		return gutils.NewConfigValidationFieldRequiredError(path, "port")
+		return nil, gutils.NewConfigValidationFieldRequiredError(path, "port")
 	}
 
 	if config.TTLMS == 0 {
-		return gutils.NewConfigValidationFieldRequiredError(path, "ttl_ms")
+		return nil, gutils.NewConfigValidationFieldRequiredError(path, "ttl_ms")
 	}
-	return nil
+	if config.MaxReconnectAttempts < 0 {
+		return nil, gutils.NewConfigValidationError(path, errors.New("max_reconnect_attempts cannot be negative"))
+	}
+	for _, ar := range config.AngularMask {
+		if ar.MinDegrees < 0 || ar.MinDegrees > 360 || ar.MaxDegrees < 0 || ar.MaxDegrees > 360 {
+			return nil, gutils.NewConfigValidationError(path, errors.Errorf("angular_mask range %v is not within [0, 360]", ar))
+		}
+	}
+	if config.MinDistanceMM < 0 || config.MaxDistanceMM < 0 {
+		return nil, gutils.NewConfigValidationError(path, errors.New("min_distance_mm and max_distance_mm cannot be negative"))
+	}
+	if config.MaxDistanceMM != 0 && config.MinDistanceMM >= config.MaxDistanceMM {
+		return nil, gutils.NewConfigValidationError(path, errors.New("min_distance_mm must be less than max_distance_mm"))
+	}
+	if err := config.Convention.Validate(); err != nil {
+		return nil, gutils.NewConfigValidationError(path, err)
+	}
+	var deps []string
+	if config.MovementSensor != "" {
+		deps = append(deps, config.MovementSensor)
+	}
+	return deps, nil
 }
 
-const modelname = "velodyne"
+const (
+	modelname            = "velodyne"
+	defaultReconnectWait = time.Second
+)
 
 func init() {
 	registry.RegisterComponent(
@@ -94,7 +211,7 @@ func init() {
 		modelname,
 		registry.Component{Constructor: func(
 			ctx context.Context,
-			_ registry.Dependencies,
+			deps registry.Dependencies,
 			config config.Component,
 			logger golog.Logger,
 		) (interface{}, error) {
@@ -108,12 +225,19 @@ func init() {
 				port = 2368
 			}
 
-			ttl := attr.TTLMS
-			if ttl == 0 {
+			if attr.TTLMS == 0 {
 				return nil, errors.New("need to specify a ttl")
 			}
 
-			return New(ctx, logger, port, ttl)
+			var ms movementsensor.MovementSensor
+			if attr.MovementSensor != "" {
+				var err error
+				if ms, err = movementsensor.FromDependencies(deps, attr.MovementSensor); err != nil {
+					return nil, err
+				}
+			}
+
+			return newVelodyne(ctx, logger, port, attr, ms, listenUDP)
 		}})
 
 	config.RegisterComponentAttributeMapConverter(camera.SubtypeName, modelname,
@@ -123,11 +247,33 @@ func init() {
 		}, &AttrConfig{})
 }
 
+// packetSource is the part of a vlp16.PacketListener the client reads packets from.
+type packetSource interface {
+	ReadPacket() error
+	Packet() *vlp16.Packet
+	SourceIP() net.IP
+	Close() error
+}
+
+type listenFunc func(ctx context.Context, bindAddress string) (packetSource, error)
+
+func listenUDP(ctx context.Context, bindAddress string) (packetSource, error) {
+	return vlp16.ListenUDP(ctx, bindAddress)
+}
+
 type client struct {
-	generic.Unimplemented
 	bindAddress     string
 	ttlMilliseconds int
 
+	listen               listenFunc
+	reconnectWait        time.Duration
+	maxReconnectAttempts int
+	angularMask          []AngleRange
+	minDistanceMM        float64
+	maxDistanceMM        float64
+	movementSensor       movementsensor.MovementSensor
+	convention           Convention
+
 	logger golog.Logger
 
 	cancelFunc              func()
@@ -135,46 +281,92 @@ type client struct {
 
 	mu sync.Mutex
 
-	lastError error
-	product   vlp16.ProductID
-	ip        string
-	packets   []vlp16.Packet
+	lastError         error
+	reconnectAttempts int
+	product           vlp16.ProductID
+	ip                string
+	packets           []vlp16.Packet
 }
 
 // New creates a connection to a Velodyne lidar and generates pointclouds from it.
 func New(ctx context.Context, logger golog.Logger, port, ttlMilliseconds int) (camera.Camera, error) {
+	return newVelodyne(ctx, logger, port, &AttrConfig{TTLMS: ttlMilliseconds}, nil, listenUDP)
+}
+
+func newVelodyne(
+	ctx context.Context,
+	logger golog.Logger,
+	port int,
+	attr *AttrConfig,
+	ms movementsensor.MovementSensor,
+	listen listenFunc,
+) (camera.Camera, error) {
 	bindAddress := fmt.Sprintf("0.0.0.0:%d", port)
-	listener, err := vlp16.ListenUDP(ctx, bindAddress)
+	listener, err := listen(ctx, bindAddress)
 	if err != nil {
 		return nil, err
 	}
-	// Listen for and print packets.
 
-	c := &client{
-		bindAddress:     bindAddress,
-		ttlMilliseconds: ttlMilliseconds,
-		logger:          logger,
+	c := newClient(logger, bindAddress, attr, listen)
+	c.movementSensor = ms
+	c.start(listener)
+	return camera.NewFromReader(ctx, c, nil, camera.DepthStream)
+}
+
+func newClient(logger golog.Logger, bindAddress string, attr *AttrConfig, listen listenFunc) *client {
+	return &client{
+		bindAddress:          bindAddress,
+		ttlMilliseconds:      attr.TTLMS,
+		listen:               listen,
+		reconnectWait:        defaultReconnectWait,
+		maxReconnectAttempts: attr.MaxReconnectAttempts,
+		angularMask:          attr.AngularMask,
+		minDistanceMM:        attr.MinDistanceMM,
+		maxDistanceMM:        attr.MaxDistanceMM,
+		convention:           attr.Convention,
+		logger:               logger,
 	}
+}
 
+// start listens for packets in the background until the client is closed.
+func (c *client) start(listener packetSource) {
 	cancelCtx, cancelFunc := context.WithCancel(context.Background())
 	c.cancelFunc = cancelFunc
 	c.activeBackgroundWorkers.Add(1)
 	gutils.PanicCapturingGo(func() {
 		c.run(cancelCtx, listener)
 	})
-
-	return camera.NewFromReader(ctx, c, nil, camera.DepthStream)
 }
 
 func (c *client) setLastError(err error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.lastError = err
+	if err == nil {
+		c.reconnectAttempts = 0
+	}
 }
 
-func (c *client) run(ctx context.Context, listener *vlp16.PacketListener) {
-	defer gutils.UncheckedErrorFunc(listener.Close)
+// nextReconnectAttempt counts a reconnection attempt and returns false once we have used
+// up all of them.
+func (c *client) nextReconnectAttempt() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.maxReconnectAttempts > 0 && c.reconnectAttempts >= c.maxReconnectAttempts {
+		c.lastError = errors.Wrapf(c.lastError, "gave up reconnecting to velodyne after %d attempts", c.reconnectAttempts)
+		return false
+	}
+	c.reconnectAttempts++
+	return true
+}
+
+func (c *client) run(ctx context.Context, listener packetSource) {
 	defer c.activeBackgroundWorkers.Done()
+	defer func() {
+		if listener != nil {
+			gutils.UncheckedError(listener.Close())
+		}
+	}()
 
 	for {
 		err := ctx.Err()
@@ -184,11 +376,16 @@ func (c *client) run(ctx context.Context, listener *vlp16.PacketListener) {
 		}
 
 		if listener == nil {
-			listener, err = vlp16.ListenUDP(ctx, c.bindAddress)
+			if !c.nextReconnectAttempt() {
+				c.logger.Errorw("giving up reconnecting to velodyne", "attempts", c.maxReconnectAttempts)
+				return
+			}
+			listener, err = c.listen(ctx, c.bindAddress)
 			if err != nil {
+				listener = nil
 				c.setLastError(err)
 				c.logger.Infof("velodyne connect error: %w", err)
-				if !gutils.SelectContextOrWait(ctx, time.Second) {
+				if !gutils.SelectContextOrWait(ctx, c.reconnectWait) {
 					return
 				}
 				continue
@@ -204,14 +401,38 @@ func (c *client) run(ctx context.Context, listener *vlp16.PacketListener) {
 				c.logger.Warn("trying to close connection after error got", "error", err)
 			}
 			listener = nil
-			if !gutils.SelectContextOrWait(ctx, time.Second) {
+			if !gutils.SelectContextOrWait(ctx, c.reconnectWait) {
 				return
 			}
 		}
 	}
 }
 
-func (c *client) runLoop(listener *vlp16.PacketListener) error {
+// DoCommand supports a "health" command reporting whether the lidar is currently
+// delivering packets.
+func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["command"]
+	if !ok {
+		return nil, errors.New("missing 'command' value")
+	}
+	switch name {
+	case "health":
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		health := map[string]interface{}{
+			"healthy":            c.lastError == nil,
+			"reconnect_attempts": c.reconnectAttempts,
+		}
+		if c.lastError != nil {
+			health["error"] = c.lastError.Error()
+		}
+		return health, nil
+	default:
+		return nil, fmt.Errorf("no such command: %s", name)
+	}
+}
+
+func (c *client) runLoop(listener packetSource) error {
 	if err := listener.ReadPacket(); err != nil {
 		return err
 	}
@@ -271,7 +492,40 @@ func pointFrom(yaw, pitch, distance float64) r3.Vector {
 	return pointcloud.NewVector(p.X*1000, p.Y*1000, p.Z*1000)
 }
 
+// scanMotion returns how the lidar moved over the given seconds at constant velocities, as the
+// pose of where it ended up relative to where it started.
+func scanMotion(linear r3.Vector, angular spatialmath.AngularVelocity, seconds float64) spatialmath.Pose {
+	rotation := r3.Vector(angular).Mul(utils.DegToRad(seconds))
+	orientation := spatialmath.NewZeroOrientation()
+	if rotation.Norm() > 0 {
+		orientation = spatialmath.R3ToR4(rotation)
+	}
+	return spatialmath.NewPoseFromOrientation(linear.Mul(seconds), orientation)
+}
+
+// packetAge returns how many seconds before the newest packet a packet arrived. Timestamps count
+// microseconds since the top of the hour, so they wrap around every hour.
+func packetAge(newest, timestamp uint32) float64 {
+	age := int64(newest) - int64(timestamp)
+	if age < 0 {
+		age += int64(time.Hour / time.Microsecond)
+	}
+	return float64(age) / 1e6
+}
+
 func (c *client) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, error) {
+	var linear r3.Vector
+	var angular spatialmath.AngularVelocity
+	if c.movementSensor != nil {
+		var err error
+		if linear, err = c.movementSensor.LinearVelocity(ctx, nil); err != nil {
+			return nil, err
+		}
+		if angular, err = c.movementSensor.AngularVelocity(ctx, nil); err != nil {
+			return nil, err
+		}
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.lastError != nil {
@@ -283,8 +537,14 @@ func (c *client) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, err
 		return nil, fmt.Errorf("no config for %s", c.product)
 	}
 
+	mask, convention := c.angularMask, c.convention
+	minDistance, maxDistance := c.minDistanceMM, c.maxDistanceMM
 	pc := pointcloud.New()
 	for _, p := range c.packets {
+		var deskew spatialmath.Pose
+		if c.movementSensor != nil {
+			deskew = spatialmath.PoseInverse(scanMotion(linear, angular, packetAge(c.packets[len(c.packets)-1].Timestamp, p.Timestamp)))
+		}
 		for _, b := range p.Blocks {
 			yaw := float64(b.Azimuth) / 100
 			for channelID, c := range b.Channels {
@@ -293,10 +553,17 @@ func (c *client) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, err
 				}
 				pitch := config[channelID].elevationAngle
 				yaw += config[channelID].azimuthOffset
-				err := pc.Set(
-					pointFrom(utils.DegToRad(yaw), utils.DegToRad(pitch), float64(c.Distance)/1000),
-					pointcloud.NewBasicData().SetIntensity(uint16(c.Reflectivity)*255),
-				)
+				if masked(mask, yaw) {
+					continue
+				}
+				if distance := float64(c.Distance); distance < minDistance || (maxDistance != 0 && distance > maxDistance) {
+					continue
+				}
+				pt := pointFrom(utils.DegToRad(convention.yaw(yaw)), utils.DegToRad(pitch), float64(c.Distance)/1000)
+				if deskew != nil {
+					pt = spatialmath.TransformPoint(deskew, pt)
+				}
+				err := pc.Set(pt, pointcloud.NewBasicData().SetIntensity(uint16(c.Reflectivity)*255))
 				if err != nil {
 					return nil, err
 				}
@@ -307,6 +574,20 @@ func (c *client) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, err
 	return pc, nil
 }
 
+// masked returns whether the given azimuth, in degrees, falls within any of the ranges of the mask.
+func masked(mask []AngleRange, yaw float64) bool {
+	yaw = math.Mod(yaw, 360)
+	if yaw < 0 {
+		yaw += 360
+	}
+	for _, ar := range mask {
+		if ar.contains(yaw) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *client) Read(ctx context.Context) (image.Image, func(), error) {
 	pc, err := c.NextPointCloud(ctx)
 	if err != nil {
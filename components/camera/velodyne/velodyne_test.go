@@ -0,0 +1,337 @@
+package velodyne
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"go.einride.tech/vlp16"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
+)
+
+// fakeListener returns the same packet on every read until it is told to fail.
+type fakeListener struct {
+	mu        sync.Mutex
+	failReads int
+	timestamp uint32
+	packet    vlp16.Packet
+}
+
+func (fl *fakeListener) ReadPacket() error {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	if fl.failReads > 0 {
+		fl.failReads--
+		return errors.New("usb hiccup")
+	}
+	fl.timestamp++
+	fl.packet.Timestamp = fl.timestamp
+	fl.packet.ProductID = vlp16.ProductIDVLP32C
+	return nil
+}
+
+func (fl *fakeListener) Packet() *vlp16.Packet {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	p := fl.packet
+	return &p
+}
+
+func (fl *fakeListener) SourceIP() net.IP {
+	return net.IPv4(192, 168, 1, 201)
+}
+
+func (fl *fakeListener) Close() error {
+	return nil
+}
+
+func health(tb testing.TB, c *client) map[string]interface{} {
+	tb.Helper()
+	resp, err := c.DoCommand(context.Background(), map[string]interface{}{"command": "health"})
+	test.That(tb, err, test.ShouldBeNil)
+	return resp
+}
+
+func TestReconnect(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	t.Run("recovers after a failure", func(t *testing.T) {
+		var mu sync.Mutex
+		listens := 0
+		listen := func(ctx context.Context, bindAddress string) (packetSource, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			listens++
+			return &fakeListener{}, nil
+		}
+		c := newClient(logger, "", &AttrConfig{TTLMS: 1000, MaxReconnectAttempts: 3}, listen)
+		c.reconnectWait = time.Millisecond
+		c.start(&fakeListener{failReads: 1})
+		defer func() {
+			test.That(t, c.Close(context.Background()), test.ShouldBeNil)
+		}()
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			mu.Lock()
+			listened := listens
+			mu.Unlock()
+			test.That(tb, listened, test.ShouldEqual, 1)
+			resp := health(tb, c)
+			test.That(tb, resp["healthy"], test.ShouldBeTrue)
+			test.That(tb, resp["reconnect_attempts"], test.ShouldEqual, 0)
+		})
+		pc, err := c.NextPointCloud(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pc.Size(), test.ShouldBeGreaterThan, 0)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		listen := func(ctx context.Context, bindAddress string) (packetSource, error) {
+			return nil, errors.New("no such device")
+		}
+		c := newClient(logger, "", &AttrConfig{TTLMS: 1000, MaxReconnectAttempts: 2}, listen)
+		c.reconnectWait = time.Millisecond
+		c.start(&fakeListener{failReads: 1})
+		defer func() {
+			test.That(t, c.Close(context.Background()), test.ShouldBeNil)
+		}()
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			resp := health(tb, c)
+			test.That(tb, resp["healthy"], test.ShouldBeFalse)
+			test.That(tb, resp["error"], test.ShouldContainSubstring, "gave up reconnecting to velodyne after 2 attempts")
+		})
+		_, err := c.NextPointCloud(context.Background())
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+// scanPacket returns a packet with one block every 30 degrees of azimuth, starting at 0.
+func scanPacket() vlp16.Packet {
+	var packet vlp16.Packet
+	for i := range packet.Blocks {
+		packet.Blocks[i].Azimuth = uint16(i * 3000)
+		for j := range packet.Blocks[i].Channels {
+			packet.Blocks[i].Channels[j].Distance = uint16(1000 + j)
+		}
+	}
+	return packet
+}
+
+func TestAngularMask(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	attr := &AttrConfig{
+		Port:        2368,
+		TTLMS:       1000,
+		AngularMask: []AngleRange{{MinDegrees: 80, MaxDegrees: 130}, {MinDegrees: 320, MaxDegrees: 10}},
+	}
+	_, err := attr.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	c := newClient(logger, "", attr, listenUDP)
+	c.product = vlp16.ProductIDVLP32C
+	c.packets = []vlp16.Packet{scanPacket()}
+	pc, err := c.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldBeGreaterThan, 0)
+
+	unmasked := newClient(logger, "", &AttrConfig{TTLMS: 1000}, listenUDP)
+	unmasked.product = vlp16.ProductIDVLP32C
+	unmasked.packets = c.packets
+	full, err := unmasked.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldBeLessThan, full.Size())
+
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		yaw := utils.RadToDeg(math.Atan2(p.Y, p.X))
+		if yaw < 0 {
+			yaw += 360
+		}
+		test.That(t, yaw < 80 || yaw > 130, test.ShouldBeTrue)
+		test.That(t, yaw > 10 && yaw < 320, test.ShouldBeTrue)
+		return true
+	})
+
+	attr.AngularMask = []AngleRange{{MinDegrees: -10, MaxDegrees: 10}}
+	_, err = attr.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDistanceFilter(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	attr := &AttrConfig{Port: 2368, TTLMS: 1000, MinDistanceMM: 1010, MaxDistanceMM: 1020}
+	_, err := attr.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	c := newClient(logger, "", attr, listenUDP)
+	c.product = vlp16.ProductIDVLP32C
+	c.packets = []vlp16.Packet{scanPacket()}
+	pc, err := c.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	// 11 of the 32 channels of each of the 12 blocks are within range
+	test.That(t, pc.Size(), test.ShouldEqual, 11*12)
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		test.That(t, p.Norm(), test.ShouldBeBetweenOrEqual, 1010-1e-6, 1020+1e-6)
+		return true
+	})
+
+	attr.MaxDistanceMM = 1000
+	_, err = attr.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	attr.MaxDistanceMM = 0
+	_, err = attr.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	attr.MinDistanceMM = -1
+	_, err = attr.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDeskew(t *testing.T) {
+	linear := r3.Vector{X: 1000}
+	angular := spatialmath.AngularVelocity{Z: 90}
+	const scanSeconds = 0.1
+
+	// poseAt follows the lidar moving at constant velocities in small steps
+	poseAt := func(seconds float64) spatialmath.Pose {
+		const step = 1e-4
+		pose := spatialmath.NewZeroPose()
+		for elapsed := 0.; elapsed < seconds-step/2; elapsed += step {
+			pose = spatialmath.Compose(pose, scanMotion(linear, angular, step))
+		}
+		return pose
+	}
+	end := spatialmath.PoseInverse(poseAt(scanSeconds))
+
+	// a wall 5m around the lidar is swept once during the scan
+	var maxSkewed, maxDeskewed float64
+	for k := 0; k < 100; k++ {
+		seconds := scanSeconds * float64(k) / 100
+		angle := 2 * math.Pi * float64(k) / 100
+		world := r3.Vector{X: 5000 * math.Cos(angle), Y: 5000 * math.Sin(angle)}
+		seen := spatialmath.TransformPoint(spatialmath.PoseInverse(poseAt(seconds)), world)
+		want := spatialmath.TransformPoint(end, world)
+		deskewed := spatialmath.TransformPoint(spatialmath.PoseInverse(scanMotion(linear, angular, scanSeconds-seconds)), seen)
+		maxSkewed = math.Max(maxSkewed, seen.Distance(want))
+		maxDeskewed = math.Max(maxDeskewed, deskewed.Distance(want))
+	}
+	test.That(t, maxSkewed, test.ShouldBeGreaterThan, 500)
+	test.That(t, maxDeskewed, test.ShouldBeLessThan, maxSkewed/10)
+
+	test.That(t, packetAge(100, 40), test.ShouldAlmostEqual, 60e-6)
+	// timestamps wrap around at the top of the hour
+	test.That(t, packetAge(10, 3599999990), test.ShouldAlmostEqual, 20e-6)
+
+	t.Run("point cloud", func(t *testing.T) {
+		logger := golog.NewTestLogger(t)
+		ms := &inject.MovementSensor{}
+		ms.LinearVelocityFunc = func(ctx context.Context, extra map[string]interface{}) (r3.Vector, error) {
+			return linear, nil
+		}
+		ms.AngularVelocityFunc = func(ctx context.Context, extra map[string]interface{}) (spatialmath.AngularVelocity, error) {
+			return spatialmath.AngularVelocity{}, nil
+		}
+		c := newClient(logger, "", &AttrConfig{TTLMS: 1000}, listenUDP)
+		c.product = vlp16.ProductIDVLP32C
+		older, newer := scanPacket(), scanPacket()
+		newer.Timestamp = 100000
+		c.packets = []vlp16.Packet{newer}
+		still, err := c.NextPointCloud(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+
+		c.movementSensor = ms
+		c.packets = []vlp16.Packet{older, newer}
+		pc, err := c.NextPointCloud(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pc.Size(), test.ShouldEqual, 2*still.Size())
+		// the lidar moved 100mm forward since the older packet, so what it saw is 100mm further back
+		still.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+			_, got := pc.At(p.X, p.Y, p.Z)
+			test.That(t, got, test.ShouldBeTrue)
+			found := false
+			pc.Iterate(0, 0, func(q r3.Vector, d pointcloud.Data) bool {
+				found = q.Distance(p.Sub(r3.Vector{X: 100})) < 1e-6
+				return !found
+			})
+			test.That(t, found, test.ShouldBeTrue)
+			return true
+		})
+	})
+
+	attr := &AttrConfig{Port: 2368, TTLMS: 1000, MovementSensor: "imu"}
+	deps, err := attr.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"imu"})
+}
+
+func TestConvention(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	velodyneConvention := Convention{ZeroAxis: "+y", Clockwise: true}
+
+	c := newClient(logger, "", &AttrConfig{TTLMS: 1000}, listenUDP)
+	c.product = vlp16.ProductIDVLP32C
+	c.packets = []vlp16.Packet{scanPacket()}
+	scan, err := c.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+
+	c.convention = velodyneConvention
+	want, err := c.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+
+	// contains returns whether every point of a is within a micron of a point of b
+	contains := func(a, b pointcloud.PointCloud) bool {
+		all := true
+		a.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+			found := false
+			b.Iterate(0, 0, func(q r3.Vector, d pointcloud.Data) bool {
+				found = q.Distance(p) < 1e-6
+				return !found
+			})
+			all = found
+			return all
+		})
+		return all
+	}
+
+	converted, err := ConvertScan(scan, Convention{}, velodyneConvention)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, converted.Size(), test.ShouldEqual, want.Size())
+	test.That(t, contains(converted, want), test.ShouldBeTrue)
+
+	// a return at an azimuth of 90 degrees is along +y by default and along +x for velodyne
+	single := pointcloud.New()
+	test.That(t, single.Set(r3.Vector{Y: 1000, Z: 5}, nil), test.ShouldBeNil)
+	quarter, err := ConvertScan(single, Convention{}, velodyneConvention)
+	test.That(t, err, test.ShouldBeNil)
+	quarter.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		test.That(t, p.X, test.ShouldAlmostEqual, 1000)
+		test.That(t, p.Y, test.ShouldAlmostEqual, 0)
+		test.That(t, p.Z, test.ShouldEqual, 5)
+		return true
+	})
+
+	back, err := ConvertScan(converted, velodyneConvention, Convention{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, back.Size(), test.ShouldEqual, scan.Size())
+	test.That(t, contains(scan, back), test.ShouldBeTrue)
+
+	_, err = ConvertScan(scan, Convention{ZeroAxis: "z"}, Convention{})
+	test.That(t, err, test.ShouldNotBeNil)
+	attr := &AttrConfig{Port: 2368, TTLMS: 1000, Convention: Convention{ZeroAxis: "up"}}
+	_, err = attr.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "zero_axis must be one of")
+}
@@ -71,6 +71,8 @@ type JoinAttrs struct {
 	CameraParameters     *transform.PinholeCameraIntrinsics `json:"intrinsic_parameters,omitempty"`
 	DistortionParameters *transform.BrownConrady            `json:"distortion_parameters,omitempty"`
 	Debug                bool                               `json:"debug,omitempty"`
+	// SectorDegrees is the width of the angular sectors used by the sector merge method.
+	SectorDegrees float64 `json:"sector_degrees,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -79,6 +81,9 @@ func (cfg *JoinAttrs) Validate(path string) ([]string, error) {
 	if len(cfg.SourceCameras) == 0 {
 		return nil, utils.NewConfigValidationFieldRequiredError(path, "source_cameras")
 	}
+	if cfg.SectorDegrees < 0 || cfg.SectorDegrees > 360 {
+		return nil, utils.NewConfigValidationError(path, errors.Errorf("sector_degrees must be between 0 and 360, got %v", cfg.SectorDegrees))
+	}
 	deps = append(deps, cfg.SourceCameras...)
 	return deps, nil
 }
@@ -97,8 +102,14 @@ const (
 	Naive = MergeMethodType("naive")
 	// ICP is the ICP merge method.
 	ICP = MergeMethodType("icp")
+	// Sector is the merge method that fuses the sources into a single scan around the target frame,
+	// taking each angular sector from the source with the most points in it.
+	Sector = MergeMethodType("sector")
 )
 
+// defaultSectorDegrees is the width of the sectors compared when merging with the Sector method.
+const defaultSectorDegrees = 1.
+
 func newMergeMethodUnsupportedError(method string) MergeMethodUnsupportedError {
 	return errors.Errorf("merge method %s not supported", method)
 }
@@ -116,6 +127,7 @@ type joinPointCloudSource struct {
 	logger        golog.Logger
 	debug         bool
 	closeness     float64
+	sectorDegrees float64
 }
 
 // newJoinPointCloudSource creates a camera that combines point cloud sources into one point cloud in the
@@ -137,6 +149,10 @@ func newJoinPointCloudSource(ctx context.Context, r robot.Robot, l golog.Logger,
 	joinSource.targetName = attrs.TargetFrame
 	joinSource.robot = r
 	joinSource.closeness = attrs.Closeness
+	joinSource.sectorDegrees = attrs.SectorDegrees
+	if joinSource.sectorDegrees == 0 {
+		joinSource.sectorDegrees = defaultSectorDegrees
+	}
 
 	joinSource.logger = l
 	joinSource.debug = attrs.Debug
@@ -167,6 +183,8 @@ func (jpcs *joinPointCloudSource) NextPointCloud(ctx context.Context) (pointclou
 		return jpcs.NextPointCloudNaive(ctx)
 	case ICP:
 		return jpcs.NextPointCloudICP(ctx)
+	case Sector:
+		return jpcs.NextPointCloudSector(ctx)
 	default:
 		return nil, newMergeMethodUnsupportedError(string(jpcs.mergeMethod))
 	}
@@ -176,6 +194,16 @@ func (jpcs *joinPointCloudSource) NextPointCloudNaive(ctx context.Context) (poin
 	ctx, span := trace.StartSpan(ctx, "joinPointCloudSource::NextPointCloudNaive")
 	defer span.End()
 
+	cloudFuncs, err := jpcs.cloudFuncs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pointcloud.MergePointClouds(ctx, cloudFuncs, jpcs.logger)
+}
+
+// cloudFuncs returns a function for each source camera that gets its point cloud along with
+// the pose of the source in the target frame.
+func (jpcs *joinPointCloudSource) cloudFuncs(ctx context.Context) ([]pointcloud.CloudAndOffsetFunc, error) {
 	fs, err := framesystem.RobotFrameSystem(ctx, jpcs.robot, nil)
 	if err != nil {
 		return nil, err
@@ -213,7 +241,66 @@ func (jpcs *joinPointCloudSource) NextPointCloudNaive(ctx context.Context) (poin
 		cloudFuncs[iCopy] = pcSrc
 	}
 
-	return pointcloud.MergePointClouds(ctx, cloudFuncs, jpcs.logger)
+	return cloudFuncs, nil
+}
+
+// NextPointCloudSector gets all the point clouds from the source cameras and fuses them into a single
+// scan around the target frame. The space around the target frame is divided into angular sectors in
+// its XY plane; where sources overlap, each sector is taken only from the source with the most points
+// in it, so the higher resolution source wins.
+func (jpcs *joinPointCloudSource) NextPointCloudSector(ctx context.Context) (pointcloud.PointCloud, error) {
+	ctx, span := trace.StartSpan(ctx, "joinPointCloudSource::NextPointCloudSector")
+	defer span.End()
+
+	cloudFuncs, err := jpcs.cloudFuncs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type sectorPoint struct {
+		p r3.Vector
+		d pointcloud.Data
+	}
+	numSectors := int(math.Ceil(360 / jpcs.sectorDegrees))
+	// sectors[i][j] holds the points of source j that fall in sector i
+	sectors := make([][][]sectorPoint, numSectors)
+	for i := range sectors {
+		sectors[i] = make([][]sectorPoint, len(cloudFuncs))
+	}
+	for j, cloudFunc := range cloudFuncs {
+		pc, offset, err := cloudFunc(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+			if offset != nil {
+				p = spatialmath.Compose(offset, spatialmath.NewPoseFromPoint(p)).Point()
+			}
+			degrees := rdkutils.RadToDeg(math.Atan2(p.Y, p.X))
+			if degrees < 0 {
+				degrees += 360
+			}
+			i := int(degrees/jpcs.sectorDegrees) % numSectors
+			sectors[i][j] = append(sectors[i][j], sectorPoint{p, d})
+			return true
+		})
+	}
+
+	fused := pointcloud.New()
+	for _, sector := range sectors {
+		best := 0
+		for j := range sector {
+			if len(sector[j]) > len(sector[best]) {
+				best = j
+			}
+		}
+		for _, sp := range sector[best] {
+			if err := fused.Set(sp.p, sp.d); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fused, nil
 }
 
 func (jpcs *joinPointCloudSource) NextPointCloudICP(ctx context.Context) (pointcloud.PointCloud, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"image"
 	"image/color"
+	"math"
 	"os"
 	"testing"
 	"time"
@@ -456,3 +457,125 @@ func TestMultiPointCloudICP(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, pc, test.ShouldNotBeNil)
 }
+
+// makeHalfScan returns a planar scan of points 1000mm away at the given angles, in degrees.
+func makeHalfScan(t *testing.T, col color.NRGBA, fromDegrees, toDegrees, stepDegrees float64) pointcloud.PointCloud {
+	t.Helper()
+	pc := pointcloud.New()
+	for deg := fromDegrees; deg < toDegrees; deg += stepDegrees {
+		rad := rdkutils.DegToRad(deg)
+		err := pc.Set(pointcloud.NewVector(1000*math.Cos(rad), 1000*math.Sin(rad), 0), pointcloud.NewColoredData(col))
+		test.That(t, err, test.ShouldBeNil)
+	}
+	return pc
+}
+
+func TestJoinPointCloudSector(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	red := color.NRGBA{255, 0, 0, 255}
+	blue := color.NRGBA{0, 0, 255, 255}
+
+	// the front lidar sees the front half of the robot, the back lidar is mounted facing backwards and
+	// also sees part of the front half, at a higher resolution
+	front := &inject.Camera{}
+	front.NextPointCloudFunc = func(ctx context.Context) (pointcloud.PointCloud, error) {
+		return makeHalfScan(t, red, 1, 180, 2), nil
+	}
+	back := &inject.Camera{}
+	back.NextPointCloudFunc = func(ctx context.Context) (pointcloud.PointCloud, error) {
+		pc := makeHalfScan(t, blue, 1, 180, 2)
+		overlap := makeHalfScan(t, blue, -29.75, 0, 0.5)
+		overlap.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+			test.That(t, pc.Set(p, d), test.ShouldBeNil)
+			return true
+		})
+		return pc, nil
+	}
+	for _, cam := range []*inject.Camera{front, back} {
+		cam.PropertiesFunc = func(ctx context.Context) (camera.Properties, error) {
+			return camera.Properties{}, nil
+		}
+	}
+
+	r := &inject.Robot{}
+	fsParts := framesystemparts.Parts{
+		{
+			Name:        "base1",
+			FrameConfig: &config.Frame{Parent: referenceframe.World},
+		},
+		{
+			Name:        "front",
+			FrameConfig: &config.Frame{Parent: "base1"},
+		},
+		{
+			Name: "back",
+			FrameConfig: &config.Frame{
+				Parent:      "base1",
+				Orientation: &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 180},
+			},
+		},
+	}
+	r.FrameSystemConfigFunc = func(
+		ctx context.Context,
+		additionalTransforms []*referenceframe.PoseInFrame,
+	) (framesystemparts.Parts, error) {
+		return fsParts, nil
+	}
+	r.LoggerFunc = func() golog.Logger {
+		return logger
+	}
+	r.ResourceNamesFunc = func() []resource.Name {
+		return []resource.Name{camera.Named("front"), camera.Named("back"), base.Named("base1")}
+	}
+	r.ResourceByNameFunc = func(n resource.Name) (interface{}, error) {
+		switch n.Name {
+		case "front":
+			return front, nil
+		case "back":
+			return back, nil
+		case "base1":
+			return &inject.Base{}, nil
+		default:
+			return nil, rdkutils.NewResourceNotFoundError(n)
+		}
+	}
+
+	attrs := &JoinAttrs{
+		SourceCameras: []string{"front", "back"},
+		TargetFrame:   "base1",
+		MergeMethod:   "sector",
+		SectorDegrees: 10,
+	}
+	_, err := attrs.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	joinedCam, err := newJoinPointCloudSource(context.Background(), r, logger, attrs)
+	test.That(t, err, test.ShouldBeNil)
+	defer joinedCam.Close(context.Background())
+	pc, err := joinedCam.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	// 75 front points below 150 degrees, 90 back points behind the robot and 60 overlapping back points
+	test.That(t, pc.Size(), test.ShouldEqual, 225)
+
+	covered := make([]bool, 36)
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		degrees := rdkutils.RadToDeg(math.Atan2(p.Y, p.X))
+		if degrees < 0 {
+			degrees += 360
+		}
+		covered[int(degrees/10)] = true
+		if degrees > 150 && degrees < 180 {
+			// the overlap comes from the higher resolution back lidar
+			test.That(t, d.Color(), test.ShouldResemble, &blue)
+		}
+		return true
+	})
+	for _, c := range covered {
+		test.That(t, c, test.ShouldBeTrue)
+	}
+
+	attrs.SectorDegrees = -1
+	_, err = attrs.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "path")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "sector_degrees")
+}
@@ -4,12 +4,12 @@ package gantry
 import (
 	"context"
 
-	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/gantry/v1"
 
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
 // subtypeServer implements the GantryService from gantry.proto.
@@ -27,11 +27,11 @@ func NewServer(s subtype.Service) pb.GantryServiceServer {
 func (s *subtypeServer) getGantry(name string) (Gantry, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no gantry with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("gantry", name)
 	}
 	gantry, ok := resource.(Gantry)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a gantry", name)
+		return nil, utils.NewWrongTypeByNameError("a gantry", name)
 	}
 	return gantry, nil
 }
@@ -0,0 +1,111 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// An ArgType is the type a command argument must have, as decoded from JSON.
+type ArgType int
+
+// The types of command arguments.
+const (
+	ArgNumber ArgType = iota
+	ArgString
+	ArgBool
+	ArgMap
+	ArgList
+)
+
+func (t ArgType) String() string {
+	switch t {
+	case ArgNumber:
+		return "number"
+	case ArgString:
+		return "string"
+	case ArgBool:
+		return "bool"
+	case ArgMap:
+		return "map"
+	case ArgList:
+		return "list"
+	default:
+		return fmt.Sprintf("ArgType(%d)", int(t))
+	}
+}
+
+func (t ArgType) matches(v interface{}) bool {
+	switch t {
+	case ArgNumber:
+		_, ok := v.(float64)
+		return ok
+	case ArgString:
+		_, ok := v.(string)
+		return ok
+	case ArgBool:
+		_, ok := v.(bool)
+		return ok
+	case ArgMap:
+		_, ok := v.(map[string]interface{})
+		return ok
+	case ArgList:
+		_, ok := v.([]interface{})
+		return ok
+	default:
+		return false
+	}
+}
+
+// An Arg is an argument of a Command, passed by name alongside "command".
+type Arg struct {
+	Name     string
+	Type     ArgType
+	Required bool
+}
+
+// A Command is a custom behavior of a resource that has no place in its API.
+type Command struct {
+	Args []Arg
+	// Run runs the command once its arguments are checked. It gets the whole cmd given to
+	// DoCommand, so optional arguments that were left out are missing.
+	Run func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error)
+}
+
+// Commands are the custom commands of a resource by name. A resource implements DoCommand by
+// calling DoCommand on its Commands.
+type Commands map[string]Command
+
+// DoCommand runs the command named by cmd["command"] after checking that its arguments are given
+// and of the right types. Arguments a command does not declare are passed along unchecked.
+func (c Commands) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["command"]
+	if !ok {
+		return nil, errors.New("missing 'command' value")
+	}
+	command, ok := c[fmt.Sprint(name)]
+	if !ok {
+		return nil, fmt.Errorf("no such command: %s", name)
+	}
+	for _, arg := range command.Args {
+		v, ok := cmd[arg.Name]
+		if !ok {
+			if arg.Required {
+				return nil, errors.Errorf("%s needs '%s', a %s", name, arg.Name, arg.Type)
+			}
+			continue
+		}
+		if !arg.Type.matches(v) {
+			return nil, errors.Errorf("'%s' of %s must be a %s, not %T", arg.Name, name, arg.Type, v)
+		}
+	}
+	resp, err := command.Run(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = map[string]interface{}{}
+	}
+	return resp, nil
+}
@@ -0,0 +1,65 @@
+package generic_test
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/generic"
+)
+
+type calibrator struct {
+	generic.Unimplemented
+	offset float64
+}
+
+func (c *calibrator) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return generic.Commands{
+		"calibrate": {
+			Args: []generic.Arg{
+				{Name: "offset", Type: generic.ArgNumber, Required: true},
+				{Name: "note", Type: generic.ArgString},
+			},
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				c.offset = cmd["offset"].(float64)
+				return map[string]interface{}{"offset": c.offset}, nil
+			},
+		},
+		"reset": {
+			Run: func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+				c.offset = 0
+				return nil, nil
+			},
+		},
+	}.DoCommand(ctx, cmd)
+}
+
+func TestCommands(t *testing.T) {
+	var c calibrator
+	var g generic.Generic = &c
+	ctx := context.Background()
+
+	resp, err := g.DoCommand(ctx, map[string]interface{}{"command": "calibrate", "offset": 2.5, "unused": true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldResemble, map[string]interface{}{"offset": 2.5})
+	test.That(t, c.offset, test.ShouldEqual, 2.5)
+
+	resp, err = g.DoCommand(ctx, map[string]interface{}{"command": "reset"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldResemble, map[string]interface{}{})
+	test.That(t, c.offset, test.ShouldEqual, 0)
+
+	_, err = g.DoCommand(ctx, map[string]interface{}{"command": "calibrate"})
+	test.That(t, err, test.ShouldBeError, "calibrate needs 'offset', a number")
+	_, err = g.DoCommand(ctx, map[string]interface{}{"command": "calibrate", "offset": "far"})
+	test.That(t, err, test.ShouldBeError, "'offset' of calibrate must be a number, not string")
+	_, err = g.DoCommand(ctx, map[string]interface{}{"command": "calibrate", "offset": 1., "note": 3.})
+	test.That(t, err, test.ShouldBeError, "'note' of calibrate must be a string, not float64")
+	test.That(t, c.offset, test.ShouldEqual, 0)
+
+	_, err = g.DoCommand(ctx, map[string]interface{}{"command": "teach"})
+	test.That(t, err, test.ShouldBeError, "no such command: teach")
+	_, err = g.DoCommand(ctx, map[string]interface{}{})
+	test.That(t, err, test.ShouldBeError, "missing 'command' value")
+}
@@ -4,11 +4,11 @@ package generic
 import (
 	"context"
 
-	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/generic/v1"
 	"go.viam.com/utils/protoutils"
 
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
 // subtypeServer implements the SensorService from sensor.proto.
@@ -26,11 +26,11 @@ func NewServer(s subtype.Service) pb.GenericServiceServer {
 func (s *subtypeServer) getGeneric(name string) (Generic, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no resource with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("resource", name)
 	}
 	generic, ok := resource.(Generic)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a generic component", name)
+		return nil, utils.NewWrongTypeByNameError("a generic component", name)
 	}
 	return generic, nil
 }
@@ -82,6 +82,12 @@ type LocalGripper interface {
 	resource.MovingCheckable
 }
 
+// A HoldingChecker is a gripper that can tell whether it is holding an object.
+type HoldingChecker interface {
+	// HoldingObject returns whether the gripper is closed on an object.
+	HoldingObject(ctx context.Context) (bool, error)
+}
+
 // NewUnimplementedInterfaceError is used when there is a failed interface check.
 func NewUnimplementedInterfaceError(actual interface{}) error {
 	return utils.NewUnimplementedInterfaceError((*Gripper)(nil), actual)
@@ -116,6 +122,7 @@ func WrapWithReconfigurable(r interface{}, name resource.Name) (resource.Reconfi
 var (
 	_ = Gripper(&reconfigurableGripper{})
 	_ = LocalGripper(&reconfigurableLocalGripper{})
+	_ = ParallelGripper(&reconfigurableGripper{})
 	_ = resource.Reconfigurable(&reconfigurableGripper{})
 	_ = resource.Reconfigurable(&reconfigurableLocalGripper{})
 
@@ -194,6 +201,16 @@ func (g *reconfigurableGripper) Stop(ctx context.Context, extra map[string]inter
 	return g.actual.Stop(ctx, extra)
 }
 
+func (g *reconfigurableGripper) MoveTo(ctx context.Context, widthMM float64, extra map[string]interface{}) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	parallel, ok := g.actual.(ParallelGripper)
+	if !ok {
+		return NewUnimplementedParallelInterfaceError(g.actual)
+	}
+	return parallel.MoveTo(ctx, widthMM, extra)
+}
+
 // Reconfigure reconfigures the resource.
 func (g *reconfigurableGripper) Reconfigure(ctx context.Context, newGripper resource.Reconfigurable) error {
 	g.mu.Lock()
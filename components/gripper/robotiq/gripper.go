@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,11 +24,18 @@ import (
 
 const (
 	modelname = "robotiq"
+
+	// defaultMaxWidthMM is the stroke of the 2F-85.
+	defaultMaxWidthMM = 85.
 )
 
 // AttrConfig is used for converting config attributes.
 type AttrConfig struct {
 	Host string `json:"host"`
+	// MinWidthMM and MaxWidthMM are how far apart the fingers are when closed and open, used by
+	// MoveTo. They default to the 0-85mm of the 2F-85.
+	MinWidthMM float64 `json:"min_width_mm,omitempty"`
+	MaxWidthMM float64 `json:"max_width_mm,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -35,7 +43,14 @@ func (cfg *AttrConfig) Validate(path string) error {
 	if cfg.Host == "" {
 		return utils.NewConfigValidationFieldRequiredError(path, "host")
 	}
-	return nil
+	return cfg.widths().Validate(path)
+}
+
+func (cfg *AttrConfig) widths() gripper.WidthRange {
+	if cfg.MinWidthMM == 0 && cfg.MaxWidthMM == 0 {
+		return gripper.WidthRange{MaxMM: defaultMaxWidthMM}
+	}
+	return gripper.WidthRange{MinMM: cfg.MinWidthMM, MaxMM: cfg.MaxWidthMM}
 }
 
 func init() {
@@ -45,7 +60,7 @@ func init() {
 			if !ok {
 				return nil, rdkutils.NewUnexpectedTypeError(attr, config.ConvertedAttributes)
 			}
-			return newGripper(ctx, attr.Host, logger)
+			return newGripper(ctx, attr.Host, attr.widths(), logger)
 		},
 	})
 
@@ -62,6 +77,7 @@ type robotiqGripper struct {
 
 	openLimit  string
 	closeLimit string
+	widths     gripper.WidthRange
 	logger     golog.Logger
 	opMgr      operation.SingleOperationManager
 
@@ -69,12 +85,12 @@ type robotiqGripper struct {
 }
 
 // newGripper TODO.
-func newGripper(ctx context.Context, host string, logger golog.Logger) (gripper.LocalGripper, error) {
+func newGripper(ctx context.Context, host string, widths gripper.WidthRange, logger golog.Logger) (gripper.LocalGripper, error) {
 	conn, err := net.Dial("tcp", host+":63352")
 	if err != nil {
 		return nil, err
 	}
-	g := &robotiqGripper{conn, "0", "255", logger, operation.SingleOperationManager{}, generic.Unimplemented{}}
+	g := &robotiqGripper{conn: conn, openLimit: "0", closeLimit: "255", widths: widths, logger: logger}
 
 	init := [][]string{
 		{"ACT", "1"},   // robot activate
@@ -233,6 +249,11 @@ func (g *robotiqGripper) Grab(ctx context.Context, extra map[string]interface{})
 	}
 
 	// we didn't close, let's see if we actually got something
+	return g.HoldingObject(ctx)
+}
+
+// HoldingObject returns true iff the gripper stopped closing on an object.
+func (g *robotiqGripper) HoldingObject(ctx context.Context) (bool, error) {
 	val, err := g.Get("OBJ")
 	if err != nil {
 		return false, err
@@ -240,6 +261,50 @@ func (g *robotiqGripper) Grab(ctx context.Context, extra map[string]interface{})
 	return val == "OBJ 2", nil
 }
 
+// MoveTo moves the fingers to widthMM apart, mapping the configured width range onto the
+// positions found by calibration.
+func (g *robotiqGripper) MoveTo(ctx context.Context, widthMM float64, extra map[string]interface{}) error {
+	ctx, done := g.opMgr.New(ctx)
+	defer done()
+
+	openPos, err := strconv.Atoi(g.openLimit)
+	if err != nil {
+		return err
+	}
+	closedPos, err := strconv.Atoi(g.closeLimit)
+	if err != nil {
+		return err
+	}
+	pos, err := g.widths.FingerPosition(widthMM, closedPos, openPos)
+	if err != nil {
+		return err
+	}
+	_, err = g.SetPos(ctx, strconv.Itoa(pos))
+	return err
+}
+
+// DoCommand supports "move_to", which runs MoveTo with the given "width_mm", for clients that
+// cannot call MoveTo directly.
+func (g *robotiqGripper) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["command"]
+	if !ok {
+		return nil, errors.New("missing 'command' value")
+	}
+	switch name {
+	case "move_to":
+		widthMM, ok := cmd["width_mm"].(float64)
+		if !ok {
+			return nil, errors.New("move_to needs a 'width_mm' value")
+		}
+		if err := g.MoveTo(ctx, widthMM, nil); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{}, nil
+	default:
+		return nil, fmt.Errorf("no such command: %s", name)
+	}
+}
+
 // Calibrate TODO.
 func (g *robotiqGripper) Calibrate(ctx context.Context) error {
 	err := g.Open(ctx, map[string]interface{}{})
@@ -0,0 +1,86 @@
+package robotiq
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/gripper"
+)
+
+// serve answers the gripper like a robotiq would, recording every position it is set to.
+func serve(conn net.Conn, positions chan<- string) {
+	defer close(positions)
+	pos := "0"
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		var reply string
+		switch {
+		case len(fields) == 3 && fields[0] == "SET":
+			if fields[1] == "POS" {
+				pos = fields[2]
+				positions <- pos
+			}
+			reply = "ack"
+		case len(fields) == 2 && fields[0] == "GET" && fields[1] == "POS":
+			reply = "POS " + pos
+		}
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func TestMoveTo(t *testing.T) {
+	cfg := &AttrConfig{Host: "localhost"}
+	test.That(t, cfg.Validate("path"), test.ShouldBeNil)
+	test.That(t, cfg.widths(), test.ShouldResemble, gripper.WidthRange{MaxMM: 85})
+
+	conn, serverConn := net.Pipe()
+	positions := make(chan string, 10)
+	go serve(serverConn, positions)
+	defer func() {
+		test.That(t, conn.Close(), test.ShouldBeNil)
+		// wait for serve to return
+		for range positions {
+		}
+	}()
+
+	// calibrated fully open at 3 and closed at 228
+	var g gripper.ParallelGripper = &robotiqGripper{
+		conn:       conn,
+		openLimit:  "3",
+		closeLimit: "228",
+		widths:     cfg.widths(),
+		logger:     golog.NewTestLogger(t),
+	}
+	for _, tc := range []struct {
+		widthMM float64
+		pos     string
+	}{
+		{85, "3"},
+		{0, "228"},
+		{42.5, "115"},
+		{10, "202"},
+	} {
+		test.That(t, g.MoveTo(context.Background(), tc.widthMM, nil), test.ShouldBeNil)
+		test.That(t, <-positions, test.ShouldEqual, tc.pos)
+	}
+
+	err := g.MoveTo(context.Background(), 90, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "outside of 0.0-85.0mm")
+
+	_, err = g.DoCommand(context.Background(), map[string]interface{}{"command": "move_to", "width_mm": 85.})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, <-positions, test.ShouldEqual, "3")
+
+	cfg.MinWidthMM, cfg.MaxWidthMM = 50, 20
+	test.That(t, cfg.Validate("path"), test.ShouldNotBeNil)
+}
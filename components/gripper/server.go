@@ -4,11 +4,11 @@ package gripper
 import (
 	"context"
 
-	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/gripper/v1"
 
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
 // subtypeServer implements the GripperService from gripper.proto.
@@ -26,11 +26,11 @@ func NewServer(s subtype.Service) pb.GripperServiceServer {
 func (s *subtypeServer) getGripper(name string) (Gripper, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no gripper with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("gripper", name)
 	}
 	gripper, ok := resource.(Gripper)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a gripper", name)
+		return nil, utils.NewWrongTypeByNameError("a gripper", name)
 	}
 	return gripper, nil
 }
@@ -0,0 +1,55 @@
+package gripper
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/utils"
+)
+
+// A ParallelGripper is a gripper whose fingers move in parallel, so that they can be opened to a
+// given width to fit objects of different sizes. There is no gRPC method for it, remote grippers
+// that support it take a "move_to" command with a "width_mm" through DoCommand instead.
+type ParallelGripper interface {
+	Gripper
+
+	// MoveTo opens or closes the fingers until they are widthMM apart.
+	// This will block until done or a new operation cancels this one
+	MoveTo(ctx context.Context, widthMM float64, extra map[string]interface{}) error
+}
+
+// NewUnimplementedParallelInterfaceError is used when there is a failed interface check.
+func NewUnimplementedParallelInterfaceError(actual interface{}) error {
+	return utils.NewUnimplementedInterfaceError((*ParallelGripper)(nil), actual)
+}
+
+// WidthRange is how far apart the fingers of a parallel gripper are when it is closed and when
+// it is open, in millimeters.
+type WidthRange struct {
+	MinMM float64 `json:"min_width_mm"`
+	MaxMM float64 `json:"max_width_mm"`
+}
+
+// Validate ensures the range is not empty.
+func (r WidthRange) Validate(path string) error {
+	if r.MinMM < 0 {
+		return errors.Errorf("%s: min_width_mm cannot be negative", path)
+	}
+	if r.MaxMM <= r.MinMM {
+		return errors.Errorf("%s: max_width_mm must be greater than min_width_mm", path)
+	}
+	return nil
+}
+
+// FingerPosition maps a width to a finger position, moving linearly from closedPos at the
+// minimum width to openPos at the maximum width. The position is rounded to the nearest whole
+// step. Widths outside of the range are an error.
+func (r WidthRange) FingerPosition(widthMM float64, closedPos, openPos int) (int, error) {
+	if widthMM < r.MinMM || widthMM > r.MaxMM {
+		return 0, errors.Errorf("width %.1fmm is outside of %.1f-%.1fmm", widthMM, r.MinMM, r.MaxMM)
+	}
+	fraction := (widthMM - r.MinMM) / (r.MaxMM - r.MinMM)
+	return closedPos + int(math.Round(fraction*float64(openPos-closedPos))), nil
+}
@@ -9,6 +9,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
 // subtypeServer implements the InputControllerService from proto.
@@ -26,11 +27,11 @@ func NewServer(s subtype.Service) pb.InputControllerServiceServer {
 func (s *subtypeServer) getInputController(name string) (Controller, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no input controller with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("input controller", name)
 	}
 	input, ok := resource.(Controller)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not an input controller", name)
+		return nil, utils.NewWrongTypeByNameError("an input controller", name)
 	}
 	return input, nil
 }
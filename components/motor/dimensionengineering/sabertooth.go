@@ -14,6 +14,7 @@ import (
 	"github.com/edaniels/golog"
 	"github.com/jacobsa/go-serial/serial"
 	"github.com/mitchellh/mapstructure"
+	"go.uber.org/multierr"
 	utils "go.viam.com/utils"
 
 	"go.viam.com/rdk/components/motor"
@@ -45,6 +46,8 @@ type controller struct {
 	address      int // 128-135
 }
 
+var _ = motor.StopNotifier(&Motor{})
+
 // Motor is a single axis/motor/component instance.
 type Motor struct {
 	// A reference to the actual controller that needs to be commanded for the motor to run
@@ -64,6 +67,8 @@ type Motor struct {
 	maxPowerPct float64
 	// the freewheel RPM of the motor
 	maxRPM float64
+	// wakes anyone waiting for the motor to stop
+	stopSignal motor.StopSignal
 
 	// A manager to ensure only a single operation is happening at any given time since commands could overlap on the serial port
 	opMgr operation.SingleOperationManager
@@ -283,6 +288,11 @@ func (m *Motor) IsPowered(ctx context.Context, extra map[string]interface{}) (bo
 	return m.isOn, m.currentPowerPct, nil
 }
 
+// Stopped returns a channel that is closed once the motor is not powered.
+func (m *Motor) Stopped() <-chan struct{} {
+	return m.stopSignal.Stopped()
+}
+
 // Close stops the motor and marks the axis inactive.
 func (m *Motor) Close() {
 	active := m.isAxisActive()
@@ -358,6 +368,7 @@ func (m *Motor) SetPower(ctx context.Context, powerPct float64, extra map[string
 	m.c.mu.Lock()
 	defer m.c.mu.Unlock()
 	m.isOn = true
+	m.stopSignal.SetPowered(true)
 	m.currentPowerPct = powerPct
 
 	rawSpeed := powerPct * maxSpeed
@@ -411,6 +422,10 @@ func (m *Motor) GoFor(ctx context.Context, rpm, revolutions float64, extra map[s
 	if m.opMgr.NewTimedWaitOp(ctx, waitDur) {
 		return m.Stop(ctx, extra)
 	}
+	// the caller gave up on the move, as opposed to another operation taking over the motor
+	if ctx.Err() != nil {
+		return multierr.Combine(ctx.Err(), m.Stop(context.Background(), extra))
+	}
 	return nil
 }
 
@@ -445,6 +460,7 @@ func (m *Motor) Stop(ctx context.Context, extra map[string]interface{}) error {
 	defer done()
 
 	m.isOn = false
+	m.stopSignal.SetPowered(false)
 	m.currentPowerPct = 0.0
 	cmd, err := newCommand(m.c.address, singleForward, m.Channel, 0)
 	if err != nil {
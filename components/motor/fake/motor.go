@@ -9,6 +9,7 @@ import (
 
 	"github.com/edaniels/golog"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	"go.viam.com/rdk/components/board"
 	"go.viam.com/rdk/components/encoder"
@@ -118,7 +119,10 @@ func init() {
 	)
 }
 
-var _ motor.LocalMotor = &Motor{}
+var (
+	_ motor.LocalMotor   = &Motor{}
+	_ motor.StopNotifier = &Motor{}
+)
 
 // A Motor allows setting and reading a set power percentage and
 // direction.
@@ -136,6 +140,8 @@ type Motor struct {
 	opMgr             operation.SingleOperationManager
 	TicksPerRotation  int
 	generic.Echo
+
+	stopSignal motor.StopSignal
 }
 
 // Position returns motor position in rotations.
@@ -191,6 +197,12 @@ func (m *Motor) SetPower(ctx context.Context, powerPct float64, extra map[string
 
 func (m *Motor) setPowerPct(powerPct float64) {
 	m.powerPct = powerPct
+	m.stopSignal.SetPowered(math.Abs(powerPct) >= 0.005)
+}
+
+// Stopped returns a channel that is closed once the motor is not powered.
+func (m *Motor) Stopped() <-chan struct{} {
+	return m.stopSignal.Stopped()
 }
 
 // PowerPct returns the set power percentage.
@@ -277,6 +289,10 @@ func (m *Motor) GoFor(ctx context.Context, rpm, revolutions float64, extra map[s
 			return m.Encoder.SetPosition(ctx, int64(finalPos*float64(m.TicksPerRotation)))
 		}
 	}
+	// the caller gave up on the move, as opposed to another operation taking over the motor
+	if ctx.Err() != nil {
+		return multierr.Combine(ctx.Err(), m.Stop(context.Background(), nil))
+	}
 	return nil
 }
 
@@ -316,6 +332,10 @@ func (m *Motor) GoTo(ctx context.Context, rpm, pos float64, extra map[string]int
 		return m.Encoder.SetPosition(ctx, int64(pos*float64(m.TicksPerRotation)))
 	}
 
+	// the caller gave up on the move, as opposed to another operation taking over the motor
+	if ctx.Err() != nil {
+		return multierr.Combine(ctx.Err(), m.Stop(context.Background(), nil))
+	}
 	return nil
 }
 
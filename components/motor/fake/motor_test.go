@@ -2,7 +2,9 @@ package fake
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"go.viam.com/test"
@@ -60,6 +62,51 @@ func TestGoFor(t *testing.T) {
 	})
 }
 
+func TestGoForCancel(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	m := &Motor{Logger: logger, MaxRPM: 60}
+
+	// one revolution at 1 rpm takes a minute, cancelling must stop it right away
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.GoFor(ctx, 1, 1, nil)
+	}()
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		on, _, err := m.IsPowered(context.Background(), nil)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, on, test.ShouldBeTrue)
+	})
+	cancel()
+	select {
+	case err := <-errCh:
+		test.That(t, errors.Is(err, context.Canceled), test.ShouldBeTrue)
+	case <-time.After(5 * time.Second):
+		t.Fatal("GoFor did not return after its context was cancelled")
+	}
+	on, _, err := m.IsPowered(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, on, test.ShouldBeFalse)
+
+	// another operation taking over leaves the motor to it
+	errCh = make(chan error, 1)
+	go func() {
+		errCh <- m.GoFor(context.Background(), 1, 1, nil)
+	}()
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		on, _, err := m.IsPowered(context.Background(), nil)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, on, test.ShouldBeTrue)
+	})
+	test.That(t, m.GoFor(context.Background(), 60, 0, nil), test.ShouldBeNil)
+	test.That(t, <-errCh, test.ShouldBeNil)
+	on, _, err = m.IsPowered(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, on, test.ShouldBeTrue)
+}
+
 func TestGoTo(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	ctx := context.Background()
@@ -39,6 +39,8 @@ func NewMotor(b board.Board, mc Config, logger golog.Logger) (motor.Motor, error
 		maxPowerPct: mc.MaxPowerPct,
 		maxRPM:      mc.MaxRPM,
 		dirFlip:     mc.DirectionFlip,
+		pwmInverted: mc.PWMInverted,
+		zeroPower:   mc.ZeroPowerBehavior,
 		logger:      logger,
 	}
 
@@ -88,7 +90,10 @@ func NewMotor(b board.Board, mc Config, logger golog.Logger) (motor.Motor, error
 	return m, nil
 }
 
-var _ = motor.LocalMotor(&Motor{})
+var (
+	_ = motor.LocalMotor(&Motor{})
+	_ = motor.StopNotifier(&Motor{})
+)
 
 // A Motor is a GPIO based Motor that resides on a GPIO Board.
 type Motor struct {
@@ -103,6 +108,9 @@ type Motor struct {
 	powerPct                 float64
 	maxRPM                   float64
 	dirFlip                  bool
+	pwmInverted              bool
+	zeroPower                string
+	stopSignal               motor.StopSignal
 
 	opMgr  operation.SingleOperationManager
 	logger golog.Logger
@@ -115,6 +123,15 @@ func (m *Motor) Position(ctx context.Context, extra map[string]interface{}) (flo
 	return 0, nil
 }
 
+// ErrNoPositionSupport is returned for the distance travelled by a motor without an encoder.
+var ErrNoPositionSupport = errors.New("motor has no position support, configure an encoder to track distance")
+
+// PositionMillis always fails with ErrNoPositionSupport, since without an encoder there is
+// nothing to tell how far the motor went. Wrap the motor with an encoder for this.
+func (m *Motor) PositionMillis(ctx context.Context, wheelCircumferenceMm float64) (float64, error) {
+	return 0, ErrNoPositionSupport
+}
+
 // Properties returns the status of whether the motor supports certain optional features.
 func (m *Motor) Properties(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
 	return map[motor.Feature]bool{
@@ -130,28 +147,11 @@ func (m *Motor) setPWM(ctx context.Context, powerPct float64, extra map[string]i
 
 	if math.Abs(powerPct) <= 0.001 {
 		m.powerPct = 0.0
-		if m.EnablePinLow != nil {
-			errs = multierr.Combine(errs, m.EnablePinLow.Set(ctx, true, extra))
-		}
-		if m.EnablePinHigh != nil {
-			errs = multierr.Combine(errs, m.EnablePinHigh.Set(ctx, false, extra))
-		}
-
-		if m.A != nil && m.B != nil {
-			errs = multierr.Combine(
-				errs,
-				m.A.Set(ctx, false, extra),
-				m.B.Set(ctx, false, extra),
-			)
-		}
-
-		if m.PWM != nil {
-			errs = multierr.Combine(errs, m.PWM.Set(ctx, false, extra))
-		}
-		return errs
+		return m.setZeroPower(ctx, extra)
 	}
 
 	m.on = true
+	m.stopSignal.SetPowered(true)
 	if m.EnablePinLow != nil {
 		errs = multierr.Combine(errs, m.EnablePinLow.Set(ctx, false, extra))
 	}
@@ -181,13 +181,44 @@ func (m *Motor) setPWM(ctx context.Context, powerPct float64, extra map[string]i
 
 	powerPct = math.Max(math.Abs(powerPct), m.minPowerPct)
 	m.powerPct = powerPct
+	dutyCyclePct := powerPct
+	if m.pwmInverted && pwmPin == m.PWM {
+		// the driver is powered while the pin is low
+		dutyCyclePct = 1.0 - dutyCyclePct
+	}
 	return multierr.Combine(
 		errs,
 		pwmPin.SetPWMFreq(ctx, m.pwmFreq, extra),
-		pwmPin.SetPWM(ctx, powerPct, extra),
+		pwmPin.SetPWM(ctx, dutyCyclePct, extra),
 	)
 }
 
+// setZeroPower sets the pins for zero power as the zero power behavior says.
+func (m *Motor) setZeroPower(ctx context.Context, extra map[string]interface{}) error {
+	var errs error
+	enabled := m.zeroPower == ZeroPowerCoast || m.zeroPower == ZeroPowerBrake
+	brake := m.zeroPower == ZeroPowerBrake
+	if m.EnablePinLow != nil {
+		errs = multierr.Combine(errs, m.EnablePinLow.Set(ctx, !enabled, extra))
+	}
+	if m.EnablePinHigh != nil {
+		errs = multierr.Combine(errs, m.EnablePinHigh.Set(ctx, enabled, extra))
+	}
+
+	if m.A != nil && m.B != nil {
+		errs = multierr.Combine(
+			errs,
+			m.A.Set(ctx, brake, extra),
+			m.B.Set(ctx, brake, extra),
+		)
+	}
+
+	if m.PWM != nil {
+		errs = multierr.Combine(errs, m.PWM.Set(ctx, brake != m.pwmInverted, extra))
+	}
+	return errs
+}
+
 // SetPower instructs the motor to operate at an rpm, where the sign of the rpm
 // indicates direction.
 func (m *Motor) SetPower(ctx context.Context, powerPct float64, extra map[string]interface{}) error {
@@ -273,6 +304,10 @@ func (m *Motor) GoFor(ctx context.Context, rpm, revolutions float64, extra map[s
 	if m.opMgr.NewTimedWaitOp(ctx, waitDur) {
 		return m.Stop(ctx, extra)
 	}
+	// the caller gave up on the move, as opposed to another operation taking over the motor
+	if ctx.Err() != nil {
+		return multierr.Combine(ctx.Err(), m.Stop(context.Background(), extra))
+	}
 	return nil
 }
 
@@ -285,9 +320,15 @@ func (m *Motor) IsPowered(ctx context.Context, extra map[string]interface{}) (bo
 func (m *Motor) Stop(ctx context.Context, extra map[string]interface{}) error {
 	m.opMgr.CancelRunning(ctx)
 	m.on = false
+	m.stopSignal.SetPowered(false)
 	return m.setPWM(ctx, 0, extra)
 }
 
+// Stopped returns a channel that is closed once the motor is not powered.
+func (m *Motor) Stopped() <-chan struct{} {
+	return m.stopSignal.Stopped()
+}
+
 // IsMoving returns if the motor is currently on or off.
 func (m *Motor) IsMoving(ctx context.Context) (bool, error) {
 	return m.on, nil
@@ -110,6 +110,23 @@ func TestMotorABPWM(t *testing.T) {
 		test.That(t, mustGetGPIOPinByName(b, "3").PWM(context.Background()), test.ShouldEqual, .45)
 	})
 
+	t.Run("motor (A/B/PWM) Stopped testing", func(t *testing.T) {
+		test.That(t, m.SetPower(ctx, 0.45, nil), test.ShouldBeNil)
+		stopped := m.(motor.StopNotifier).Stopped()
+		select {
+		case <-stopped:
+			t.Fatal("motor reported stopped while powered")
+		default:
+		}
+		test.That(t, m.Stop(ctx, nil), test.ShouldBeNil)
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("motor did not report being stopped")
+		}
+		test.That(t, motor.WaitUntilStopped(ctx, m), test.ShouldBeNil)
+	})
+
 	t.Run("motor (A/B/PWM) Position testing", func(t *testing.T) {
 		pos, err := m.Position(ctx, nil)
 		test.That(t, err, test.ShouldBeNil)
@@ -206,6 +223,120 @@ func TestMotorDirPWM(t *testing.T) {
 	})
 }
 
+// Test that a driver wired with the opposite polarity gets the opposite pin states.
+func TestMotorInvertedPolarity(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	_, err := (&Config{BoardName: "b", MaxRPM: maxRPM, Pins: PinConfig{A: "1", B: "2"}, PWMInverted: true}).Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "pwm_inverted needs a pwm pin")
+
+	normalBoard := &fakeboard.Board{GPIOPins: map[string]*fakeboard.GPIOPin{}}
+	normal, err := NewMotor(normalBoard, Config{
+		Pins:   PinConfig{Direction: "1", EnablePinHigh: "2", PWM: "3"},
+		MaxRPM: maxRPM,
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	invertedBoard := &fakeboard.Board{GPIOPins: map[string]*fakeboard.GPIOPin{}}
+	inverted, err := NewMotor(invertedBoard, Config{
+		Pins:          PinConfig{Direction: "1", EnablePinLow: "2", PWM: "3"},
+		MaxRPM:        maxRPM,
+		DirectionFlip: true,
+		PWMInverted:   true,
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, cmd := range []struct {
+		name      string
+		run       func(m motor.Motor) error
+		dutyCycle float64
+	}{
+		{"forward", func(m motor.Motor) error { return m.SetPower(ctx, 0.3, nil) }, 0.3},
+		{"backward", func(m motor.Motor) error { return m.SetPower(ctx, -0.3, nil) }, 0.3},
+		{"go for", func(m motor.Motor) error { return m.GoFor(ctx, 80, 0, nil) }, 0.8},
+	} {
+		t.Run(cmd.name, func(t *testing.T) {
+			test.That(t, cmd.run(normal), test.ShouldBeNil)
+			test.That(t, cmd.run(inverted), test.ShouldBeNil)
+			for _, pin := range []string{"1", "2"} {
+				test.That(t, mustGetGPIOPinByName(invertedBoard, pin).Get(ctx), test.ShouldNotEqual,
+					mustGetGPIOPinByName(normalBoard, pin).Get(ctx))
+			}
+			test.That(t, mustGetGPIOPinByName(normalBoard, "3").PWM(ctx), test.ShouldAlmostEqual, cmd.dutyCycle)
+			test.That(t, mustGetGPIOPinByName(invertedBoard, "3").PWM(ctx), test.ShouldAlmostEqual, 1-cmd.dutyCycle)
+
+			// both report the power they were asked for
+			_, normalPower, err := normal.IsPowered(ctx, nil)
+			test.That(t, err, test.ShouldBeNil)
+			_, invertedPower, err := inverted.IsPowered(ctx, nil)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, invertedPower, test.ShouldEqual, normalPower)
+		})
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		test.That(t, normal.Stop(ctx, nil), test.ShouldBeNil)
+		test.That(t, inverted.Stop(ctx, nil), test.ShouldBeNil)
+		for _, pin := range []string{"2", "3"} {
+			test.That(t, mustGetGPIOPinByName(invertedBoard, pin).Get(ctx), test.ShouldNotEqual,
+				mustGetGPIOPinByName(normalBoard, pin).Get(ctx))
+		}
+	})
+}
+
+func TestMotorZeroPowerBehavior(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	for _, tc := range []struct {
+		behavior   string
+		pins       PinConfig
+		expErr     string
+		expEnabled bool
+		expBrake   bool
+	}{
+		{behavior: "", pins: PinConfig{A: "1", B: "2", PWM: "3", EnablePinHigh: "4"}},
+		{behavior: ZeroPowerCoast, pins: PinConfig{A: "1", B: "2", PWM: "3", EnablePinHigh: "4"}, expEnabled: true},
+		{behavior: ZeroPowerBrake, pins: PinConfig{A: "1", B: "2", PWM: "3", EnablePinHigh: "4"}, expEnabled: true, expBrake: true},
+		{behavior: ZeroPowerFloat, pins: PinConfig{A: "1", B: "2", PWM: "3", EnablePinHigh: "4"}},
+		{behavior: ZeroPowerBrake, pins: PinConfig{Direction: "1", PWM: "3"}, expErr: "brake needs a and b pins"},
+		{behavior: ZeroPowerFloat, pins: PinConfig{A: "1", B: "2", PWM: "3"}, expErr: "float needs an en_high or en_low pin"},
+		{behavior: "hold", pins: PinConfig{A: "1", B: "2", PWM: "3"}, expErr: "zero_power_behavior must be coast, brake or float"},
+	} {
+		t.Run(tc.behavior, func(t *testing.T) {
+			cfg := Config{BoardName: "b", Pins: tc.pins, MaxRPM: maxRPM, ZeroPowerBehavior: tc.behavior}
+			_, err := cfg.Validate("path")
+			if tc.expErr != "" {
+				test.That(t, err, test.ShouldNotBeNil)
+				test.That(t, err.Error(), test.ShouldContainSubstring, tc.expErr)
+				return
+			}
+			test.That(t, err, test.ShouldBeNil)
+
+			b := &fakeboard.Board{GPIOPins: map[string]*fakeboard.GPIOPin{}}
+			m, err := NewMotor(b, cfg, logger)
+			test.That(t, err, test.ShouldBeNil)
+			checkZeroPower := func() {
+				t.Helper()
+				test.That(t, mustGetGPIOPinByName(b, "4").Get(ctx), test.ShouldEqual, tc.expEnabled)
+				test.That(t, mustGetGPIOPinByName(b, "1").Get(ctx), test.ShouldEqual, tc.expBrake)
+				test.That(t, mustGetGPIOPinByName(b, "2").Get(ctx), test.ShouldEqual, tc.expBrake)
+				test.That(t, mustGetGPIOPinByName(b, "3").Get(ctx), test.ShouldEqual, tc.expBrake)
+			}
+
+			test.That(t, m.SetPower(ctx, 0.5, nil), test.ShouldBeNil)
+			test.That(t, mustGetGPIOPinByName(b, "4").Get(ctx), test.ShouldBeTrue)
+			test.That(t, m.SetPower(ctx, 0, nil), test.ShouldBeNil)
+			checkZeroPower()
+
+			// at the end of a move
+			test.That(t, m.GoFor(ctx, maxRPM, 0.01, nil), test.ShouldBeNil)
+			checkZeroPower()
+		})
+	}
+}
+
 // Test the A/B only style IO.
 func TestMotorAB(t *testing.T) {
 	ctx := context.Background()
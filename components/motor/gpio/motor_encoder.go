@@ -161,6 +161,8 @@ func newEncodedMotor(
 	return em, nil
 }
 
+var _ = motor.StopNotifier(&EncodedMotor{})
+
 // EncodedMotor is a motor that utilizes an encoder to track its position.
 type EncodedMotor struct {
 	activeBackgroundWorkers *sync.WaitGroup
@@ -199,6 +201,8 @@ type EncodedMotorState struct {
 	currentRPM   float64
 	lastPowerPct float64
 	setPoint     int64
+	// direction is the sign of the last power that was not zero, 0 before the first
+	direction int64
 }
 
 // Position returns the position of the motor.
@@ -211,8 +215,22 @@ func (m *EncodedMotor) Position(ctx context.Context, extra map[string]interface{
 	return float64(ticks) / float64(m.cfg.TicksPerRotation), nil
 }
 
+// PositionMillis returns how far, in mm, a wheel of the given circumference driven by the motor
+// has travelled from the zero position, as counted by the encoder. It is negative behind it.
+func (m *EncodedMotor) PositionMillis(ctx context.Context, wheelCircumferenceMm float64) (float64, error) {
+	if wheelCircumferenceMm <= 0 {
+		return 0, errors.Errorf("wheel circumference must be positive, not %v", wheelCircumferenceMm)
+	}
+	revolutions, err := m.Position(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return revolutions * wheelCircumferenceMm, nil
+}
+
 // DirectionMoving returns the direction we are currently mpving in, with 1 representing
-// forward and  -1 representing backwards.
+// forward and  -1 representing backwards. Once the power is cut it is the direction the motor
+// was last going in, so that ticks of a motor coasting to a stop count the right way.
 func (m *EncodedMotor) DirectionMoving() int64 {
 	m.stateMu.RLock()
 	defer m.stateMu.RUnlock()
@@ -227,7 +245,10 @@ func sign(x float64) int64 { // A quick helper function
 }
 
 func (m *EncodedMotor) directionMovingInLock() int64 {
-	return sign(m.state.lastPowerPct)
+	if m.state.lastPowerPct != 0 {
+		return sign(m.state.lastPowerPct)
+	}
+	return m.state.direction
 }
 
 // Properties returns the status of whether the motor supports certain optional features.
@@ -278,6 +299,9 @@ func (m *EncodedMotor) setPower(ctx context.Context, powerPct float64, internal
 		m.state.regulated = false // user wants direct control, so we stop trying to control the world
 	}
 	m.state.lastPowerPct = m.fixPowerPct(powerPct)
+	if m.state.lastPowerPct != 0 {
+		m.state.direction = sign(m.state.lastPowerPct)
+	}
 	return m.real.SetPower(ctx, m.state.lastPowerPct, nil)
 }
 
@@ -578,6 +602,22 @@ func (m *EncodedMotor) IsPowered(ctx context.Context, extra map[string]interface
 	return m.real.IsPowered(ctx, extra)
 }
 
+// Stopped returns a channel that is closed once the motor is not powered. It is forwarded from the
+// underlying motor, which is polled if it cannot notify.
+func (m *EncodedMotor) Stopped() <-chan struct{} {
+	if notifier, ok := m.real.(motor.StopNotifier); ok {
+		return notifier.Stopped()
+	}
+	stopped := make(chan struct{})
+	m.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		if err := motor.WaitUntilStopped(m.cancelCtx, m.real); err == nil {
+			close(stopped)
+		}
+	}, m.activeBackgroundWorkers.Done)
+	return stopped
+}
+
 // Close cleanly shuts down the motor.
 func (m *EncodedMotor) Close() {
 	if m.loop != nil {
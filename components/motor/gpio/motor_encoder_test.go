@@ -261,6 +261,22 @@ func TestMotorEncoder1(t *testing.T) {
 		test.That(t, ctx.Err(), test.ShouldNotBeNil)
 		test.That(t, _motor.state.desiredRPM, test.ShouldEqual, 0)
 	})
+
+	t.Run("encoded motor testing Stopped", func(t *testing.T) {
+		test.That(t, _motor.SetPower(context.Background(), .5, nil), test.ShouldBeNil)
+		stopped := _motor.Stopped()
+		select {
+		case <-stopped:
+			t.Fatal("motor reported stopped while powered")
+		default:
+		}
+		test.That(t, _motor.Stop(context.Background(), nil), test.ShouldBeNil)
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("motor did not report being stopped")
+		}
+	})
 }
 
 func TestMotorEncoderIncremental(t *testing.T) {
@@ -624,6 +640,60 @@ func TestWrapMotorWithEncoder(t *testing.T) {
 	})
 }
 
+func TestPositionMillis(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+	interrupt := &board.BasicDigitalInterrupt{}
+	e := &encoder.SingleEncoder{I: interrupt, CancelCtx: ctx}
+	e.Start(ctx)
+
+	m, err := WrapMotorWithEncoder(
+		ctx,
+		e,
+		config.Component{Name: "motor1"},
+		Config{TicksPerRotation: 100, MaxRPM: 60},
+		&fakemotor.Motor{MaxRPM: 60, Logger: logger},
+		logger,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, utils.TryClose(ctx, m), test.ShouldBeNil)
+	}()
+	encoded := m.(*EncodedMotor)
+
+	tickAndCheck := func(ticks int, mm float64) {
+		t.Helper()
+		for i := 0; i < ticks; i++ {
+			test.That(t, interrupt.Tick(ctx, true, nowNanosTest()), test.ShouldBeNil)
+		}
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			pos, err := encoded.PositionMillis(ctx, 200)
+			test.That(tb, err, test.ShouldBeNil)
+			test.That(tb, pos, test.ShouldAlmostEqual, mm)
+		})
+	}
+
+	test.That(t, m.SetPower(ctx, 0.5, nil), test.ShouldBeNil)
+	tickAndCheck(10, 20)
+	test.That(t, m.SetPower(ctx, -0.5, nil), test.ShouldBeNil)
+	tickAndCheck(15, -10)
+	// coasting to a stop after going backwards keeps counting down
+	test.That(t, m.Stop(ctx, nil), test.ShouldBeNil)
+	tickAndCheck(5, -20)
+
+	_, err = encoded.PositionMillis(ctx, 0)
+	test.That(t, err, test.ShouldBeError, "wheel circumference must be positive, not 0")
+
+	b := &fakeboard.Board{GPIOPins: map[string]*fakeboard.GPIOPin{}}
+	basic, err := NewMotor(b, Config{Pins: PinConfig{A: "1", B: "2"}, PWMFreq: 4000}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	unencoded, err := WrapMotorWithEncoder(ctx, nil, config.Component{Name: "motor2"}, Config{}, basic, logger)
+	test.That(t, err, test.ShouldBeNil)
+	_, err = unencoded.(*Motor).PositionMillis(ctx, 200)
+	test.That(t, err, test.ShouldBeError, ErrNoPositionSupport)
+}
+
 func TestDirFlipMotor(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	cfg := Config{TicksPerRotation: 100, MaxRPM: 100, DirectionFlip: true}
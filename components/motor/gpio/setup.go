@@ -18,6 +18,17 @@ import (
 
 const modelName = "gpio"
 
+// The behaviors of a motor at zero power. By default a motor floats if it has an enable pin, and
+// coasts otherwise.
+const (
+	// ZeroPowerCoast keeps the driver enabled with both sides of the bridge low, so the motor spins down freely.
+	ZeroPowerCoast = "coast"
+	// ZeroPowerBrake keeps the driver enabled with both sides of the bridge high, shorting the motor to hold it.
+	ZeroPowerBrake = "brake"
+	// ZeroPowerFloat disables the driver through its enable pins, leaving the motor disconnected.
+	ZeroPowerFloat = "float"
+)
+
 // PinConfig defines the mapping of where motor are wired.
 type PinConfig struct {
 	A             string `json:"a"`
@@ -30,17 +41,19 @@ type PinConfig struct {
 
 // Config describes the configuration of a motor.
 type Config struct {
-	Pins             PinConfig      `json:"pins"`
-	BoardName        string         `json:"board"`
-	MinPowerPct      float64        `json:"min_power_pct,omitempty"` // min power percentage to allow for this motor default is 0.0
-	MaxPowerPct      float64        `json:"max_power_pct,omitempty"` // max power percentage to allow for this motor (0.06 - 1.0)
-	PWMFreq          uint           `json:"pwm_freq,omitempty"`
-	DirectionFlip    bool           `json:"dir_flip,omitempty"`       // Flip the direction of the signal sent if there is a Dir pin
-	ControlLoop      control.Config `json:"control_config,omitempty"` // Optional control loop
-	Encoder          string         `json:"encoder,omitempty"`        // name of encoder
-	RampRate         float64        `json:"ramp_rate,omitempty"`      // how fast to ramp power to motor when using rpm control
-	MaxRPM           float64        `json:"max_rpm,omitempty"`
-	TicksPerRotation int            `json:"ticks_per_rotation,omitempty"`
+	Pins              PinConfig      `json:"pins"`
+	BoardName         string         `json:"board"`
+	MinPowerPct       float64        `json:"min_power_pct,omitempty"` // min power percentage to allow for this motor default is 0.0
+	MaxPowerPct       float64        `json:"max_power_pct,omitempty"` // max power percentage to allow for this motor (0.06 - 1.0)
+	PWMFreq           uint           `json:"pwm_freq,omitempty"`
+	DirectionFlip     bool           `json:"dir_flip,omitempty"`            // Flip the direction of the signal sent if there is a Dir pin
+	PWMInverted       bool           `json:"pwm_inverted,omitempty"`        // Drive the pwm pin low for power, for active low drivers
+	ZeroPowerBehavior string         `json:"zero_power_behavior,omitempty"` // coast, brake or float
+	ControlLoop       control.Config `json:"control_config,omitempty"`      // Optional control loop
+	Encoder           string         `json:"encoder,omitempty"`             // name of encoder
+	RampRate          float64        `json:"ramp_rate,omitempty"`           // how fast to ramp power to motor when using rpm control
+	MaxRPM            float64        `json:"max_rpm,omitempty"`
+	TicksPerRotation  int            `json:"ticks_per_rotation,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -52,6 +65,24 @@ func (config *Config) Validate(path string) ([]string, error) {
 	}
 	deps = append(deps, config.BoardName)
 
+	if config.PWMInverted && config.Pins.PWM == "" {
+		return nil, vutils.NewConfigValidationError(path, errors.New("pwm_inverted needs a pwm pin"))
+	}
+	switch config.ZeroPowerBehavior {
+	case "", ZeroPowerCoast:
+	case ZeroPowerBrake:
+		if config.Pins.A == "" || config.Pins.B == "" {
+			return nil, vutils.NewConfigValidationError(path, errors.New("zero_power_behavior brake needs a and b pins"))
+		}
+	case ZeroPowerFloat:
+		if config.Pins.EnablePinHigh == "" && config.Pins.EnablePinLow == "" {
+			return nil, vutils.NewConfigValidationError(path, errors.New("zero_power_behavior float needs an en_high or en_low pin"))
+		}
+	default:
+		return nil, vutils.NewConfigValidationError(path,
+			errors.Errorf("zero_power_behavior must be %s, %s or %s", ZeroPowerCoast, ZeroPowerBrake, ZeroPowerFloat))
+	}
+
 	// If an encoder is present the max_rpm field is optional, in the absence of an encoder the field is required
 	if config.Encoder != "" {
 		deps = append(deps, config.Encoder)
@@ -3,6 +3,7 @@ package motor
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	pb "go.viam.com/api/component/motor/v1"
@@ -111,6 +112,84 @@ type LocalMotor interface {
 	resource.MovingCheckable
 }
 
+// A StopNotifier is a motor that can wake up whoever waits for it to stop instead of being polled.
+type StopNotifier interface {
+	// Stopped returns a channel that is closed once the motor is not powered.
+	Stopped() <-chan struct{}
+}
+
+// stopPollInterval is how often WaitUntilStopped checks motors that are not StopNotifiers.
+const stopPollInterval = 10 * time.Millisecond
+
+// WaitUntilStopped blocks until the motor is not powered or ctx is done. A motor that is a StopNotifier
+// wakes the waiter as soon as it stops, any other motor is polled.
+func WaitUntilStopped(ctx context.Context, m Motor) error {
+	if notifier, ok := utils.UnwrapProxy(m).(StopNotifier); ok {
+		select {
+		case <-notifier.Stopped():
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for {
+		on, _, err := m.IsPowered(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if !on {
+			return nil
+		}
+		if !viamutils.SelectContextOrWait(ctx, stopPollInterval) {
+			return ctx.Err()
+		}
+	}
+}
+
+// A StopSignal lets a motor driver implement StopNotifier. Drivers call SetPowered whenever
+// they turn on or off. The zero value is a stopped motor.
+type StopSignal struct {
+	mu      sync.Mutex
+	stopped chan struct{}
+}
+
+// SetPowered records whether the motor is powered, waking any waiters when it turns off.
+func (s *StopSignal) SetPowered(powered bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case powered && s.isStopped():
+		s.stopped = make(chan struct{})
+	case !powered && !s.isStopped():
+		close(s.stopped)
+	}
+}
+
+// isStopped must be called with the lock held.
+func (s *StopSignal) isStopped() bool {
+	if s.stopped == nil {
+		return true
+	}
+	select {
+	case <-s.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stopped returns a channel that is closed once the motor is not powered.
+func (s *StopSignal) Stopped() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isStopped() {
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	return s.stopped
+}
+
 // Named is a helper for getting the named Motor's typed resource name.
 func Named(name string) resource.Name {
 	return resource.NameFromSubtype(Subtype, name)
@@ -2,8 +2,11 @@ package motor_test
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/edaniels/golog"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/motor/v1"
@@ -13,6 +16,7 @@ import (
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/motor/fake"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/testutils/inject"
@@ -554,3 +558,62 @@ func (m *mockLocal) IsMoving(ctx context.Context) (bool, error) {
 	m.isMovingCount++
 	return isMoving, nil
 }
+
+func TestWaitUntilStopped(t *testing.T) {
+	ctx := context.Background()
+
+	waitInBackground := func(ctx context.Context, m motor.Motor) <-chan error {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- motor.WaitUntilStopped(ctx, m)
+		}()
+		return errCh
+	}
+
+	t.Run("notified by the motor", func(t *testing.T) {
+		fakeMotor := &fake.Motor{MaxRPM: 60, Logger: golog.NewTestLogger(t)}
+		reconfMotor, err := motor.WrapWithReconfigurable(fakeMotor, motor.Named("m"))
+		test.That(t, err, test.ShouldBeNil)
+		m := reconfMotor.(motor.Motor)
+
+		// a motor that is off does not block
+		test.That(t, motor.WaitUntilStopped(ctx, m), test.ShouldBeNil)
+
+		test.That(t, m.SetPower(ctx, 0.5, nil), test.ShouldBeNil)
+		errCh := waitInBackground(ctx, m)
+		select {
+		case <-errCh:
+			t.Fatal("returned while the motor was still on")
+		case <-time.After(20 * time.Millisecond):
+		}
+		test.That(t, m.Stop(ctx, nil), test.ShouldBeNil)
+		select {
+		case err := <-errCh:
+			test.That(t, err, test.ShouldBeNil)
+		case <-time.After(time.Second):
+			t.Fatal("not notified that the motor stopped")
+		}
+
+		// a timed move stops on its own
+		errCh = waitInBackground(ctx, m)
+		test.That(t, m.GoFor(ctx, 60, 0.05, nil), test.ShouldBeNil)
+		test.That(t, <-errCh, test.ShouldBeNil)
+	})
+
+	t.Run("polls other motors", func(t *testing.T) {
+		var on atomic.Bool
+		on.Store(true)
+		injectMotor := &inject.Motor{}
+		injectMotor.IsPoweredFunc = func(ctx context.Context, extra map[string]interface{}) (bool, float64, error) {
+			return on.Load(), 0, nil
+		}
+		errCh := waitInBackground(ctx, injectMotor)
+		on.Store(false)
+		test.That(t, <-errCh, test.ShouldBeNil)
+
+		on.Store(true)
+		cancelCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		test.That(t, motor.WaitUntilStopped(cancelCtx, injectMotor), test.ShouldBeError, context.DeadlineExceeded)
+	})
+}
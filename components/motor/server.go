@@ -4,13 +4,17 @@ package motor
 import (
 	"context"
 
-	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/motor/v1"
 
+	"go.viam.com/rdk/commandlog"
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
+var commandsCounter = metrics.NewCounter("rdk_motor_commands_total", "Commands received by motors.", "motor", "method")
+
 type subtypeServer struct {
 	pb.UnimplementedMotorServiceServer
 	service subtype.Service
@@ -25,11 +29,11 @@ func NewServer(service subtype.Service) pb.MotorServiceServer {
 func (server *subtypeServer) getMotor(name string) (Motor, error) {
 	resource := server.service.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no motor with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("motor", name)
 	}
 	motor, ok := resource.(Motor)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a motor", name)
+		return nil, utils.NewWrongTypeByNameError("a motor", name)
 	}
 	return motor, nil
 }
@@ -42,8 +46,10 @@ func (server *subtypeServer) SetPower(
 	motorName := req.GetName()
 	motor, err := server.getMotor(motorName)
 	if err != nil {
-		return nil, errors.Errorf("no motor (%s) found", motorName)
+		return nil, err
 	}
+	commandsCounter.Inc(motorName, "SetPower")
+	commandlog.Record(Named(motorName).String(), "SetPower", map[string]interface{}{"power_pct": req.GetPowerPct()})
 	return &pb.SetPowerResponse{}, motor.SetPower(ctx, req.GetPowerPct(), req.Extra.AsMap())
 }
 
@@ -57,8 +63,9 @@ func (server *subtypeServer) GoFor(
 	motorName := req.GetName()
 	motor, err := server.getMotor(motorName)
 	if err != nil {
-		return nil, errors.Errorf("no motor (%s) found", motorName)
+		return nil, err
 	}
+	commandsCounter.Inc(motorName, "GoFor")
 
 	// erh: this isn't right semantically.
 	// GoFor with 0 rotations means something important.
@@ -68,6 +75,7 @@ func (server *subtypeServer) GoFor(
 		rVal = revolutions
 	}
 
+	commandlog.Record(Named(motorName).String(), "GoFor", map[string]interface{}{"rpm": req.GetRpm(), "revolutions": rVal})
 	return &pb.GoForResponse{}, motor.GoFor(ctx, req.GetRpm(), rVal, req.Extra.AsMap())
 }
 
@@ -82,7 +90,7 @@ func (server *subtypeServer) GetPosition(
 	motorName := req.GetName()
 	motor, err := server.getMotor(motorName)
 	if err != nil {
-		return nil, errors.Errorf("no motor (%s) found", motorName)
+		return nil, err
 	}
 
 	pos, err := motor.Position(ctx, req.Extra.AsMap())
@@ -100,7 +108,7 @@ func (server *subtypeServer) GetProperties(
 	motorName := req.GetName()
 	motor, err := server.getMotor(motorName)
 	if err != nil {
-		return nil, errors.Errorf("no motor (%s) found", motorName)
+		return nil, err
 	}
 	features, err := motor.Properties(ctx, req.Extra.AsMap())
 	if err != nil {
@@ -117,9 +125,11 @@ func (server *subtypeServer) Stop(
 	motorName := req.GetName()
 	motor, err := server.getMotor(motorName)
 	if err != nil {
-		return nil, errors.Errorf("no motor (%s) found", motorName)
+		return nil, err
 	}
+	commandsCounter.Inc(motorName, "Stop")
 
+	commandlog.Record(Named(motorName).String(), "Stop", nil)
 	return &pb.StopResponse{}, motor.Stop(ctx, req.Extra.AsMap())
 }
 
@@ -131,7 +141,7 @@ func (server *subtypeServer) IsPowered(
 	motorName := req.GetName()
 	motor, err := server.getMotor(motorName)
 	if err != nil {
-		return nil, errors.Errorf("no motor (%s) found", motorName)
+		return nil, err
 	}
 
 	isOn, powerPct, err := motor.IsPowered(ctx, req.Extra.AsMap())
@@ -150,9 +160,14 @@ func (server *subtypeServer) GoTo(
 	motorName := req.GetName()
 	motor, err := server.getMotor(motorName)
 	if err != nil {
-		return nil, errors.Errorf("no motor (%s) found", motorName)
+		return nil, err
 	}
+	commandsCounter.Inc(motorName, "GoTo")
 
+	commandlog.Record(Named(motorName).String(), "GoTo", map[string]interface{}{
+		"rpm":                  req.GetRpm(),
+		"position_revolutions": req.GetPositionRevolutions(),
+	})
 	return &pb.GoToResponse{}, motor.GoTo(ctx, req.GetRpm(), req.GetPositionRevolutions(), req.Extra.AsMap())
 }
 
@@ -165,8 +180,10 @@ func (server *subtypeServer) ResetZeroPosition(
 	motorName := req.GetName()
 	motor, err := server.getMotor(motorName)
 	if err != nil {
-		return nil, errors.Errorf("no motor (%s) found", motorName)
+		return nil, err
 	}
+	commandsCounter.Inc(motorName, "ResetZeroPosition")
 
+	commandlog.Record(Named(motorName).String(), "ResetZeroPosition", map[string]interface{}{"offset": req.GetOffset()})
 	return &pb.ResetZeroPositionResponse{}, motor.ResetZeroPosition(ctx, req.GetOffset(), req.Extra.AsMap())
 }
@@ -3,6 +3,7 @@ package motor_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	pb "go.viam.com/api/component/motor/v1"
@@ -10,6 +11,7 @@ import (
 	"go.viam.com/utils/protoutils"
 
 	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/subtype"
 	"go.viam.com/rdk/testutils/inject"
@@ -41,6 +43,16 @@ func TestServerSetPower(t *testing.T) {
 	resp, err := motorServer.SetPower(context.Background(), &req)
 	test.That(t, resp, test.ShouldBeNil)
 	test.That(t, err, test.ShouldNotBeNil)
+	req = pb.SetPowerRequest{Name: missingMotorName}
+	resp, err = motorServer.SetPower(context.Background(), &req)
+	test.That(t, resp, test.ShouldBeNil)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// commands to names that are not motors are not counted, so callers cannot grow the metrics without bound
+	var counted strings.Builder
+	test.That(t, metrics.WriteText(&counted), test.ShouldBeNil)
+	test.That(t, counted.String(), test.ShouldNotContainSubstring, fakeMotorName)
+	test.That(t, counted.String(), test.ShouldNotContainSubstring, missingMotorName)
 
 	failingMotor.SetPowerFunc = func(ctx context.Context, powerPct float64, extra map[string]interface{}) error {
 		return errors.New("set power failed")
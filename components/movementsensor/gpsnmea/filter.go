@@ -0,0 +1,96 @@
+package gpsnmea
+
+import (
+	"time"
+
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+)
+
+const defaultFilterWindowSize = 5
+
+// FilterConfig is used for converting the location filter attributes of a NMEA MovementSensor.
+type FilterConfig struct {
+	// Fixes further from the last accepted one than this speed allows are rejected.
+	MaxSpeedMMPerSec float64 `json:"max_speed_mm_per_sec"`
+	// The number of accepted fixes averaged together.
+	WindowSize int `json:"window_size,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (cfg *FilterConfig) Validate(path string) error {
+	if cfg.MaxSpeedMMPerSec <= 0 {
+		return utils.NewConfigValidationError(path, errors.New("filter max_speed_mm_per_sec must be positive"))
+	}
+	if cfg.WindowSize < 0 {
+		return utils.NewConfigValidationError(path, errors.New("filter window_size cannot be negative"))
+	}
+	return nil
+}
+
+// A locationFilter rejects fixes that imply impossible speeds and averages the rest. Should it
+// reject a whole window of fixes in a row, the fix it last accepted is taken to have been the
+// outlier and it starts over.
+type locationFilter struct {
+	maxSpeed   float64
+	windowSize int
+
+	seen     *geo.Point
+	last     *geo.Point
+	lastTime time.Time
+	window   []*geo.Point
+	rejected int
+}
+
+func newLocationFilter(cfg *FilterConfig) *locationFilter {
+	windowSize := cfg.WindowSize
+	if windowSize == 0 {
+		windowSize = defaultFilterWindowSize
+	}
+	return &locationFilter{maxSpeed: cfg.MaxSpeedMMPerSec, windowSize: windowSize}
+}
+
+// update filters the location of data if it is a fix not seen before, and returns whether it was
+// rejected.
+func (f *locationFilter) update(data *gpsData, now time.Time) bool {
+	if data.location == nil || data.location == f.seen || !data.valid {
+		return false
+	}
+	f.seen = data.location
+	return !f.add(data.location, now)
+}
+
+// add filters a fix made at the given time, and returns whether it was accepted.
+func (f *locationFilter) add(p *geo.Point, now time.Time) bool {
+	if f.last != nil && f.rejected < f.windowSize {
+		distMM := f.last.GreatCircleDistance(p) * 1e6
+		if distMM > f.maxSpeed*now.Sub(f.lastTime).Seconds() {
+			f.rejected++
+			return false
+		}
+	} else if f.last != nil {
+		f.window = nil
+	}
+	f.rejected = 0
+	f.last = p
+	f.lastTime = now
+	f.window = append(f.window, p)
+	if len(f.window) > f.windowSize {
+		f.window = f.window[len(f.window)-f.windowSize:]
+	}
+	return true
+}
+
+// location returns the average of the fixes in the window, or nil if none have been accepted.
+func (f *locationFilter) location() *geo.Point {
+	if len(f.window) == 0 {
+		return nil
+	}
+	var lat, lng float64
+	for _, p := range f.window {
+		lat += p.Lat()
+		lng += p.Lng()
+	}
+	return geo.NewPoint(lat/float64(len(f.window)), lng/float64(len(f.window)))
+}
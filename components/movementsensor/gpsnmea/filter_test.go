@@ -0,0 +1,82 @@
+package gpsnmea
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	geo "github.com/kellydunn/golang-geo"
+	"go.viam.com/test"
+)
+
+func TestValidateFilter(t *testing.T) {
+	cfg := &AttrConfig{
+		ConnectionType:   serialStr,
+		SerialAttrConfig: &SerialAttrConfig{SerialPath: "some-path"},
+		Filter:           &FilterConfig{},
+	}
+	_, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "filter max_speed_mm_per_sec must be positive")
+
+	cfg.Filter = &FilterConfig{MaxSpeedMMPerSec: 2000, WindowSize: -1}
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "filter window_size cannot be negative")
+
+	cfg.Filter.WindowSize = 3
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestFilterOutlier(t *testing.T) {
+	ctx := context.Background()
+	g := &SerialNMEAMovementSensor{
+		logger: golog.NewTestLogger(t),
+		filter: newLocationFilter(&FilterConfig{MaxSpeedMMPerSec: 2000, WindowSize: 3}),
+	}
+
+	// driving north at a meter a second, with one fix a kilometer off
+	start := time.Now()
+	origin := geo.NewPoint(40.7, -74)
+	for i := 0; i < 6; i++ {
+		fix := origin.PointAtDistanceAndBearing(float64(i)/1000, 0)
+		if i == 3 {
+			fix = origin.PointAtDistanceAndBearing(1, 90)
+		}
+		g.data = gpsData{location: fix, alt: alt, valid: true}
+		rejected := g.filter.update(&g.data, start.Add(time.Duration(i)*time.Second))
+		test.That(t, rejected, test.ShouldEqual, i == 3)
+		// the same fix reported by another sentence is not filtered again
+		test.That(t, g.filter.update(&g.data, start.Add(time.Duration(i)*time.Second)), test.ShouldBeFalse)
+	}
+
+	raw, _, err := g.RawPosition(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, raw, test.ShouldEqual, g.data.location)
+
+	// the average of the fixes 2, 4 and 5 meters north
+	filtered, filteredAlt, err := g.Position(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filteredAlt, test.ShouldEqual, alt)
+	test.That(t, origin.GreatCircleDistance(filtered)*1000, test.ShouldAlmostEqual, 11./3, 1e-3)
+	test.That(t, origin.BearingTo(filtered), test.ShouldAlmostEqual, 0, 1e-3)
+
+	t.Run("starts over when the accepted fix was the outlier", func(t *testing.T) {
+		f := newLocationFilter(&FilterConfig{MaxSpeedMMPerSec: 2000, WindowSize: 2})
+		test.That(t, f.add(origin.PointAtDistanceAndBearing(1, 90), start), test.ShouldBeTrue)
+		for i := 1; i <= 2; i++ {
+			test.That(t, f.add(origin.PointAtDistanceAndBearing(float64(i)/1000, 0), start.Add(time.Duration(i)*time.Second)),
+				test.ShouldBeFalse)
+		}
+		test.That(t, f.add(origin, start.Add(3*time.Second)), test.ShouldBeTrue)
+		test.That(t, f.location(), test.ShouldResemble, origin)
+	})
+
+	t.Run("no accepted fix", func(t *testing.T) {
+		g.filter = newLocationFilter(&FilterConfig{MaxSpeedMMPerSec: 2000})
+		_, _, err := g.FilteredPosition(ctx)
+		test.That(t, err, test.ShouldBeError, errNilLocation)
+	})
+}
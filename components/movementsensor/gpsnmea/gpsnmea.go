@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
 	"github.com/pkg/errors"
 	"go.viam.com/utils"
 
@@ -27,6 +28,14 @@ type AttrConfig struct {
 	Board          string `json:"board,omitempty"`
 	DisableNMEA    bool   `json:"disable_nmea,omitempty"`
 
+	// The position of the antenna in the base frame, in mm: +X to the right, +Y forward and +Z up
+	// from the center of the base.
+	AntennaOffsetMM r3.Vector `json:"antenna_offset_mm,omitempty"`
+	// Filter the location, when set.
+	Filter *FilterConfig `json:"filter,omitempty"`
+	// Seconds without a new fix after which the position is considered stale. Defaults to 5.
+	StaleFixTimeoutSec float64 `json:"stale_fix_timeout_sec,omitempty"`
+
 	*SerialAttrConfig `json:"serial_attributes,omitempty"`
 	*I2CAttrConfig    `json:"i2c_attributes,omitempty"`
 }
@@ -57,6 +66,16 @@ func (cfg *AttrConfig) Validate(path string) ([]string, error) {
 		return nil, utils.NewConfigValidationFieldRequiredError(path, "connection_type")
 	}
 
+	if cfg.StaleFixTimeoutSec < 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("stale_fix_timeout_sec cannot be negative"))
+	}
+
+	if cfg.Filter != nil {
+		if err := cfg.Filter.Validate(path); err != nil {
+			return nil, err
+		}
+	}
+
 	switch cfg.ConnectionType {
 	case i2cStr:
 		if cfg.Board == "" {
@@ -0,0 +1,27 @@
+package gpsnmea
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+// baseCenter returns the position and altitude, in meters, of the center of a base with the given
+// compass heading in degrees whose antenna is at the offset in mm from it and was located at
+// antenna and alt. The base is assumed to be level.
+func baseCenter(antenna *geo.Point, alt float64, offset r3.Vector, headingDeg float64) (*geo.Point, float64) {
+	// rotate the offset from the base frame to east and north
+	heading := rdkutils.DegToRad(headingDeg)
+	eastMM := offset.X*math.Cos(heading) + offset.Y*math.Sin(heading)
+	northMM := -offset.X*math.Sin(heading) + offset.Y*math.Cos(heading)
+	distMM := math.Hypot(eastMM, northMM)
+	if distMM == 0 {
+		return antenna, alt - offset.Z/1000
+	}
+	// the center is back from the antenna along the offset
+	bearing := rdkutils.RadToDeg(math.Atan2(eastMM, northMM)) + 180
+	return antenna.PointAtDistanceAndBearing(distMM/1e6, bearing), alt - offset.Z/1000
+}
@@ -27,6 +27,17 @@ type gpsData struct {
 	fixQuality int
 }
 
+// fixTypes names the fix qualities of GGA sentences.
+var fixTypes = []string{"invalid", "gps", "dgps", "pps", "rtk_fixed", "rtk_float", "dead_reckoning", "manual", "simulation"}
+
+// FixType returns the name of a fix quality as read by ReadFix, like "rtk_fixed" for 4.
+func FixType(fixQuality int) string {
+	if fixQuality < 0 || fixQuality >= len(fixTypes) {
+		return "unknown"
+	}
+	return fixTypes[fixQuality]
+}
+
 func errInvalidFix(sentenceType, badFix, goodFix string) error {
 	return errors.Errorf("type %q sentence fix is not valid have: %q  want %q", sentenceType, badFix, goodFix)
 }
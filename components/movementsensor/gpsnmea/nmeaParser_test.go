@@ -18,6 +18,9 @@ func TestParsing(t *testing.T) {
 	test.That(t, data.hDOP, test.ShouldEqual, 1.72)
 	test.That(t, data.location.Lat(), test.ShouldAlmostEqual, 44.05776, 0.001)
 	test.That(t, data.location.Lng(), test.ShouldAlmostEqual, -121.31325, 0.001)
+	test.That(t, FixType(data.fixQuality), test.ShouldEqual, "gps")
+	test.That(t, FixType(4), test.ShouldEqual, "rtk_fixed")
+	test.That(t, FixType(9), test.ShouldEqual, "unknown")
 
 	// Test GSA, should update HDOP
 	nmeaSentence = "$GPGSA,A,3,21,10,27,08,,,,,,,,,1.98,2.99,0.98*0E"
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
@@ -28,13 +29,17 @@ type PmtkI2CNMEAMovementSensor struct {
 	data                    gpsData
 	activeBackgroundWorkers sync.WaitGroup
 
-	disableNmea bool
-	errMu       sync.Mutex
-	lastError   error
-
-	bus   board.I2C
-	addr  byte
-	wbaud int
+	disableNmea   bool
+	antennaOffset r3.Vector
+	filter        *locationFilter
+	fixMonitor    *fixMonitor
+	errMu         sync.Mutex
+	lastError     error
+
+	bus     board.I2C
+	busName string
+	addr    byte
+	wbaud   int
 }
 
 // NewPmtkI2CGPSNMEA implements a gps that communicates over i2c.
@@ -73,13 +78,22 @@ func NewPmtkI2CGPSNMEA(
 	cancelCtx, cancelFunc := context.WithCancel(context.Background())
 
 	g := &PmtkI2CNMEAMovementSensor{
-		bus:         i2cbus,
-		addr:        byte(addr),
-		wbaud:       attr.I2CAttrConfig.I2CBaudRate,
-		cancelCtx:   cancelCtx,
-		cancelFunc:  cancelFunc,
-		logger:      logger,
-		disableNmea: disableNmea,
+		bus:           i2cbus,
+		busName:       attr.I2CAttrConfig.I2CBus,
+		addr:          byte(addr),
+		wbaud:         attr.I2CAttrConfig.I2CBaudRate,
+		cancelCtx:     cancelCtx,
+		cancelFunc:    cancelFunc,
+		logger:        logger,
+		disableNmea:   disableNmea,
+		antennaOffset: attr.AntennaOffsetMM,
+	}
+
+	if attr.Filter != nil {
+		g.filter = newLocationFilter(attr.Filter)
+	}
+	if !disableNmea {
+		g.fixMonitor = newFixMonitor(attr.StaleFixTimeoutSec, time.Now())
 	}
 
 	if err := g.Start(ctx); err != nil {
@@ -166,6 +180,13 @@ func (g *PmtkI2CNMEAMovementSensor) Start(ctx context.Context) error {
 						if strBuf != "" {
 							g.mu.Lock()
 							err = g.data.parseAndUpdate(strBuf)
+							if g.fixMonitor != nil {
+								g.fixMonitor.update(&g.data, time.Now())
+							}
+							if g.filter != nil && g.filter.update(&g.data, time.Now()) {
+								g.logger.Debugf("rejected gps fix %v", g.data.location)
+							}
+							fixQualityGauge.Set(float64(g.data.fixQuality), fmt.Sprintf("%s:%#x", g.busName, g.addr))
 							g.mu.Unlock()
 							if err != nil {
 								g.logger.Debugf("can't parse nmea : %s, %v", strBuf, err)
@@ -180,6 +201,14 @@ func (g *PmtkI2CNMEAMovementSensor) Start(ctx context.Context) error {
 		}
 	})
 
+	if g.fixMonitor != nil {
+		g.activeBackgroundWorkers.Add(1)
+		utils.PanicCapturingGo(func() {
+			defer g.activeBackgroundWorkers.Done()
+			monitorFix(g.cancelCtx, &g.mu, g.fixMonitor, &g.data, g.logger)
+		})
+	}
+
 	return g.lastError
 }
 
@@ -188,13 +217,52 @@ func (g *PmtkI2CNMEAMovementSensor) GetBusAddr() (board.I2C, byte) {
 	return g.bus, g.addr
 }
 
-// Position returns the current geographic location of the MovementSensor.
+// Position returns the current geographic location of the MovementSensor. It is filtered when a
+// filter is configured.
 func (g *PmtkI2CNMEAMovementSensor) Position(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+	return g.FilteredPosition(ctx)
+}
+
+// RawPosition returns the latest fix, whether or not a filter is configured.
+func (g *PmtkI2CNMEAMovementSensor) RawPosition(ctx context.Context) (*geo.Point, float64, error) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 	return g.data.location, g.data.alt, g.lastError
 }
 
+// FilteredPosition returns the average of the latest fixes the filter accepted, or the raw
+// position when no filter is configured.
+func (g *PmtkI2CNMEAMovementSensor) FilteredPosition(ctx context.Context) (*geo.Point, float64, error) {
+	if g.filter == nil {
+		return g.RawPosition(ctx)
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	location := g.filter.location()
+	if location == nil {
+		return geo.NewPoint(0, 0), 0, errNilLocation
+	}
+	return location, g.data.alt, g.lastError
+}
+
+// BaseCenterPosition returns the position and altitude of the center of the base rather than of the
+// antenna, given the compass heading of the base in degrees.
+func (g *PmtkI2CNMEAMovementSensor) BaseCenterPosition(
+	ctx context.Context,
+	headingDeg float64,
+	extra map[string]interface{},
+) (*geo.Point, float64, error) {
+	antenna, alt, err := g.Position(ctx, extra)
+	if err != nil {
+		return antenna, alt, err
+	}
+	if antenna == nil {
+		return geo.NewPoint(0, 0), 0, errNilLocation
+	}
+	center, centerAlt := baseCenter(antenna, alt, g.antennaOffset, headingDeg)
+	return center, centerAlt, nil
+}
+
 // Accuracy returns the accuracy, hDOP and vDOP.
 func (g *PmtkI2CNMEAMovementSensor) Accuracy(ctx context.Context, extra map[string]interface{}) (map[string]float32, error) {
 	g.mu.RLock()
@@ -248,6 +316,18 @@ func (g *PmtkI2CNMEAMovementSensor) ReadFix(ctx context.Context) (int, error) {
 	return g.data.fixQuality, g.lastError
 }
 
+// StaleFix returns whether no new fix has arrived within the stale fix timeout, in which case the
+// position is the last one the receiver sent rather than where it is now, and when the last fix
+// arrived.
+func (g *PmtkI2CNMEAMovementSensor) StaleFix(ctx context.Context) (bool, time.Time) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.fixMonitor == nil {
+		return false, time.Time{}
+	}
+	return g.fixMonitor.staleAt(time.Now()), g.fixMonitor.lastFix
+}
+
 // Readings will use return all of the MovementSensor Readings.
 func (g *PmtkI2CNMEAMovementSensor) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
 	readings, err := movementsensor.Readings(ctx, g, extra)
@@ -261,6 +341,8 @@ func (g *PmtkI2CNMEAMovementSensor) Readings(ctx context.Context, extra map[stri
 	}
 
 	readings["fix"] = fix
+	readings["fix_type"] = FixType(fix)
+	readings["stale_fix"], _ = g.StaleFix(ctx)
 
 	return readings, nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/edaniels/golog"
+	geo "github.com/kellydunn/golang-geo"
 	"go.viam.com/test"
 	gutils "go.viam.com/utils"
 
@@ -121,6 +122,18 @@ func TestReadingsI2C(t *testing.T) {
 	fix1, err := g.ReadFix(ctx)
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, fix1, test.ShouldEqual, fix)
+
+	// without a fix the position is a zero point, as for the serial gps
+	g.filter = newLocationFilter(&FilterConfig{MaxSpeedMMPerSec: 2000})
+	loc1, _, err = g.FilteredPosition(ctx)
+	test.That(t, err, test.ShouldBeError, errNilLocation)
+	test.That(t, loc1, test.ShouldResemble, geo.NewPoint(0, 0))
+
+	g.filter = nil
+	g.data = gpsData{}
+	loc1, _, err = g.BaseCenterPosition(ctx, 0, nil)
+	test.That(t, err, test.ShouldBeError, errNilLocation)
+	test.That(t, loc1, test.ShouldResemble, geo.NewPoint(0, 0))
 }
 
 func TestCloseI2C(t *testing.T) {
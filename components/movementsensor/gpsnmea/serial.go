@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/adrianmo/go-nmea"
 	"github.com/edaniels/golog"
@@ -18,10 +19,15 @@ import (
 
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/spatialmath"
 )
 
-var errNilLocation = errors.New("nil gps location, check nmea message parsing")
+var (
+	errNilLocation = errors.New("nil gps location, check nmea message parsing")
+
+	fixQualityGauge = metrics.NewGauge("rdk_gps_fix_quality", "Fix quality of the latest GGA sentence of gps devices.", "device")
+)
 
 // SerialNMEAMovementSensor allows the use of any MovementSensor chip that communicates over serial.
 type SerialNMEAMovementSensor struct {
@@ -33,9 +39,12 @@ type SerialNMEAMovementSensor struct {
 	data                    gpsData
 	activeBackgroundWorkers sync.WaitGroup
 
-	disableNmea bool
-	errMu       sync.Mutex
-	lastError   error
+	disableNmea   bool
+	antennaOffset r3.Vector
+	filter        *locationFilter
+	fixMonitor    *fixMonitor
+	errMu         sync.Mutex
+	lastError     error
 
 	dev                io.ReadWriteCloser
 	path               string
@@ -94,6 +103,14 @@ func NewSerialGPSNMEA(ctx context.Context, attr *AttrConfig, logger golog.Logger
 		baudRate:           uint(baudRate),
 		correctionBaudRate: uint(correctionBaudRate),
 		disableNmea:        disableNmea,
+		antennaOffset:      attr.AntennaOffsetMM,
+	}
+
+	if attr.Filter != nil {
+		g.filter = newLocationFilter(attr.Filter)
+	}
+	if !disableNmea {
+		g.fixMonitor = newFixMonitor(attr.StaleFixTimeoutSec, time.Now())
 	}
 
 	if err := g.Start(ctx); err != nil {
@@ -132,6 +149,13 @@ func (g *SerialNMEAMovementSensor) Start(ctx context.Context) error {
 				// Update our struct's gps data in-place
 				g.mu.Lock()
 				err = g.data.parseAndUpdate(line)
+				if g.fixMonitor != nil {
+					g.fixMonitor.update(&g.data, time.Now())
+				}
+				if g.filter != nil && g.filter.update(&g.data, time.Now()) {
+					g.logger.Debugf("rejected gps fix %v", g.data.location)
+				}
+				fixQualityGauge.Set(float64(g.data.fixQuality), g.path)
 				g.mu.Unlock()
 				if err != nil {
 					g.logger.Warnf("can't parse nmea sentence: %#v", err)
@@ -140,6 +164,14 @@ func (g *SerialNMEAMovementSensor) Start(ctx context.Context) error {
 		}
 	})
 
+	if g.fixMonitor != nil {
+		g.activeBackgroundWorkers.Add(1)
+		utils.PanicCapturingGo(func() {
+			defer g.activeBackgroundWorkers.Done()
+			monitorFix(g.cancelCtx, &g.mu, g.fixMonitor, &g.data, g.logger)
+		})
+	}
+
 	return g.lastError
 }
 
@@ -148,8 +180,13 @@ func (g *SerialNMEAMovementSensor) GetCorrectionInfo() (string, uint) {
 	return g.correctionPath, g.correctionBaudRate
 }
 
-// Position position, altitide.
+// Position position, altitide. It is filtered when a filter is configured.
 func (g *SerialNMEAMovementSensor) Position(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+	return g.FilteredPosition(ctx)
+}
+
+// RawPosition returns the latest fix, whether or not a filter is configured.
+func (g *SerialNMEAMovementSensor) RawPosition(ctx context.Context) (*geo.Point, float64, error) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 	if g.data.location == nil {
@@ -158,6 +195,36 @@ func (g *SerialNMEAMovementSensor) Position(ctx context.Context, extra map[strin
 	return g.data.location, g.data.alt, g.lastError
 }
 
+// FilteredPosition returns the average of the latest fixes the filter accepted, or the raw
+// position when no filter is configured.
+func (g *SerialNMEAMovementSensor) FilteredPosition(ctx context.Context) (*geo.Point, float64, error) {
+	if g.filter == nil {
+		return g.RawPosition(ctx)
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	location := g.filter.location()
+	if location == nil {
+		return geo.NewPoint(0, 0), 0, errNilLocation
+	}
+	return location, g.data.alt, g.lastError
+}
+
+// BaseCenterPosition returns the position and altitude of the center of the base rather than of the
+// antenna, given the compass heading of the base in degrees.
+func (g *SerialNMEAMovementSensor) BaseCenterPosition(
+	ctx context.Context,
+	headingDeg float64,
+	extra map[string]interface{},
+) (*geo.Point, float64, error) {
+	antenna, alt, err := g.Position(ctx, extra)
+	if err != nil {
+		return antenna, alt, err
+	}
+	center, centerAlt := baseCenter(antenna, alt, g.antennaOffset, headingDeg)
+	return center, centerAlt, nil
+}
+
 // Accuracy returns the accuracy, hDOP and vDOP.
 func (g *SerialNMEAMovementSensor) Accuracy(ctx context.Context, extra map[string]interface{}) (map[string]float32, error) {
 	g.mu.RLock()
@@ -198,6 +265,18 @@ func (g *SerialNMEAMovementSensor) ReadFix(ctx context.Context) (int, error) {
 	return g.data.fixQuality, nil
 }
 
+// StaleFix returns whether no new fix has arrived within the stale fix timeout, in which case the
+// position is the last one the receiver sent rather than where it is now, and when the last fix
+// arrived.
+func (g *SerialNMEAMovementSensor) StaleFix(ctx context.Context) (bool, time.Time) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.fixMonitor == nil {
+		return false, time.Time{}
+	}
+	return g.fixMonitor.staleAt(time.Now()), g.fixMonitor.lastFix
+}
+
 // Readings will use return all of the MovementSensor Readings.
 func (g *SerialNMEAMovementSensor) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
 	readings, err := movementsensor.Readings(ctx, g, extra)
@@ -211,6 +290,8 @@ func (g *SerialNMEAMovementSensor) Readings(ctx context.Context, extra map[strin
 	}
 
 	readings["fix"] = fix
+	readings["fix_type"] = FixType(fix)
+	readings["stale_fix"], _ = g.StaleFix(ctx)
 
 	return readings, g.lastError
 }
@@ -2,16 +2,19 @@ package gpsnmea
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"github.com/adrianmo/go-nmea"
 	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
 	geo "github.com/kellydunn/golang-geo"
 	"go.viam.com/test"
 	"go.viam.com/utils"
 
 	"go.viam.com/rdk/components/movementsensor"
 	"go.viam.com/rdk/config"
+	rdkutils "go.viam.com/rdk/utils"
 )
 
 var (
@@ -124,6 +127,54 @@ func TestReadingsSerial(t *testing.T) {
 	fix1, err := g.ReadFix(ctx)
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, fix1, test.ShouldEqual, fix)
+
+	readings, err := g.Readings(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings["position"], test.ShouldEqual, loc)
+	test.That(t, readings["altitide"], test.ShouldEqual, alt)
+	test.That(t, readings["linear_velocity"], test.ShouldResemble, r3.Vector{Y: speed})
+	test.That(t, readings["fix"], test.ShouldEqual, fix)
+	test.That(t, readings["fix_type"], test.ShouldEqual, "gps")
+}
+
+func TestBaseCenterPosition(t *testing.T) {
+	ctx := context.Background()
+	antenna := geo.NewPoint(40.7, -74)
+	g := &SerialNMEAMovementSensor{
+		logger: golog.NewTestLogger(t),
+		// a meter ahead of the center, half a meter to the right and 200mm up
+		antennaOffset: r3.Vector{X: 500, Y: 1000, Z: 200},
+	}
+	g.data = gpsData{location: antenna, alt: alt}
+
+	// facing east, so the center is a meter west and half a meter north of the antenna
+	center, centerAlt, err := g.BaseCenterPosition(ctx, 90, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, centerAlt, test.ShouldAlmostEqual, alt-0.2)
+	metersPerDegree := 6371000 * math.Pi / 180
+	northM := (center.Lat() - antenna.Lat()) * metersPerDegree
+	eastM := (center.Lng() - antenna.Lng()) * metersPerDegree * math.Cos(rdkutils.DegToRad(antenna.Lat()))
+	test.That(t, northM, test.ShouldAlmostEqual, 0.5, 1e-3)
+	test.That(t, eastM, test.ShouldAlmostEqual, -1, 1e-3)
+
+	// facing south west
+	center, _, err = g.BaseCenterPosition(ctx, 225, nil)
+	test.That(t, err, test.ShouldBeNil)
+	northM = (center.Lat() - antenna.Lat()) * metersPerDegree
+	eastM = (center.Lng() - antenna.Lng()) * metersPerDegree * math.Cos(rdkutils.DegToRad(antenna.Lat()))
+	test.That(t, northM, test.ShouldAlmostEqual, (1-0.5)/math.Sqrt2, 1e-3)
+	test.That(t, eastM, test.ShouldAlmostEqual, (1+0.5)/math.Sqrt2, 1e-3)
+
+	// without an offset the antenna is the center
+	g.antennaOffset = r3.Vector{}
+	center, centerAlt, err = g.BaseCenterPosition(ctx, 225, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, center, test.ShouldEqual, antenna)
+	test.That(t, centerAlt, test.ShouldEqual, alt)
+
+	g.data = gpsData{}
+	_, _, err = g.BaseCenterPosition(ctx, 0, nil)
+	test.That(t, err, test.ShouldBeError, errNilLocation)
 }
 
 func TestCloseSerial(t *testing.T) {
@@ -0,0 +1,78 @@
+package gpsnmea
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	geo "github.com/kellydunn/golang-geo"
+	"go.viam.com/utils"
+)
+
+const defaultStaleFixTimeout = 5 * time.Second
+
+// A fixMonitor notices when a receiver stops sending new fixes, so that the last fix it sent is not
+// mistaken for where it is now.
+type fixMonitor struct {
+	timeout time.Duration
+
+	seen    *geo.Point
+	lastFix time.Time
+	stale   bool
+}
+
+// newFixMonitor returns a monitor that considers the fix stale once the timeout passes without a
+// new one, counting from the given time.
+func newFixMonitor(timeoutSec float64, now time.Time) *fixMonitor {
+	timeout := time.Duration(timeoutSec * float64(time.Second))
+	if timeout == 0 {
+		timeout = defaultStaleFixTimeout
+	}
+	return &fixMonitor{timeout: timeout, lastFix: now}
+}
+
+// update records the time of the fix in data if it is one not seen before.
+func (m *fixMonitor) update(data *gpsData, now time.Time) {
+	if data.location == nil || data.location == m.seen || !data.valid {
+		return
+	}
+	m.seen = data.location
+	m.lastFix = now
+}
+
+// staleAt returns whether no new fix has arrived within the timeout before the given time.
+func (m *fixMonitor) staleAt(now time.Time) bool {
+	return now.Sub(m.lastFix) > m.timeout
+}
+
+// check returns whether the fix is stale at the given time and whether that changed since the
+// last check.
+func (m *fixMonitor) check(now time.Time) (bool, bool) {
+	stale := m.staleAt(now)
+	changed := stale != m.stale
+	m.stale = stale
+	return stale, changed
+}
+
+// monitorFix checks the monitor, which is guarded by mu along with data, until ctx is done. It
+// logs the health of the receiver on every check, and warns when the fix goes stale.
+func monitorFix(ctx context.Context, mu *sync.RWMutex, monitor *fixMonitor, data *gpsData, logger golog.Logger) {
+	for utils.SelectContextOrWait(ctx, monitor.timeout/4) {
+		now := time.Now()
+		mu.Lock()
+		stale, changed := monitor.check(now)
+		lastFix := monitor.lastFix
+		fixQuality, hDOP, satsInUse := data.fixQuality, data.hDOP, data.satsInUse
+		mu.Unlock()
+
+		logger.Debugw("gps health",
+			"fix_type", FixType(fixQuality), "hdop", hDOP, "sats_in_use", satsInUse, "fix_age", now.Sub(lastFix))
+		switch {
+		case stale && changed:
+			logger.Warnw("no new gps fix, position is stale", "last_fix", lastFix)
+		case changed:
+			logger.Infow("gps fix resumed", "stale_for", now.Sub(lastFix))
+		}
+	}
+}
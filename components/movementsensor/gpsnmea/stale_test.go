@@ -0,0 +1,102 @@
+package gpsnmea
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+)
+
+const ggaSentence = "$GNGGA,191351.000,4403.4655,N,12118.7950,W,1,6,1.72,1094.5,M,-19.6,M,,*47\n"
+
+func TestFixMonitor(t *testing.T) {
+	start := time.Now()
+	m := newFixMonitor(0, start)
+	test.That(t, m.timeout, test.ShouldEqual, defaultStaleFixTimeout)
+
+	var data gpsData
+	test.That(t, data.parseAndUpdate(ggaSentence), test.ShouldBeNil)
+	m.update(&data, start.Add(time.Second))
+	stale, changed := m.check(start.Add(5 * time.Second))
+	test.That(t, stale, test.ShouldBeFalse)
+	test.That(t, changed, test.ShouldBeFalse)
+
+	// the same fix read again is not a new one
+	m.update(&data, start.Add(6*time.Second))
+	stale, changed = m.check(start.Add(7 * time.Second))
+	test.That(t, stale, test.ShouldBeTrue)
+	test.That(t, changed, test.ShouldBeTrue)
+	stale, changed = m.check(start.Add(8 * time.Second))
+	test.That(t, stale, test.ShouldBeTrue)
+	test.That(t, changed, test.ShouldBeFalse)
+
+	test.That(t, data.parseAndUpdate(ggaSentence), test.ShouldBeNil)
+	m.update(&data, start.Add(9*time.Second))
+	stale, changed = m.check(start.Add(9 * time.Second))
+	test.That(t, stale, test.ShouldBeFalse)
+	test.That(t, changed, test.ShouldBeTrue)
+}
+
+// pipeDev is a serial device that reads what is written to the other end of its pipe.
+type pipeDev struct {
+	*io.PipeReader
+}
+
+func (d pipeDev) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func TestStaleFixSerial(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+	cancelCtx, cancelFunc := context.WithCancel(ctx)
+	r, w := io.Pipe()
+	g := &SerialNMEAMovementSensor{
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		logger:     logger,
+		dev:        pipeDev{r},
+		fixMonitor: newFixMonitor(0.2, time.Now()),
+	}
+	test.That(t, g.Start(ctx), test.ShouldBeNil)
+	defer func() {
+		test.That(t, g.Close(), test.ShouldBeNil)
+	}()
+
+	feedCtx, stopFeed := context.WithCancel(ctx)
+	feedDone := make(chan struct{})
+	go func() {
+		defer close(feedDone)
+		for {
+			if _, err := io.WriteString(w, ggaSentence); err != nil {
+				return
+			}
+			select {
+			case <-feedCtx.Done():
+				return
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+	stale, lastFix := g.StaleFix(ctx)
+	test.That(t, stale, test.ShouldBeFalse)
+	test.That(t, time.Since(lastFix), test.ShouldBeLessThan, 200*time.Millisecond)
+
+	stopFeed()
+	<-feedDone
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		stale, _ := g.StaleFix(ctx)
+		test.That(tb, stale, test.ShouldBeTrue)
+	})
+	readings, err := g.Readings(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings["stale_fix"], test.ShouldEqual, true)
+	test.That(t, w.Close(), test.ShouldBeNil)
+}
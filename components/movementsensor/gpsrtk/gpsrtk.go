@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/de-bkg/gognss/pkg/ntrip"
 	"github.com/edaniels/golog"
@@ -120,7 +121,11 @@ func (cfg *NtripAttrConfig) ValidateNtrip(path string) error {
 	return nil
 }
 
-const roverModel = "gps-rtk"
+const (
+	roverModel               = "gps-rtk"
+	defaultConnectAttempts   = 10
+	defaultReconnectInterval = time.Second
+)
 
 func init() {
 	registry.RegisterComponent(
@@ -159,6 +164,7 @@ type RTKMovementSensor struct {
 	ntripClient        *NtripInfo
 	correctionWriter   io.ReadWriteCloser
 	ntripStatus        bool
+	reconnectInterval  time.Duration
 
 	bus       board.I2C
 	wbaud     int
@@ -182,9 +188,10 @@ func newRTKMovementSensor(
 	cancelCtx, cancelFunc := context.WithCancel(ctx)
 
 	g := &RTKMovementSensor{
-		cancelCtx:  cancelCtx,
-		cancelFunc: cancelFunc,
-		logger:     logger,
+		cancelCtx:         cancelCtx,
+		cancelFunc:        cancelFunc,
+		logger:            logger,
+		reconnectInterval: defaultReconnectInterval,
 	}
 
 	g.inputProtocol = attr.CorrectionSource
@@ -225,7 +232,11 @@ func newRTKMovementSensor(
 		MountPoint:         attr.NtripMountpoint,
 		Client:             &ntrip.Client{},
 		Stream:             nil,
-		MaxConnectAttempts: 0,
+		MaxConnectAttempts: attr.NtripConnectAttempts,
+	}
+	if g.ntripClient.MaxConnectAttempts == 0 {
+		g.ntripClient.MaxConnectAttempts = defaultConnectAttempts
+		g.logger.Infof("ntrip_connect_attempts using default %d", defaultConnectAttempts)
 	}
 
 	// baud rate
@@ -234,9 +245,10 @@ func newRTKMovementSensor(
 		g.logger.Info("ntrip_baud using default baud rate 38400")
 	}
 
-	if g.writepath != "" {
+	g.writepath = attr.NtripPath
+	if g.writepath == "" && attr.SerialAttrConfig != nil {
 		g.logger.Info("ntrip_path will use same path for writing RCTM messages to gps")
-		g.writepath = attr.NtripPath
+		g.writepath = attr.SerialPath
 	}
 
 	// I2C address only, assumes address is correct since this was checked when gps was initialized
@@ -340,6 +352,27 @@ func (g *RTKMovementSensor) GetStream(mountPoint string, maxAttempts int) error
 	return g.lastError
 }
 
+// reconnect gets the stream again after it was lost, connecting to the caster again if it has to.
+// It keeps trying every reconnect interval until it succeeds or the sensor is closed, and returns
+// whether it succeeded.
+func (g *RTKMovementSensor) reconnect() bool {
+	g.ntripStatus = false
+	for {
+		err := g.GetStream(g.ntripClient.MountPoint, g.ntripClient.MaxConnectAttempts)
+		if err == nil {
+			g.ntripStatus = true
+			return true
+		}
+		g.logger.Debugw("caster unavailable, will reconnect", "error", err)
+		if !utils.SelectContextOrWait(g.cancelCtx, g.reconnectInterval) {
+			return false
+		}
+		if err := g.Connect(g.ntripClient.URL, g.ntripClient.Username, g.ntripClient.Password, g.ntripClient.MaxConnectAttempts); err != nil {
+			g.logger.Debugw("cannot connect to caster", "error", err)
+		}
+	}
+}
+
 // ReceiveAndWriteI2C connects to NTRIP receiver and sends correction stream to the MovementSensor through I2C protocol.
 func (g *RTKMovementSensor) ReceiveAndWriteI2C(ctx context.Context) {
 	g.activeBackgroundWorkers.Add(1)
@@ -435,9 +468,7 @@ func (g *RTKMovementSensor) ReceiveAndWriteI2C(ctx context.Context) {
 			g.ntripStatus = false
 			if msg == nil {
 				g.logger.Debug("No message... reconnecting to stream...")
-				err = g.GetStream(g.ntripClient.MountPoint, g.ntripClient.MaxConnectAttempts)
-				if err != nil {
-					g.setLastError(err)
+				if !g.reconnect() {
 					return
 				}
 
@@ -489,20 +520,22 @@ func (g *RTKMovementSensor) ReceiveAndWriteSerial() {
 		g.logger.Infof("caster %s seems to be down", g.ntripClient.URL)
 	}
 
-	options := slib.OpenOptions{
-		PortName:        g.writepath,
-		BaudRate:        uint(g.wbaud),
-		DataBits:        8,
-		StopBits:        1,
-		MinimumReadSize: 1,
-	}
+	if g.correctionWriter == nil {
+		options := slib.OpenOptions{
+			PortName:        g.writepath,
+			BaudRate:        uint(g.wbaud),
+			DataBits:        8,
+			StopBits:        1,
+			MinimumReadSize: 1,
+		}
 
-	// Open the port.
-	g.correctionWriter, err = slib.Open(options)
-	if err != nil {
-		g.logger.Errorf("serial.Open: %v", err)
-		g.setLastError(err)
-		return
+		// Open the port.
+		g.correctionWriter, err = slib.Open(options)
+		if err != nil {
+			g.logger.Errorf("serial.Open: %v", err)
+			g.setLastError(err)
+			return
+		}
 	}
 
 	w := bufio.NewWriter(g.correctionWriter)
@@ -518,7 +551,7 @@ func (g *RTKMovementSensor) ReceiveAndWriteSerial() {
 
 	g.ntripStatus = true
 
-	for g.ntripStatus {
+	for {
 		select {
 		case <-g.cancelCtx.Done():
 			return
@@ -526,21 +559,22 @@ func (g *RTKMovementSensor) ReceiveAndWriteSerial() {
 		}
 
 		msg, err := scanner.NextMessage()
-		if err != nil {
-			g.ntripStatus = false
-			if msg == nil {
-				g.logger.Debug("No message... reconnecting to stream...")
-				err = g.GetStream(g.ntripClient.MountPoint, g.ntripClient.MaxConnectAttempts)
-				if err != nil {
-					g.setLastError(err)
-					return
-				}
-
-				r = io.TeeReader(g.ntripClient.Stream, w)
-				scanner = rtcm3.NewScanner(r)
-				g.ntripStatus = true
-				continue
+		if err != nil && msg == nil {
+			g.logger.Debug("No message... reconnecting to stream...")
+			if !g.reconnect() {
+				return
 			}
+			// drop the partial message left of the lost stream
+			w.Reset(g.correctionWriter)
+			r = io.TeeReader(g.ntripClient.Stream, w)
+			scanner = rtcm3.NewScanner(r)
+			continue
+		}
+		// send the whole message on to the gps now rather than when the buffer fills
+		if err := w.Flush(); err != nil {
+			g.logger.Errorf("failed to write corrections: %s", err)
+			g.setLastError(err)
+			return
 		}
 	}
 }
@@ -627,6 +661,7 @@ func (g *RTKMovementSensor) Readings(ctx context.Context, extra map[string]inter
 	}
 
 	readings["fix"] = fix
+	readings["fix_type"] = gpsnmea.FixType(fix)
 
 	return readings, nil
 }
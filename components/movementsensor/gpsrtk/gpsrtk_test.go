@@ -1,10 +1,16 @@
 package gpsrtk
 
 import (
+	"bytes"
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
+	"github.com/go-gnss/rtcm/rtcm3"
 	geo "github.com/kellydunn/golang-geo"
 	"go.viam.com/test"
 	"go.viam.com/utils"
@@ -215,6 +221,98 @@ func TestNewRTKMovementSensor(t *testing.T) {
 	})
 }
 
+// A deviceWriter records the corrections written to the gps.
+type deviceWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *deviceWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *deviceWriter) Read(p []byte) (int, error) {
+	return 0, nil
+}
+
+func (w *deviceWriter) Close() error {
+	return nil
+}
+
+func (w *deviceWriter) written() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]byte(nil), w.buf.Bytes()...)
+}
+
+func TestReceiveAndWriteSerial(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	first := rtcm3.EncapsulateByteArray([]byte{0xFF, 0xE0, 1, 2, 3}).Serialize()
+	second := rtcm3.EncapsulateByteArray([]byte{0xFF, 0xE0, 4, 5, 6}).Serialize()
+
+	// a caster that sends a correction, goes down, then comes back with another
+	var mu sync.Mutex
+	var streamRequests int
+	caster := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mp" {
+			return
+		}
+		mu.Lock()
+		streamRequests++
+		request := streamRequests
+		mu.Unlock()
+		switch request {
+		case 1:
+			w.Header().Set("Content-Type", "gnss/data")
+			w.Write(first)
+		case 3:
+			w.Header().Set("Content-Type", "gnss/data")
+			w.Write(second)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer caster.Close()
+
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+	device := &deviceWriter{}
+	g := &RTKMovementSensor{
+		cancelCtx:         cancelCtx,
+		cancelFunc:        cancelFunc,
+		logger:            logger,
+		reconnectInterval: 10 * time.Millisecond,
+		correctionWriter:  device,
+		ntripClient: &NtripInfo{
+			URL:                caster.URL,
+			MountPoint:         "mp",
+			MaxConnectAttempts: 1,
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		g.ReceiveAndWriteSerial()
+		close(done)
+	}()
+
+	want := append(append([]byte(nil), first...), second...)
+	for start := time.Now(); !bytes.Equal(device.written(), want); {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("wrote %v to the gps, want %v", device.written(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	test.That(t, streamRequests, test.ShouldBeGreaterThanOrEqualTo, 3)
+	mu.Unlock()
+
+	cancelFunc()
+	<-done
+	test.That(t, g.lastError, test.ShouldBeNil)
+}
+
 func TestReadingsRTK(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	ctx := context.Background()
@@ -0,0 +1,195 @@
+// Package headingfusion implements a movementsensor whose compass heading fuses a relative
+// heading source, such as a gyro or lidar, with an absolute one, such as a magnetometer.
+package headingfusion
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/spatialmath"
+	rutils "go.viam.com/rdk/utils"
+)
+
+const (
+	modelName             = "heading-fusion"
+	defaultAbsoluteWeight = 0.02
+)
+
+// AttrConfig is used to configure a heading fusion movement sensor.
+type AttrConfig struct {
+	// Relative is a movement sensor whose heading changes smoothly and responsively but drifts.
+	Relative string `json:"relative"`
+	// Absolute is a movement sensor whose heading does not drift but is noisy, like a compass.
+	Absolute string `json:"absolute"`
+	// AbsoluteWeight is how much of the difference between the fused and absolute headings is
+	// corrected on each reading, between 0 and 1.
+	AbsoluteWeight float64 `json:"absolute_weight,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid, and then returns the list of things we
+// depend on.
+func (cfg *AttrConfig) Validate(path string) ([]string, error) {
+	if cfg.Relative == "" {
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "relative")
+	}
+	if cfg.Absolute == "" {
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "absolute")
+	}
+	if cfg.AbsoluteWeight < 0 || cfg.AbsoluteWeight > 1 {
+		return nil, utils.NewConfigValidationError(path, errors.New("absolute_weight must be between 0 and 1"))
+	}
+	return []string{cfg.Relative, cfg.Absolute}, nil
+}
+
+func init() {
+	registry.RegisterComponent(movementsensor.Subtype, modelName, registry.Component{
+		Constructor: func(
+			ctx context.Context,
+			deps registry.Dependencies,
+			cfg config.Component,
+			logger golog.Logger,
+		) (interface{}, error) {
+			attrs, ok := cfg.ConvertedAttributes.(*AttrConfig)
+			if !ok {
+				return nil, rutils.NewUnexpectedTypeError(attrs, cfg.ConvertedAttributes)
+			}
+			relative, err := movementsensor.FromDependencies(deps, attrs.Relative)
+			if err != nil {
+				return nil, err
+			}
+			absolute, err := movementsensor.FromDependencies(deps, attrs.Absolute)
+			if err != nil {
+				return nil, err
+			}
+			return newHeadingFusion(relative, absolute, attrs.AbsoluteWeight, logger), nil
+		},
+	})
+
+	config.RegisterComponentAttributeMapConverter(movementsensor.SubtypeName, modelName,
+		func(attributes config.AttributeMap) (interface{}, error) {
+			var attr AttrConfig
+			return config.TransformAttributeMapToStruct(&attr, attributes)
+		},
+		&AttrConfig{})
+}
+
+type headingFusion struct {
+	relative       movementsensor.MovementSensor
+	absolute       movementsensor.MovementSensor
+	absoluteWeight float64
+	logger         golog.Logger
+
+	mu           sync.Mutex
+	initialized  bool
+	lastRelative float64
+	fused        float64
+
+	generic.Unimplemented
+}
+
+func newHeadingFusion(relative, absolute movementsensor.MovementSensor, absoluteWeight float64, logger golog.Logger) *headingFusion {
+	if absoluteWeight == 0 {
+		absoluteWeight = defaultAbsoluteWeight
+	}
+	return &headingFusion{
+		relative:       relative,
+		absolute:       absolute,
+		absoluteWeight: absoluteWeight,
+		logger:         logger,
+	}
+}
+
+// angleDiff returns the signed difference from one heading to another in degrees, in [-180, 180).
+func angleDiff(from, to float64) float64 {
+	return math.Mod(math.Mod(to-from, 360)+540, 360) - 180
+}
+
+func normalize(heading float64) float64 {
+	return math.Mod(math.Mod(heading, 360)+360, 360)
+}
+
+// CompassHeading follows the changes of the relative heading while correcting its drift towards
+// the absolute heading. When only one of the sources can be read, the other one is relied on.
+func (hf *headingFusion) CompassHeading(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	relative, relErr := hf.relative.CompassHeading(ctx, extra)
+	absolute, absErr := hf.absolute.CompassHeading(ctx, extra)
+
+	hf.mu.Lock()
+	defer hf.mu.Unlock()
+	switch {
+	case relErr != nil && absErr != nil:
+		return 0, multierr.Combine(relErr, absErr)
+	case relErr != nil:
+		hf.logger.Debugw("relative heading unavailable, using absolute heading", "error", relErr)
+		// the relative heading has to be picked up afresh once it comes back
+		hf.initialized = false
+		hf.fused = absolute
+		return normalize(absolute), nil
+	case !hf.initialized:
+		hf.initialized = true
+		hf.lastRelative = relative
+		if absErr != nil {
+			hf.fused = relative
+		} else {
+			hf.fused = absolute
+		}
+		return normalize(hf.fused), nil
+	}
+
+	hf.fused += angleDiff(hf.lastRelative, relative)
+	hf.lastRelative = relative
+	if absErr != nil {
+		hf.logger.Debugw("absolute heading unavailable, using relative heading", "error", absErr)
+	} else {
+		hf.fused += hf.absoluteWeight * angleDiff(hf.fused, absolute)
+	}
+	hf.fused = normalize(hf.fused)
+	return hf.fused, nil
+}
+
+func (hf *headingFusion) AngularVelocity(ctx context.Context, extra map[string]interface{}) (spatialmath.AngularVelocity, error) {
+	return hf.relative.AngularVelocity(ctx, extra)
+}
+
+func (hf *headingFusion) LinearVelocity(ctx context.Context, extra map[string]interface{}) (r3.Vector, error) {
+	return r3.Vector{}, movementsensor.ErrMethodUnimplementedLinearVelocity
+}
+
+func (hf *headingFusion) Orientation(ctx context.Context, extra map[string]interface{}) (spatialmath.Orientation, error) {
+	return nil, movementsensor.ErrMethodUnimplementedOrientation
+}
+
+func (hf *headingFusion) Position(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+	return geo.NewPoint(0, 0), 0, movementsensor.ErrMethodUnimplementedPosition
+}
+
+func (hf *headingFusion) Accuracy(ctx context.Context, extra map[string]interface{}) (map[string]float32, error) {
+	return map[string]float32{}, movementsensor.ErrMethodUnimplementedAccuracy
+}
+
+func (hf *headingFusion) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	return movementsensor.Readings(ctx, hf, extra)
+}
+
+func (hf *headingFusion) Properties(ctx context.Context, extra map[string]interface{}) (*movementsensor.Properties, error) {
+	relProps, err := hf.relative.Properties(ctx, extra)
+	if err != nil {
+		return nil, err
+	}
+	return &movementsensor.Properties{
+		CompassHeadingSupported:  true,
+		AngularVelocitySupported: relProps.AngularVelocitySupported,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package headingfusion
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestAngleDiff(t *testing.T) {
+	test.That(t, angleDiff(10, 20), test.ShouldAlmostEqual, 10)
+	test.That(t, angleDiff(350, 10), test.ShouldAlmostEqual, 20)
+	test.That(t, angleDiff(10, 350), test.ShouldAlmostEqual, -20)
+	test.That(t, angleDiff(0, 180), test.ShouldAlmostEqual, -180)
+}
+
+func TestCompassHeading(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	var truth, relative float64
+	var relErr, absErr error
+	relSensor := &inject.MovementSensor{}
+	relSensor.CompassHeadingFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+		return relative, relErr
+	}
+	absSensor := &inject.MovementSensor{}
+	absSensor.CompassHeadingFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+		return truth, absErr
+	}
+	hf := newHeadingFusion(relSensor, absSensor, 0.1, logger)
+
+	heading, err := hf.CompassHeading(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, heading, test.ShouldEqual, 0)
+
+	// turn in a circle a few times while the relative heading drifts by a degree each step
+	for i := 0; i < 200; i++ {
+		truth = math.Mod(truth+7, 360)
+		relative = math.Mod(relative+8, 360)
+		heading, err = hf.CompassHeading(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+	}
+	test.That(t, math.Abs(angleDiff(relative, truth)), test.ShouldBeGreaterThan, 100)
+	test.That(t, math.Abs(angleDiff(heading, truth)), test.ShouldBeLessThan, 10)
+
+	t.Run("absolute source fails", func(t *testing.T) {
+		absErr = errors.New("no fix")
+		defer func() { absErr = nil }()
+		before, err := hf.CompassHeading(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		relative = math.Mod(relative+30, 360)
+		after, err := hf.CompassHeading(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, angleDiff(before, after), test.ShouldAlmostEqual, 30)
+	})
+
+	t.Run("relative source fails", func(t *testing.T) {
+		relErr = errors.New("lidar unplugged")
+		defer func() { relErr = nil }()
+		truth = 123
+		heading, err := hf.CompassHeading(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, heading, test.ShouldEqual, 123)
+	})
+
+	t.Run("both sources fail", func(t *testing.T) {
+		relErr = errors.New("lidar unplugged")
+		absErr = errors.New("no fix")
+		defer func() { relErr, absErr = nil, nil }()
+		_, err := hf.CompassHeading(ctx, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	cfg := &AttrConfig{Relative: "gyro", Absolute: "compass", AbsoluteWeight: 2}
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	cfg.AbsoluteWeight = 0.5
+	deps, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"gyro", "compass"})
+}
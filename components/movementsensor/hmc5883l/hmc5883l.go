@@ -0,0 +1,270 @@
+// Package hmc5883l implements the movementsensor interface for an HMC5883L 3-axis magnetometer,
+// which is used as an absolute compass.
+package hmc5883l
+
+import (
+	"context"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/spatialmath"
+	rutils "go.viam.com/rdk/utils"
+)
+
+const modelName = "magnetometer-hmc5883l"
+
+const (
+	address = 0x1E
+
+	configRegisterA = 0x00
+	configRegisterB = 0x01
+	modeRegister    = 0x02
+	dataRegister    = 0x03
+	idRegister      = 0x0A
+
+	// 8 samples averaged per measurement at 15Hz.
+	defaultConfigA = 0x70
+	// A gain of 1090 LSB per gauss, for fields of up to 1.3 gauss.
+	defaultConfigB  = 0x20
+	lsbPerGauss     = 1090.
+	continuousMode  = 0x00
+	overflowReading = -4096
+)
+
+// AttrConfig is used to configure the attributes of the chip.
+type AttrConfig struct {
+	BoardName string `json:"board"`
+	I2cBus    string `json:"i2c_bus"`
+	// Accelerometer is an optional movement sensor mounted with the same axes as the
+	// magnetometer, whose linear_acceleration reading is used to compensate for tilt.
+	Accelerometer           string                                  `json:"accelerometer,omitempty"`
+	MagnetometerCalibration *movementsensor.MagnetometerCalibration `json:"magnetometer_calibration,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid, and then returns the list of things we
+// depend on.
+func (cfg *AttrConfig) Validate(path string) ([]string, error) {
+	if cfg.BoardName == "" {
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "board")
+	}
+	if cfg.I2cBus == "" {
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "i2c_bus")
+	}
+
+	deps := []string{cfg.BoardName}
+	if cfg.Accelerometer != "" {
+		deps = append(deps, cfg.Accelerometer)
+	}
+	return deps, nil
+}
+
+func init() {
+	registry.RegisterComponent(movementsensor.Subtype, modelName, registry.Component{
+		Constructor: func(
+			ctx context.Context,
+			deps registry.Dependencies,
+			cfg config.Component,
+			logger golog.Logger,
+		) (interface{}, error) {
+			return NewHMC5883L(ctx, deps, cfg, logger)
+		},
+	})
+
+	config.RegisterComponentAttributeMapConverter(movementsensor.SubtypeName, modelName,
+		func(attributes config.AttributeMap) (interface{}, error) {
+			var attr AttrConfig
+			return config.TransformAttributeMapToStruct(&attr, attributes)
+		},
+		&AttrConfig{})
+}
+
+type hmc5883l struct {
+	bus           board.I2C
+	accelerometer movementsensor.MovementSensor
+	calibration   movementsensor.MagnetometerCalibration
+	logger        golog.Logger
+
+	generic.Unimplemented
+}
+
+// NewHMC5883L constructs a new HMC5883L magnetometer.
+func NewHMC5883L(
+	ctx context.Context,
+	deps registry.Dependencies,
+	rawConfig config.Component,
+	logger golog.Logger,
+) (movementsensor.MovementSensor, error) {
+	cfg, ok := rawConfig.ConvertedAttributes.(*AttrConfig)
+	if !ok {
+		return nil, rutils.NewUnexpectedTypeError(cfg, rawConfig.ConvertedAttributes)
+	}
+
+	b, err := board.FromDependencies(deps, cfg.BoardName)
+	if err != nil {
+		return nil, err
+	}
+	localB, ok := b.(board.LocalBoard)
+	if !ok {
+		return nil, errors.Errorf("board %s is not local", cfg.BoardName)
+	}
+	bus, ok := localB.I2CByName(cfg.I2cBus)
+	if !ok {
+		return nil, errors.Errorf("can't find I2C bus '%s' for HMC5883L sensor", cfg.I2cBus)
+	}
+
+	var accelerometer movementsensor.MovementSensor
+	if cfg.Accelerometer != "" {
+		accelerometer, err = movementsensor.FromDependencies(deps, cfg.Accelerometer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var calibration movementsensor.MagnetometerCalibration
+	if cfg.MagnetometerCalibration != nil {
+		calibration = *cfg.MagnetometerCalibration
+	}
+	return newHMC5883L(ctx, bus, accelerometer, calibration, logger)
+}
+
+func newHMC5883L(
+	ctx context.Context,
+	bus board.I2C,
+	accelerometer movementsensor.MovementSensor,
+	calibration movementsensor.MagnetometerCalibration,
+	logger golog.Logger,
+) (*hmc5883l, error) {
+	sensor := &hmc5883l{
+		bus:           bus,
+		accelerometer: accelerometer,
+		calibration:   calibration,
+		logger:        logger,
+	}
+
+	handle, err := bus.OpenHandle(address)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := handle.Close(); err != nil {
+			logger.Error(err)
+		}
+	}()
+
+	// the identification registers always read "H43"
+	id, err := handle.ReadBlockData(ctx, idRegister, 3)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read from HMC5883L")
+	}
+	if string(id) != "H43" {
+		return nil, errors.Errorf("unexpected non-HMC5883L device at address %d: id '%v'", address, id)
+	}
+	for register, value := range map[byte]byte{
+		configRegisterA: defaultConfigA,
+		configRegisterB: defaultConfigB,
+		modeRegister:    continuousMode,
+	} {
+		if err := handle.WriteByteData(ctx, register, value); err != nil {
+			return nil, errors.Wrap(err, "can't configure HMC5883L")
+		}
+	}
+	return sensor, nil
+}
+
+// magnetometer returns the calibrated magnetic field in gauss.
+func (h *hmc5883l) magnetometer(ctx context.Context) (r3.Vector, error) {
+	handle, err := h.bus.OpenHandle(address)
+	if err != nil {
+		return r3.Vector{}, err
+	}
+	defer func() {
+		if err := handle.Close(); err != nil {
+			h.logger.Error(err)
+		}
+	}()
+
+	data, err := handle.ReadBlockData(ctx, dataRegister, 6)
+	if err != nil {
+		return r3.Vector{}, err
+	}
+	if len(data) != 6 {
+		return r3.Vector{}, errors.Errorf("expected 6 bytes of magnetometer data, got %d", len(data))
+	}
+	// the data registers are in x, z, y order
+	x := rutils.Int16FromBytesBE(data[0:2])
+	z := rutils.Int16FromBytesBE(data[2:4])
+	y := rutils.Int16FromBytesBE(data[4:6])
+	if x == overflowReading || y == overflowReading || z == overflowReading {
+		return r3.Vector{}, errors.New("HMC5883L reading overflowed")
+	}
+	mag := r3.Vector{X: float64(x) / lsbPerGauss, Y: float64(y) / lsbPerGauss, Z: float64(z) / lsbPerGauss}
+	return h.calibration.Apply(mag), nil
+}
+
+// CompassHeading returns the heading in degrees from magnetic north, compensated for tilt when
+// an accelerometer is configured.
+func (h *hmc5883l) CompassHeading(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	mag, err := h.magnetometer(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if h.accelerometer == nil {
+		return movementsensor.MagnetometerHeading(mag), nil
+	}
+	readings, err := h.accelerometer.Readings(ctx, extra)
+	if err != nil {
+		return 0, err
+	}
+	acceleration, ok := readings["linear_acceleration"].(r3.Vector)
+	if !ok {
+		return 0, errors.New("accelerometer does not report a linear_acceleration reading")
+	}
+	return movementsensor.TiltCompensatedHeading(mag, acceleration), nil
+}
+
+func (h *hmc5883l) AngularVelocity(ctx context.Context, extra map[string]interface{}) (spatialmath.AngularVelocity, error) {
+	return spatialmath.AngularVelocity{}, movementsensor.ErrMethodUnimplementedAngularVelocity
+}
+
+func (h *hmc5883l) LinearVelocity(ctx context.Context, extra map[string]interface{}) (r3.Vector, error) {
+	return r3.Vector{}, movementsensor.ErrMethodUnimplementedLinearVelocity
+}
+
+func (h *hmc5883l) Orientation(ctx context.Context, extra map[string]interface{}) (spatialmath.Orientation, error) {
+	return nil, movementsensor.ErrMethodUnimplementedOrientation
+}
+
+func (h *hmc5883l) Position(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+	return geo.NewPoint(0, 0), 0, movementsensor.ErrMethodUnimplementedPosition
+}
+
+func (h *hmc5883l) Accuracy(ctx context.Context, extra map[string]interface{}) (map[string]float32, error) {
+	return map[string]float32{}, movementsensor.ErrMethodUnimplementedAccuracy
+}
+
+func (h *hmc5883l) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	mag, err := h.magnetometer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	heading, err := h.CompassHeading(ctx, extra)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"magnetometer": mag, "compass": heading}, nil
+}
+
+func (h *hmc5883l) Properties(ctx context.Context, extra map[string]interface{}) (*movementsensor.Properties, error) {
+	return &movementsensor.Properties{
+		CompassHeadingSupported: true,
+	}, nil
+}
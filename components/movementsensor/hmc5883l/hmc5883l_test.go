@@ -0,0 +1,126 @@
+package hmc5883l
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
+)
+
+// fakeHandle is an HMC5883L that measures whatever field it is given.
+type fakeHandle struct {
+	board.I2CHandle
+	mu        sync.Mutex
+	field     r3.Vector
+	registers map[byte]byte
+}
+
+func encode(gauss float64) []byte {
+	v := int16(math.Round(gauss * lsbPerGauss))
+	return []byte{byte(uint16(v) >> 8), byte(v)}
+}
+
+func (h *fakeHandle) ReadBlockData(ctx context.Context, register byte, numBytes uint8) ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch register {
+	case idRegister:
+		return []byte("H43"), nil
+	case dataRegister:
+		data := append(encode(h.field.X), encode(h.field.Z)...)
+		return append(data, encode(h.field.Y)...), nil
+	default:
+		return nil, errors.New("unexpected register")
+	}
+}
+
+func (h *fakeHandle) WriteByteData(ctx context.Context, register, data byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.registers[register] = data
+	return nil
+}
+
+func (h *fakeHandle) Close() error {
+	return nil
+}
+
+type fakeAccelerometer struct {
+	movementsensor.MovementSensor
+	acceleration r3.Vector
+}
+
+func (a *fakeAccelerometer) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{"linear_acceleration": a.acceleration}, nil
+}
+
+func TestCompassHeading(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+	handle := &fakeHandle{registers: map[byte]byte{}}
+	bus := &inject.I2C{}
+	bus.OpenHandleFunc = func(addr byte) (board.I2CHandle, error) {
+		test.That(t, addr, test.ShouldEqual, address)
+		return handle, nil
+	}
+
+	sensor, err := newHMC5883L(ctx, bus, nil, movementsensor.MagnetometerCalibration{}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, handle.registers[modeRegister], test.ShouldEqual, continuousMode)
+	test.That(t, handle.registers[configRegisterB], test.ShouldEqual, defaultConfigB)
+
+	for _, heading := range []float64{0, 45, 90, 180, 270} {
+		rad := utils.DegToRad(heading)
+		handle.mu.Lock()
+		handle.field = r3.Vector{X: 0.3 * math.Cos(rad), Y: -0.3 * math.Sin(rad), Z: 0.4}
+		handle.mu.Unlock()
+		got, err := sensor.CompassHeading(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, math.Abs(got-heading), test.ShouldBeLessThan, 0.5)
+	}
+
+	t.Run("tilt compensation", func(t *testing.T) {
+		// pitched up by 30 degrees while facing east, so part of the downwards field shows up on x
+		pitch := utils.DegToRad(30)
+		handle.mu.Lock()
+		handle.field = r3.Vector{X: -0.4 * math.Sin(pitch), Y: -0.3, Z: 0.4 * math.Cos(pitch)}
+		handle.mu.Unlock()
+		level, err := sensor.CompassHeading(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, math.Abs(level-90), test.ShouldBeGreaterThan, 5)
+
+		accelerometer := &fakeAccelerometer{acceleration: r3.Vector{X: -9806.65 * math.Sin(pitch), Z: 9806.65 * math.Cos(pitch)}}
+		tilted, err := newHMC5883L(ctx, bus, accelerometer, movementsensor.MagnetometerCalibration{}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		got, err := tilted.CompassHeading(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, math.Abs(got-90), test.ShouldBeLessThan, 0.5)
+	})
+
+	t.Run("wrong device", func(t *testing.T) {
+		other := &inject.I2C{}
+		other.OpenHandleFunc = func(addr byte) (board.I2CHandle, error) {
+			return &wrongDevice{}, nil
+		}
+		_, err := newHMC5883L(ctx, other, nil, movementsensor.MagnetometerCalibration{}, logger)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+type wrongDevice struct {
+	fakeHandle
+}
+
+func (w *wrongDevice) ReadBlockData(ctx context.Context, register byte, numBytes uint8) ([]byte, error) {
+	return []byte{0, 0, 0}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -17,7 +18,6 @@ import (
 	geo "github.com/kellydunn/golang-geo"
 	"go.viam.com/utils"
 
-	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/components/movementsensor"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/registry"
@@ -31,6 +31,9 @@ const model = "imu-wit"
 type AttrConfig struct {
 	Port     string `json:"serial_path"`
 	BaudRate int    `json:"serial_baud_rate,omitempty"`
+	// MagnetometerCalibration corrects the magnetometer readings, as returned by the
+	// finish_calibration command.
+	MagnetometerCalibration *movementsensor.MagnetometerCalibration `json:"magnetometer_calibration,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -69,12 +72,15 @@ type wit struct {
 	numBadReadings  uint32
 	lastError       error
 
+	calibration        movementsensor.MagnetometerCalibration
+	calibrating        bool
+	calibrationSamples []r3.Vector
+
 	mu sync.Mutex
 
 	cancelFunc              func()
 	activeBackgroundWorkers sync.WaitGroup
-	generic.Unimplemented
-	logger golog.Logger
+	logger                  golog.Logger
 }
 
 func (imu *wit) AngularVelocity(ctx context.Context, extra map[string]interface{}) (spatialmath.AngularVelocity, error) {
@@ -102,15 +108,54 @@ func (imu *wit) GetAcceleration(ctx context.Context) (r3.Vector, error) {
 	return imu.acceleration, imu.lastError
 }
 
-// GetMagnetometer returns magnetic field in gauss.
+// GetMagnetometer returns magnetic field in gauss, corrected by the magnetometer calibration.
 func (imu *wit) GetMagnetometer(ctx context.Context) (r3.Vector, error) {
 	imu.mu.Lock()
 	defer imu.mu.Unlock()
-	return imu.magnetometer, imu.lastError
+	return imu.calibration.Apply(imu.magnetometer), imu.lastError
 }
 
 func (imu *wit) CompassHeading(ctx context.Context, extra map[string]interface{}) (float64, error) {
-	return 0, movementsensor.ErrMethodUnimplementedCompassHeading
+	mag, err := imu.GetMagnetometer(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return movementsensor.MagnetometerHeading(mag), nil
+}
+
+// DoCommand supports calibrating the magnetometer. Send start_calibration, turn the robot
+// through at least a full circle, then send finish_calibration. The calibration is applied
+// right away and returned so that it can be saved as magnetometer_calibration in the config.
+func (imu *wit) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["command"]
+	if !ok {
+		return nil, errors.New("missing 'command' value")
+	}
+	imu.mu.Lock()
+	defer imu.mu.Unlock()
+	switch name {
+	case "start_calibration":
+		imu.calibrating = true
+		imu.calibrationSamples = nil
+		return map[string]interface{}{}, nil
+	case "finish_calibration":
+		if !imu.calibrating {
+			return nil, errors.New("calibration was not started")
+		}
+		imu.calibrating = false
+		cal, err := movementsensor.CalibrateMagnetometer(imu.calibrationSamples)
+		imu.calibrationSamples = nil
+		if err != nil {
+			return nil, err
+		}
+		imu.calibration = cal
+		return map[string]interface{}{
+			"offset": map[string]interface{}{"x": cal.Offset.X, "y": cal.Offset.Y, "z": cal.Offset.Z},
+			"scale":  map[string]interface{}{"x": cal.Scale.X, "y": cal.Scale.Y, "z": cal.Scale.Z},
+		}, nil
+	default:
+		return nil, fmt.Errorf("no such command: %s", name)
+	}
 }
 
 func (imu *wit) Position(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
@@ -146,6 +191,7 @@ func (imu *wit) Properties(ctx context.Context, extra map[string]interface{}) (*
 	return &movementsensor.Properties{
 		AngularVelocitySupported: true,
 		OrientationSupported:     true,
+		CompassHeadingSupported:  true,
 	}, nil
 }
 
@@ -175,6 +221,9 @@ func NewWit(
 
 	var i wit
 	i.logger = logger
+	if conf.MagnetometerCalibration != nil {
+		i.calibration = *conf.MagnetometerCalibration
+	}
 	logger.Debugf("initializing wit serial connection with parameters: %+v", options)
 	port, err := slib.Open(options)
 	if err != nil {
@@ -277,6 +326,9 @@ func (imu *wit) parseWIT(line string) error {
 		imu.magnetometer.X = scalemag(line[1], line[2], 1) // converts to gauss
 		imu.magnetometer.Y = scalemag(line[3], line[4], 1)
 		imu.magnetometer.Z = scalemag(line[5], line[6], 1)
+		if imu.calibrating {
+			imu.calibrationSamples = append(imu.calibrationSamples, imu.magnetometer)
+		}
 	}
 
 	return nil
@@ -0,0 +1,158 @@
+package posefusion
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// An ekf is an extended Kalman filter estimating the pose of a base driving on the ground. Its
+// state is how far east and north the base is in meters, and its compass heading in radians,
+// clockwise from north.
+type ekf struct {
+	x *mat.VecDense
+	p *mat.Dense
+}
+
+const (
+	east = iota
+	north
+	heading
+)
+
+// newEKF returns a filter certain that the base is at the origin, heading north.
+func newEKF() *ekf {
+	return &ekf{x: mat.NewVecDense(3, nil), p: mat.NewDense(3, 3, nil)}
+}
+
+// wrapAngle returns the angle in radians in [-pi, pi).
+func wrapAngle(a float64) float64 {
+	return math.Mod(math.Mod(a+math.Pi, 2*math.Pi)+2*math.Pi, 2*math.Pi) - math.Pi
+}
+
+// predict moves the estimate forward by dt seconds of driving at speed meters per second while
+// turning at turnRate radians per second clockwise. The uncertainty grows by the process noise q.
+func (f *ekf) predict(speed, turnRate, dt float64, q mat.Matrix) {
+	theta := f.x.AtVec(heading)
+	f.x.SetVec(east, f.x.AtVec(east)+speed*math.Sin(theta)*dt)
+	f.x.SetVec(north, f.x.AtVec(north)+speed*math.Cos(theta)*dt)
+	f.x.SetVec(heading, wrapAngle(theta+turnRate*dt))
+
+	jacobian := mat.NewDense(3, 3, []float64{
+		1, 0, speed * math.Cos(theta) * dt,
+		0, 1, -speed * math.Sin(theta) * dt,
+		0, 0, 1,
+	})
+	var p mat.Dense
+	p.Product(jacobian, f.p, jacobian.T())
+	p.Add(&p, q)
+	f.p = &p
+}
+
+// odometryNoise is the process noise of driving for dt seconds with speeds measured with the
+// standard deviations speedStdDev in meters per second and turnStdDev in radians per second.
+func (f *ekf) odometryNoise(dt, speedStdDev, turnStdDev float64) mat.Matrix {
+	theta := f.x.AtVec(heading)
+	g := mat.NewDense(3, 2, []float64{
+		math.Sin(theta) * dt, 0,
+		math.Cos(theta) * dt, 0,
+		0, dt,
+	})
+	var q mat.Dense
+	q.Product(g, mat.NewDiagDense(2, []float64{speedStdDev * speedStdDev, turnStdDev * turnStdDev}), g.T())
+	return &q
+}
+
+// unknownMotionNoise is the process noise of moving for dt seconds in any direction at speeds
+// with the standard deviations speedStdDev in meters per second and turnStdDev in radians per
+// second, for when there is no odometry.
+func unknownMotionNoise(dt, speedStdDev, turnStdDev float64) mat.Matrix {
+	pos := speedStdDev * speedStdDev * dt * dt
+	return mat.NewDiagDense(3, []float64{pos, pos, turnStdDev * turnStdDev * dt * dt})
+}
+
+// update corrects the estimate with the measurement z of the state through h, with the
+// measurement noise covariance r. Innovations of headings are wrapped around.
+func (f *ekf) update(z *mat.VecDense, h *mat.Dense, r mat.Matrix) {
+	var innovation mat.VecDense
+	innovation.MulVec(h, f.x)
+	innovation.SubVec(z, &innovation)
+	for i := 0; i < innovation.Len(); i++ {
+		if h.At(i, heading) != 0 {
+			innovation.SetVec(i, wrapAngle(innovation.AtVec(i)))
+		}
+	}
+
+	var s, sInv, gain mat.Dense
+	s.Product(h, f.p, h.T())
+	s.Add(&s, r)
+	if err := sInv.Inverse(&s); err != nil {
+		return
+	}
+	gain.Product(f.p, h.T(), &sInv)
+
+	var correction mat.VecDense
+	correction.MulVec(&gain, &innovation)
+	f.x.AddVec(f.x, &correction)
+	f.x.SetVec(heading, wrapAngle(f.x.AtVec(heading)))
+
+	var kh, p mat.Dense
+	kh.Mul(&gain, h)
+	kh.Sub(eye(3), &kh)
+	p.Mul(&kh, f.p)
+	f.p = &p
+}
+
+// updatePosition corrects the estimate with a measured position, with the standard deviation
+// stdDev in meters in each direction.
+func (f *ekf) updatePosition(eastM, northM, stdDev float64) {
+	f.update(
+		mat.NewVecDense(2, []float64{eastM, northM}),
+		mat.NewDense(2, 3, []float64{1, 0, 0, 0, 1, 0}),
+		mat.NewDiagDense(2, []float64{stdDev * stdDev, stdDev * stdDev}),
+	)
+}
+
+// updateHeading corrects the estimate with a measured heading in radians, with the standard
+// deviation stdDev in radians.
+func (f *ekf) updateHeading(theta, stdDev float64) {
+	f.update(
+		mat.NewVecDense(1, []float64{theta}),
+		mat.NewDense(1, 3, []float64{0, 0, 1}),
+		mat.NewDiagDense(1, []float64{stdDev * stdDev}),
+	)
+}
+
+// resetPosition sets the position, with the standard deviation stdDev in meters, forgetting any
+// correlation of the position with the heading.
+func (f *ekf) resetPosition(eastM, northM, stdDev float64) {
+	f.x.SetVec(east, eastM)
+	f.x.SetVec(north, northM)
+	for i := 0; i < 3; i++ {
+		for _, j := range []int{east, north} {
+			f.p.Set(i, j, 0)
+			f.p.Set(j, i, 0)
+		}
+	}
+	f.p.Set(east, east, stdDev*stdDev)
+	f.p.Set(north, north, stdDev*stdDev)
+}
+
+// resetHeading sets the heading in radians, with the standard deviation stdDev in radians,
+// forgetting any correlation of the heading with the position.
+func (f *ekf) resetHeading(theta, stdDev float64) {
+	f.x.SetVec(heading, wrapAngle(theta))
+	for i := 0; i < 3; i++ {
+		f.p.Set(i, heading, 0)
+		f.p.Set(heading, i, 0)
+	}
+	f.p.Set(heading, heading, stdDev*stdDev)
+}
+
+func eye(n int) *mat.Dense {
+	m := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, 1)
+	}
+	return m
+}
@@ -0,0 +1,450 @@
+// Package posefusion implements a movementsensor that fuses wheel odometry, a compass and a GPS
+// into an estimate of the pose of a base, with its uncertainty, using an extended Kalman filter.
+package posefusion
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/spatialmath"
+	rutils "go.viam.com/rdk/utils"
+)
+
+const (
+	modelName = "pose-fusion"
+
+	defaultOdometrySpeedStdDevMMPerSec  = 50.
+	defaultOdometryTurnStdDevDegsPerSec = 5.
+	defaultCompassStdDevDegs            = 5.
+	defaultGPSStdDevMM                  = 2500.
+	defaultUpdateInterval               = 100 * time.Millisecond
+
+	// without odometry the base is assumed to move at up to about these speeds in any direction
+	unknownSpeedStdDevMMPerSec  = 1000.
+	unknownTurnStdDevDegsPerSec = 45.
+
+	earthRadiusM = 6371000.
+)
+
+// The sources of a pose fusion movement sensor, as named to SetSourceEnabled.
+const (
+	SourceOdometry = "odometry"
+	SourceCompass  = "compass"
+	SourceGPS      = "gps"
+)
+
+// AttrConfig is used to configure a pose fusion movement sensor. Every source is optional, but
+// there has to be at least one.
+type AttrConfig struct {
+	// Odometry is a movement sensor reporting the linear and angular velocity of the base, like
+	// its wheel odometry.
+	Odometry string `json:"odometry,omitempty"`
+	// Compass is a movement sensor reporting the compass heading of the base.
+	Compass string `json:"compass,omitempty"`
+	// GPS is a movement sensor reporting the position of the base.
+	GPS string `json:"gps,omitempty"`
+
+	// The standard deviations of the noise of each source.
+	OdometrySpeedStdDevMMPerSec  float64 `json:"odometry_speed_std_dev_mm_per_sec,omitempty"`
+	OdometryTurnStdDevDegsPerSec float64 `json:"odometry_turn_std_dev_degs_per_sec,omitempty"`
+	CompassStdDevDegs            float64 `json:"compass_std_dev_degs,omitempty"`
+	GPSStdDevMM                  float64 `json:"gps_std_dev_mm,omitempty"`
+
+	// UpdateIntervalMs is how often the sources are read, 100ms by default.
+	UpdateIntervalMs int `json:"update_interval_ms,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid, and then returns the list of things we
+// depend on.
+func (cfg *AttrConfig) Validate(path string) ([]string, error) {
+	var deps []string
+	for _, dep := range []string{cfg.Odometry, cfg.Compass, cfg.GPS} {
+		if dep != "" {
+			deps = append(deps, dep)
+		}
+	}
+	if len(deps) == 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("needs at least one of odometry, compass and gps"))
+	}
+	for name, v := range map[string]float64{
+		"odometry_speed_std_dev_mm_per_sec":  cfg.OdometrySpeedStdDevMMPerSec,
+		"odometry_turn_std_dev_degs_per_sec": cfg.OdometryTurnStdDevDegsPerSec,
+		"compass_std_dev_degs":               cfg.CompassStdDevDegs,
+		"gps_std_dev_mm":                     cfg.GPSStdDevMM,
+		"update_interval_ms":                 float64(cfg.UpdateIntervalMs),
+	} {
+		if v < 0 {
+			return nil, utils.NewConfigValidationError(path, errors.Errorf("%s cannot be negative", name))
+		}
+	}
+	return deps, nil
+}
+
+func init() {
+	registry.RegisterComponent(movementsensor.Subtype, modelName, registry.Component{
+		Constructor: func(
+			ctx context.Context,
+			deps registry.Dependencies,
+			cfg config.Component,
+			logger golog.Logger,
+		) (interface{}, error) {
+			attrs, ok := cfg.ConvertedAttributes.(*AttrConfig)
+			if !ok {
+				return nil, rutils.NewUnexpectedTypeError(attrs, cfg.ConvertedAttributes)
+			}
+			sources := map[string]movementsensor.MovementSensor{}
+			for source, name := range map[string]string{
+				SourceOdometry: attrs.Odometry,
+				SourceCompass:  attrs.Compass,
+				SourceGPS:      attrs.GPS,
+			} {
+				if name == "" {
+					continue
+				}
+				ms, err := movementsensor.FromDependencies(deps, name)
+				if err != nil {
+					return nil, err
+				}
+				sources[source] = ms
+			}
+			pf := newPoseFusion(sources, attrs, logger)
+			pf.start()
+			return pf, nil
+		},
+	})
+
+	config.RegisterComponentAttributeMapConverter(movementsensor.SubtypeName, modelName,
+		func(attributes config.AttributeMap) (interface{}, error) {
+			var attr AttrConfig
+			return config.TransformAttributeMapToStruct(&attr, attributes)
+		},
+		&AttrConfig{})
+}
+
+// A PoseEstimate is a fused estimate of where a base is and which way it is heading.
+type PoseEstimate struct {
+	// Position is where the base is, nil until the GPS has had a fix.
+	Position *geo.Point
+	// EastMM and NorthMM are how far the base is from where the GPS first had a fix, or from
+	// where the base started when there is no GPS.
+	EastMM, NorthMM float64
+	// Heading is the compass heading in degrees, or the heading relative to where the base
+	// started heading when there is no compass.
+	Heading float64
+	// Covariance is the covariance of east and north in mm and heading in degrees, in that order.
+	Covariance [3][3]float64
+}
+
+type poseFusion struct {
+	sources  map[string]movementsensor.MovementSensor
+	interval time.Duration
+	logger   golog.Logger
+
+	// standard deviations in meters, radians and seconds
+	speedStdDev, turnStdDev, compassStdDev, gpsStdDev float64
+
+	mu           sync.Mutex
+	enabled      map[string]bool
+	filter       *ekf
+	headingFixed bool
+	origin       *geo.Point
+	lastStep     time.Time
+
+	cancel                  func()
+	activeBackgroundWorkers sync.WaitGroup
+}
+
+func orDefault(v, defaultV float64) float64 {
+	if v == 0 {
+		return defaultV
+	}
+	return v
+}
+
+func newPoseFusion(sources map[string]movementsensor.MovementSensor, attrs *AttrConfig, logger golog.Logger) *poseFusion {
+	pf := &poseFusion{
+		sources:       sources,
+		interval:      time.Duration(attrs.UpdateIntervalMs) * time.Millisecond,
+		logger:        logger,
+		speedStdDev:   orDefault(attrs.OdometrySpeedStdDevMMPerSec, defaultOdometrySpeedStdDevMMPerSec) / 1000,
+		turnStdDev:    rutils.DegToRad(orDefault(attrs.OdometryTurnStdDevDegsPerSec, defaultOdometryTurnStdDevDegsPerSec)),
+		compassStdDev: rutils.DegToRad(orDefault(attrs.CompassStdDevDegs, defaultCompassStdDevDegs)),
+		gpsStdDev:     orDefault(attrs.GPSStdDevMM, defaultGPSStdDevMM) / 1000,
+		enabled:       map[string]bool{},
+		filter:        newEKF(),
+	}
+	if pf.interval == 0 {
+		pf.interval = defaultUpdateInterval
+	}
+	for source := range sources {
+		pf.enabled[source] = true
+	}
+	return pf
+}
+
+// start reads the sources every interval until Close is called.
+func (pf *poseFusion) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	pf.cancel = cancel
+	pf.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		for utils.SelectContextOrWait(ctx, pf.interval) {
+			pf.step(ctx, time.Now())
+		}
+	}, pf.activeBackgroundWorkers.Done)
+}
+
+// step reads the enabled sources and folds them into the estimate as of now. Sources that cannot
+// be read are skipped until the next step.
+func (pf *poseFusion) step(ctx context.Context, now time.Time) {
+	pf.mu.Lock()
+	enabled := make(map[string]bool, len(pf.enabled))
+	for source, on := range pf.enabled {
+		enabled[source] = on
+	}
+	pf.mu.Unlock()
+
+	var speed, turnRate float64
+	haveOdometry := false
+	if enabled[SourceOdometry] {
+		odometry := pf.sources[SourceOdometry]
+		linear, linErr := odometry.LinearVelocity(ctx, nil)
+		angular, angErr := odometry.AngularVelocity(ctx, nil)
+		if linErr != nil || angErr != nil {
+			pf.logger.Debugw("odometry unavailable", "linear_error", linErr, "angular_error", angErr)
+		} else {
+			haveOdometry = true
+			speed = linear.Y / 1000
+			// turning counter-clockwise about Z lowers the compass heading
+			turnRate = -rutils.DegToRad(angular.Z)
+		}
+	}
+	compassHeading, compassErr := 0., errors.New("disabled")
+	if enabled[SourceCompass] {
+		if compassHeading, compassErr = pf.sources[SourceCompass].CompassHeading(ctx, nil); compassErr != nil {
+			pf.logger.Debugw("compass unavailable", "error", compassErr)
+		}
+	}
+	var fix *geo.Point
+	if enabled[SourceGPS] {
+		var err error
+		if fix, _, err = pf.sources[SourceGPS].Position(ctx, nil); err != nil {
+			pf.logger.Debugw("gps unavailable", "error", err)
+			fix = nil
+		}
+	}
+
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	if !pf.lastStep.IsZero() {
+		dt := now.Sub(pf.lastStep).Seconds()
+		if haveOdometry {
+			pf.filter.predict(speed, turnRate, dt, pf.filter.odometryNoise(dt, pf.speedStdDev, pf.turnStdDev))
+		} else {
+			pf.filter.predict(0, 0, dt, unknownMotionNoise(
+				dt, unknownSpeedStdDevMMPerSec/1000, rutils.DegToRad(unknownTurnStdDevDegsPerSec)))
+		}
+	}
+	pf.lastStep = now
+
+	if compassErr == nil {
+		if pf.headingFixed {
+			pf.filter.updateHeading(rutils.DegToRad(compassHeading), pf.compassStdDev)
+		} else {
+			pf.filter.resetHeading(rutils.DegToRad(compassHeading), pf.compassStdDev)
+			pf.headingFixed = true
+		}
+	}
+	if fix != nil {
+		if pf.origin == nil {
+			pf.origin = fix
+			pf.filter.resetPosition(0, 0, pf.gpsStdDev)
+		} else {
+			eastM, northM := toLocal(pf.origin, fix)
+			pf.filter.updatePosition(eastM, northM, pf.gpsStdDev)
+		}
+	}
+}
+
+// toLocal returns how many meters east and north of the origin the point is.
+func toLocal(origin, p *geo.Point) (float64, float64) {
+	eastM := rutils.DegToRad(p.Lng()-origin.Lng()) * earthRadiusM * math.Cos(rutils.DegToRad(origin.Lat()))
+	northM := rutils.DegToRad(p.Lat()-origin.Lat()) * earthRadiusM
+	return eastM, northM
+}
+
+// fromLocal returns the point the given meters east and north of the origin.
+func fromLocal(origin *geo.Point, eastM, northM float64) *geo.Point {
+	return geo.NewPoint(
+		origin.Lat()+rutils.RadToDeg(northM/earthRadiusM),
+		origin.Lng()+rutils.RadToDeg(eastM/(earthRadiusM*math.Cos(rutils.DegToRad(origin.Lat())))),
+	)
+}
+
+// PoseEstimate returns the current fused estimate of the pose of the base.
+func (pf *poseFusion) PoseEstimate(ctx context.Context) (PoseEstimate, error) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	scale := [3]float64{1000, 1000, 180 / math.Pi}
+	estimate := PoseEstimate{
+		EastMM:  pf.filter.x.AtVec(east) * scale[east],
+		NorthMM: pf.filter.x.AtVec(north) * scale[north],
+		Heading: math.Mod(rutils.RadToDeg(pf.filter.x.AtVec(heading))+360, 360),
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			estimate.Covariance[i][j] = pf.filter.p.At(i, j) * scale[i] * scale[j]
+		}
+	}
+	if pf.origin != nil {
+		estimate.Position = fromLocal(pf.origin, pf.filter.x.AtVec(east), pf.filter.x.AtVec(north))
+	}
+	return estimate, nil
+}
+
+// SetSourceEnabled stops or resumes using one of the configured sources, named SourceOdometry,
+// SourceCompass or SourceGPS, for example while it is known to be unreliable.
+func (pf *poseFusion) SetSourceEnabled(source string, enabled bool) error {
+	if _, ok := pf.sources[source]; !ok {
+		return errors.Errorf("no %q source configured", source)
+	}
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	pf.enabled[source] = enabled
+	return nil
+}
+
+// DoCommand supports "get_pose_estimate", which returns the PoseEstimate as "east_mm",
+// "north_mm", "heading_deg", the row-major "covariance" and, once the GPS has had a fix, "lat" and
+// "lng". "enable_source" and "disable_source" run SetSourceEnabled for the given "source".
+func (pf *poseFusion) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["command"]
+	if !ok {
+		return nil, errors.New("missing 'command' value")
+	}
+	switch name {
+	case "get_pose_estimate":
+		estimate, err := pf.PoseEstimate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		covariance := make([]interface{}, 0, 9)
+		for _, row := range estimate.Covariance {
+			for _, v := range row {
+				covariance = append(covariance, v)
+			}
+		}
+		resp := map[string]interface{}{
+			"east_mm":     estimate.EastMM,
+			"north_mm":    estimate.NorthMM,
+			"heading_deg": estimate.Heading,
+			"covariance":  covariance,
+		}
+		if estimate.Position != nil {
+			resp["lat"] = estimate.Position.Lat()
+			resp["lng"] = estimate.Position.Lng()
+		}
+		return resp, nil
+	case "enable_source", "disable_source":
+		source, ok := cmd["source"].(string)
+		if !ok {
+			return nil, errors.Errorf("%s needs a 'source' value", name)
+		}
+		if err := pf.SetSourceEnabled(source, name == "enable_source"); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{}, nil
+	default:
+		return nil, fmt.Errorf("no such command: %s", name)
+	}
+}
+
+func (pf *poseFusion) Position(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+	if _, ok := pf.sources[SourceGPS]; !ok {
+		return geo.NewPoint(0, 0), 0, movementsensor.ErrMethodUnimplementedPosition
+	}
+	estimate, err := pf.PoseEstimate(ctx)
+	if err != nil {
+		return geo.NewPoint(0, 0), 0, err
+	}
+	if estimate.Position == nil {
+		return geo.NewPoint(0, 0), 0, errors.New("gps has not had a fix yet")
+	}
+	return estimate.Position, 0, nil
+}
+
+func (pf *poseFusion) CompassHeading(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	estimate, err := pf.PoseEstimate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return estimate.Heading, nil
+}
+
+func (pf *poseFusion) LinearVelocity(ctx context.Context, extra map[string]interface{}) (r3.Vector, error) {
+	odometry, ok := pf.sources[SourceOdometry]
+	if !ok {
+		return r3.Vector{}, movementsensor.ErrMethodUnimplementedLinearVelocity
+	}
+	return odometry.LinearVelocity(ctx, extra)
+}
+
+func (pf *poseFusion) AngularVelocity(ctx context.Context, extra map[string]interface{}) (spatialmath.AngularVelocity, error) {
+	odometry, ok := pf.sources[SourceOdometry]
+	if !ok {
+		return spatialmath.AngularVelocity{}, movementsensor.ErrMethodUnimplementedAngularVelocity
+	}
+	return odometry.AngularVelocity(ctx, extra)
+}
+
+func (pf *poseFusion) Orientation(ctx context.Context, extra map[string]interface{}) (spatialmath.Orientation, error) {
+	return nil, movementsensor.ErrMethodUnimplementedOrientation
+}
+
+// Accuracy returns the standard deviations of the estimate of the position in mm, as "east" and
+// "north".
+func (pf *poseFusion) Accuracy(ctx context.Context, extra map[string]interface{}) (map[string]float32, error) {
+	estimate, err := pf.PoseEstimate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]float32{
+		"east":  float32(math.Sqrt(estimate.Covariance[east][east])),
+		"north": float32(math.Sqrt(estimate.Covariance[north][north])),
+	}, nil
+}
+
+func (pf *poseFusion) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	return movementsensor.Readings(ctx, pf, extra)
+}
+
+func (pf *poseFusion) Properties(ctx context.Context, extra map[string]interface{}) (*movementsensor.Properties, error) {
+	_, haveOdometry := pf.sources[SourceOdometry]
+	_, haveGPS := pf.sources[SourceGPS]
+	return &movementsensor.Properties{
+		PositionSupported:        haveGPS,
+		CompassHeadingSupported:  true,
+		LinearVelocitySupported:  haveOdometry,
+		AngularVelocitySupported: haveOdometry,
+	}, nil
+}
+
+// Close stops reading the sources.
+func (pf *poseFusion) Close() {
+	if pf.cancel != nil {
+		pf.cancel()
+	}
+	pf.activeBackgroundWorkers.Wait()
+}
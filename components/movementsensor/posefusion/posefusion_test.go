@@ -0,0 +1,236 @@
+package posefusion
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+	rutils "go.viam.com/rdk/utils"
+)
+
+func TestValidate(t *testing.T) {
+	cfg := AttrConfig{}
+	_, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "needs at least one of odometry, compass and gps")
+
+	cfg = AttrConfig{Odometry: "wheels", GPS: "gps", GPSStdDevMM: -1}
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "gps_std_dev_mm cannot be negative")
+
+	cfg = AttrConfig{Odometry: "wheels", GPS: "gps"}
+	deps, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"wheels", "gps"})
+}
+
+// A drive is a base driving around on a field, and noisy sensors measuring it.
+type drive struct {
+	rng    *rand.Rand
+	origin *geo.Point
+
+	// the true pose, in meters and radians clockwise from north
+	eastM, northM, heading float64
+	speed, turnRate        float64
+
+	odometry, compass, gps *inject.MovementSensor
+}
+
+func newDrive(seed int64) *drive {
+	d := &drive{rng: rand.New(rand.NewSource(seed)), origin: geo.NewPoint(40.7, -74)}
+	d.odometry = &inject.MovementSensor{}
+	d.odometry.LinearVelocityFunc = func(ctx context.Context, extra map[string]interface{}) (r3.Vector, error) {
+		// the wheels slip a little
+		return r3.Vector{Y: (d.speed*0.95 + d.rng.NormFloat64()*0.05) * 1000}, nil
+	}
+	d.odometry.AngularVelocityFunc = func(ctx context.Context, extra map[string]interface{}) (spatialmath.AngularVelocity, error) {
+		// one side slips more than the other
+		return spatialmath.AngularVelocity{Z: -rutils.RadToDeg(d.turnRate + 0.01 + d.rng.NormFloat64()*0.05)}, nil
+	}
+	d.compass = &inject.MovementSensor{}
+	d.compass.CompassHeadingFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+		return d.measuredHeading(), nil
+	}
+	d.gps = &inject.MovementSensor{}
+	d.gps.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+		return d.measuredPosition(), 0, nil
+	}
+	return d
+}
+
+func (d *drive) measuredHeading() float64 {
+	return math.Mod(rutils.RadToDeg(d.heading+d.rng.NormFloat64()*rutils.DegToRad(10))+720, 360)
+}
+
+func (d *drive) measuredPosition() *geo.Point {
+	return fromLocal(d.origin, d.eastM+d.rng.NormFloat64()*3, d.northM+d.rng.NormFloat64()*3)
+}
+
+// move drives the base for dt seconds, weaving left and right.
+func (d *drive) move(at, dt float64) {
+	d.speed = 1
+	d.turnRate = 0.3 * math.Sin(at/5)
+	d.eastM += d.speed * math.Sin(d.heading) * dt
+	d.northM += d.speed * math.Cos(d.heading) * dt
+	d.heading = wrapAngle(d.heading + d.turnRate*dt)
+}
+
+func (d *drive) positionError(p *geo.Point) float64 {
+	eastM, northM := toLocal(d.origin, p)
+	return math.Hypot(eastM-d.eastM, northM-d.northM)
+}
+
+func (d *drive) headingError(headingDeg float64) float64 {
+	return math.Abs(rutils.RadToDeg(wrapAngle(rutils.DegToRad(headingDeg) - d.heading)))
+}
+
+func TestFusionTracksTruth(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	d := newDrive(7)
+	attrs := &AttrConfig{
+		OdometrySpeedStdDevMMPerSec:  100,
+		OdometryTurnStdDevDegsPerSec: 5,
+		CompassStdDevDegs:            10,
+		GPSStdDevMM:                  3000,
+	}
+	fused := newPoseFusion(map[string]movementsensor.MovementSensor{
+		SourceOdometry: d.odometry,
+		SourceCompass:  d.compass,
+		SourceGPS:      d.gps,
+	}, attrs, logger)
+	deadReckoning := newPoseFusion(map[string]movementsensor.MovementSensor{SourceOdometry: d.odometry}, attrs, logger)
+
+	var fusedPosition, fusedHeading, gpsPosition, compassHeading, deadReckoningPosition, deadReckoningHeading float64
+	start := time.Now()
+	const dt, steps = 0.1, 1200
+	for i := 0; i < steps; i++ {
+		now := start.Add(time.Duration(float64(i) * dt * float64(time.Second)))
+		if i > 0 {
+			d.move(float64(i)*dt, dt)
+		}
+		fused.step(ctx, now)
+		deadReckoning.step(ctx, now)
+
+		estimate, err := fused.PoseEstimate(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		fusedPosition += math.Pow(d.positionError(estimate.Position), 2)
+		fusedHeading += math.Pow(d.headingError(estimate.Heading), 2)
+
+		estimate, err = deadReckoning.PoseEstimate(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, estimate.Position, test.ShouldBeNil)
+		deadReckoningPosition += math.Pow(math.Hypot(estimate.EastMM/1000-d.eastM, estimate.NorthMM/1000-d.northM), 2)
+		deadReckoningHeading += math.Pow(d.headingError(estimate.Heading), 2)
+
+		gpsPosition += math.Pow(d.positionError(d.measuredPosition()), 2)
+		compassHeading += math.Pow(d.headingError(d.measuredHeading()), 2)
+	}
+	rms := func(sum float64) float64 { return math.Sqrt(sum / steps) }
+	t.Logf("position error: fused %.2fm, gps %.2fm, dead reckoning %.2fm",
+		rms(fusedPosition), rms(gpsPosition), rms(deadReckoningPosition))
+	t.Logf("heading error: fused %.2f°, compass %.2f°, dead reckoning %.2f°",
+		rms(fusedHeading), rms(compassHeading), rms(deadReckoningHeading))
+	test.That(t, rms(fusedPosition), test.ShouldBeLessThan, rms(gpsPosition))
+	test.That(t, rms(fusedPosition), test.ShouldBeLessThan, rms(deadReckoningPosition))
+	test.That(t, rms(fusedHeading), test.ShouldBeLessThan, rms(compassHeading))
+	test.That(t, rms(fusedHeading), test.ShouldBeLessThan, rms(deadReckoningHeading))
+
+	// the uncertainty reported is about the error actually made
+	estimate, err := fused.PoseEstimate(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, math.Sqrt(estimate.Covariance[east][east])/1000, test.ShouldBeBetween, rms(fusedPosition)/4, rms(fusedPosition)*2)
+}
+
+func TestSources(t *testing.T) {
+	ctx := context.Background()
+	d := newDrive(1)
+	pf := newPoseFusion(map[string]movementsensor.MovementSensor{
+		SourceOdometry: d.odometry,
+		SourceGPS:      d.gps,
+	}, &AttrConfig{}, golog.NewTestLogger(t))
+
+	_, _, err := pf.Position(ctx, nil)
+	test.That(t, err, test.ShouldBeError, "gps has not had a fix yet")
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		d.move(float64(i)*0.1, 0.1)
+		pf.step(ctx, start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	_, _, err = pf.Position(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	accuracy, err := pf.Accuracy(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, accuracy["east"], test.ShouldBeLessThan, 2500)
+
+	props, err := pf.Properties(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, props, test.ShouldResemble, &movementsensor.Properties{
+		PositionSupported:        true,
+		CompassHeadingSupported:  true,
+		LinearVelocitySupported:  true,
+		AngularVelocitySupported: true,
+	})
+
+	t.Run("disabled sources are not read", func(t *testing.T) {
+		_, err := pf.DoCommand(ctx, map[string]interface{}{"command": "disable_source", "source": SourceGPS})
+		test.That(t, err, test.ShouldBeNil)
+		d.gps.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+			t.Fatal("disabled gps was read")
+			return nil, 0, nil
+		}
+		for i := 10; i < 110; i++ {
+			pf.step(ctx, start.Add(time.Duration(i)*100*time.Millisecond))
+		}
+		// only dead reckoning is left, so the position gets less certain
+		uncertain, err := pf.Accuracy(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, uncertain["east"], test.ShouldBeGreaterThan, accuracy["east"])
+
+		d.gps.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+			return d.measuredPosition(), 0, nil
+		}
+		test.That(t, pf.SetSourceEnabled(SourceGPS, true), test.ShouldBeNil)
+		pf.step(ctx, start.Add(110*100*time.Millisecond))
+		certain, err := pf.Accuracy(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, certain["east"], test.ShouldBeLessThan, uncertain["east"])
+	})
+
+	t.Run("unconfigured sources", func(t *testing.T) {
+		test.That(t, pf.SetSourceEnabled(SourceCompass, false), test.ShouldBeError, `no "compass" source configured`)
+		_, err := pf.DoCommand(ctx, map[string]interface{}{"command": "enable_source", "source": "sonar"})
+		test.That(t, err, test.ShouldBeError, `no "sonar" source configured`)
+		_, err = pf.DoCommand(ctx, map[string]interface{}{"command": "enable_source"})
+		test.That(t, err, test.ShouldBeError, "enable_source needs a 'source' value")
+	})
+
+	t.Run("get_pose_estimate", func(t *testing.T) {
+		resp, err := pf.DoCommand(ctx, map[string]interface{}{"command": "get_pose_estimate"})
+		test.That(t, err, test.ShouldBeNil)
+		estimate, err := pf.PoseEstimate(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp["east_mm"], test.ShouldEqual, estimate.EastMM)
+		test.That(t, resp["north_mm"], test.ShouldEqual, estimate.NorthMM)
+		test.That(t, resp["heading_deg"], test.ShouldEqual, estimate.Heading)
+		test.That(t, resp["lat"], test.ShouldEqual, estimate.Position.Lat())
+		test.That(t, resp["lng"], test.ShouldEqual, estimate.Position.Lng())
+		test.That(t, resp["covariance"], test.ShouldHaveLength, 9)
+		test.That(t, resp["covariance"].([]interface{})[4], test.ShouldEqual, estimate.Covariance[north][north])
+
+		_, err = pf.DoCommand(ctx, map[string]interface{}{"command": "fly"})
+		test.That(t, err, test.ShouldBeError, "no such command: fly")
+	})
+}
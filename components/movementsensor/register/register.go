@@ -8,7 +8,10 @@ import (
 	_ "go.viam.com/rdk/components/movementsensor/fake"
 	_ "go.viam.com/rdk/components/movementsensor/gpsnmea"
 	_ "go.viam.com/rdk/components/movementsensor/gpsrtk"
+	_ "go.viam.com/rdk/components/movementsensor/headingfusion"
+	_ "go.viam.com/rdk/components/movementsensor/hmc5883l"
 	_ "go.viam.com/rdk/components/movementsensor/imuvectornav"
 	_ "go.viam.com/rdk/components/movementsensor/imuwit"
 	_ "go.viam.com/rdk/components/movementsensor/mpu6050"
+	_ "go.viam.com/rdk/components/movementsensor/posefusion"
 )
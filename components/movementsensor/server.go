@@ -4,12 +4,12 @@ import (
 	"context"
 
 	"github.com/golang/geo/r3"
-	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/movementsensor/v1"
 
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
 type subtypeServer struct {
@@ -25,11 +25,11 @@ func NewServer(s subtype.Service) pb.MovementSensorServiceServer {
 func (s *subtypeServer) getMovementSensor(name string) (MovementSensor, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no MovementSensor with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("MovementSensor", name)
 	}
 	ms, ok := resource.(MovementSensor)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a MovementSensor", name)
+		return nil, utils.NewWrongTypeByNameError("a MovementSensor", name)
 	}
 	return ms, nil
 }
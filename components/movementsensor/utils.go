@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math"
 
+	"github.com/golang/geo/r3"
 	geo "github.com/kellydunn/golang-geo"
 
 	"go.viam.com/rdk/utils"
@@ -67,3 +68,82 @@ var (
 	// ErrMethodUnimplementedProperties returns error if the Properties method is unimplemented.
 	ErrMethodUnimplementedProperties = errors.New("Properties Unimplemented")
 )
+
+// MagnetometerCalibration corrects the readings of a magnetometer for hard-iron effects, which
+// shift them by a constant offset, and for the differing sensitivity of each of its axes.
+type MagnetometerCalibration struct {
+	Offset r3.Vector `json:"offset"`
+	Scale  r3.Vector `json:"scale"`
+}
+
+// minAxisCoverage is the fraction of the radius of the x and y readings that the z readings must
+// span for z to be calibrated too.
+const minAxisCoverage = 0.5
+
+// CalibrateMagnetometer computes the calibration of a magnetometer from readings taken while it
+// was turned through a full circle. The offset is the center of the readings and the scale
+// stretches each axis to the average radius of the readings. The sensor is usually turned about
+// its z axis, which then barely changes; unless the readings span z about as well as x and y, z is
+// left uncalibrated rather than scaled by its noise.
+func CalibrateMagnetometer(samples []r3.Vector) (MagnetometerCalibration, error) {
+	if len(samples) < 2 {
+		return MagnetometerCalibration{}, errors.New("need at least 2 magnetometer readings to calibrate")
+	}
+	minimum, maximum := samples[0], samples[0]
+	for _, s := range samples[1:] {
+		minimum = r3.Vector{X: math.Min(minimum.X, s.X), Y: math.Min(minimum.Y, s.Y), Z: math.Min(minimum.Z, s.Z)}
+		maximum = r3.Vector{X: math.Max(maximum.X, s.X), Y: math.Max(maximum.Y, s.Y), Z: math.Max(maximum.Z, s.Z)}
+	}
+	radius := maximum.Sub(minimum).Mul(0.5)
+	if radius.X == 0 || radius.Y == 0 {
+		return MagnetometerCalibration{}, errors.New("magnetometer readings do not cover a full circle")
+	}
+	offset := maximum.Add(minimum).Mul(0.5)
+	avgRadius := (radius.X + radius.Y) / 2
+	scaleZ := 1.
+	if radius.Z < minAxisCoverage*avgRadius {
+		offset.Z = 0
+	} else {
+		avgRadius = (radius.X + radius.Y + radius.Z) / 3
+		scaleZ = avgRadius / radius.Z
+	}
+	return MagnetometerCalibration{
+		Offset: offset,
+		Scale:  r3.Vector{X: avgRadius / radius.X, Y: avgRadius / radius.Y, Z: scaleZ},
+	}, nil
+}
+
+// Apply returns the given magnetometer reading corrected by the calibration.
+func (mc MagnetometerCalibration) Apply(mag r3.Vector) r3.Vector {
+	if mc.Scale == (r3.Vector{}) {
+		return mag.Sub(mc.Offset)
+	}
+	v := mag.Sub(mc.Offset)
+	return r3.Vector{X: v.X * mc.Scale.X, Y: v.Y * mc.Scale.Y, Z: v.Z * mc.Scale.Z}
+}
+
+// MagnetometerHeading returns the compass heading in degrees of a level magnetometer whose x axis
+// points forward and whose y axis points to the right. 0 degrees indicate North, 90 degrees
+// indicate East and so on.
+func MagnetometerHeading(mag r3.Vector) float64 {
+	heading := utils.RadToDeg(math.Atan2(-mag.Y, mag.X))
+	if heading < 0 {
+		heading += 360
+	}
+	return heading
+}
+
+// TiltCompensatedHeading returns the compass heading in degrees of a magnetometer that may not be
+// level, using the acceleration measured in the same frame to find which way is down. The frame
+// is the same as that of MagnetometerHeading, with its z axis pointing down, and the acceleration
+// of a level sensor at rest is along positive z.
+func TiltCompensatedHeading(mag, acceleration r3.Vector) float64 {
+	roll := math.Atan2(acceleration.Y, acceleration.Z)
+	pitch := math.Atan(-acceleration.X / (acceleration.Y*math.Sin(roll) + acceleration.Z*math.Cos(roll)))
+	// rotate the magnetic field back to level
+	level := r3.Vector{
+		X: mag.X*math.Cos(pitch) + mag.Y*math.Sin(pitch)*math.Sin(roll) + mag.Z*math.Sin(pitch)*math.Cos(roll),
+		Y: mag.Y*math.Cos(roll) - mag.Z*math.Sin(roll),
+	}
+	return MagnetometerHeading(level)
+}
@@ -1,10 +1,14 @@
 package movementsensor
 
 import (
+	"math"
 	"testing"
 
+	"github.com/golang/geo/r3"
 	geo "github.com/kellydunn/golang-geo"
 	"go.viam.com/test"
+
+	"go.viam.com/rdk/utils"
 )
 
 func TestGetHeading(t *testing.T) {
@@ -64,3 +68,86 @@ func TestGetHeading(t *testing.T) {
 	test.That(t, heading, test.ShouldAlmostEqual, 325.6498, 1e-3)
 	test.That(t, standardBearing, test.ShouldAlmostEqual, -124.3501, 1e-3)
 }
+
+func TestCalibrateMagnetometer(t *testing.T) {
+	_, err := CalibrateMagnetometer(nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = CalibrateMagnetometer([]r3.Vector{{X: 1}, {X: 2}})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// readings of a level sensor spun through a full circle, shifted by a hard-iron offset
+	// and with a more sensitive y axis
+	offset := r3.Vector{X: 0.2, Y: -0.1, Z: 0.05}
+	reading := func(heading float64) r3.Vector {
+		rad := utils.DegToRad(heading)
+		return r3.Vector{X: 0.4 * math.Cos(rad), Y: -0.6 * math.Sin(rad), Z: 0.3}.Add(offset)
+	}
+	var samples []r3.Vector
+	for heading := 0.; heading < 360; heading += 5 {
+		samples = append(samples, reading(heading))
+	}
+	cal, err := CalibrateMagnetometer(samples)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cal.Offset.X, test.ShouldAlmostEqual, offset.X)
+	test.That(t, cal.Offset.Y, test.ShouldAlmostEqual, offset.Y)
+	test.That(t, cal.Scale.X*0.4, test.ShouldAlmostEqual, cal.Scale.Y*0.6)
+
+	for _, heading := range []float64{0, 45, 90, 135, 180, 270, 330} {
+		uncorrected := MagnetometerHeading(reading(heading))
+		test.That(t, math.Abs(uncorrected-heading), test.ShouldBeGreaterThan, 1)
+		test.That(t, MagnetometerHeading(cal.Apply(reading(heading))), test.ShouldAlmostEqual, heading, 1e-6)
+	}
+
+	// z barely changes while the sensor is spun level, so its noise must not be taken for its range
+	jittered := make([]r3.Vector, 0, len(samples))
+	for i, s := range samples {
+		jittered = append(jittered, s.Add(r3.Vector{Z: 0.002 * math.Sin(float64(i))}))
+	}
+	cal, err = CalibrateMagnetometer(jittered)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cal.Scale.Z, test.ShouldEqual, 1)
+	test.That(t, cal.Offset.Z, test.ShouldEqual, 0)
+	test.That(t, cal.Scale.X*0.4, test.ShouldAlmostEqual, cal.Scale.Y*0.6)
+	test.That(t, cal.Apply(jittered[3]).Z, test.ShouldAlmostEqual, jittered[3].Z)
+	for i, heading := range []float64{0, 45, 90, 135} {
+		test.That(t, MagnetometerHeading(cal.Apply(jittered[9*i])), test.ShouldAlmostEqual, heading, 1e-6)
+	}
+
+	// readings spun about every axis calibrate z as well
+	var sphere []r3.Vector
+	for lat := -80.; lat <= 80; lat += 20 {
+		for lon := 0.; lon < 360; lon += 20 {
+			la, lo := utils.DegToRad(lat), utils.DegToRad(lon)
+			v := r3.Vector{X: 0.4 * math.Cos(la) * math.Cos(lo), Y: 0.6 * math.Cos(la) * math.Sin(lo), Z: 0.5 * math.Sin(la)}
+			sphere = append(sphere, v.Add(offset))
+		}
+	}
+	cal, err = CalibrateMagnetometer(sphere)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cal.Offset.Z, test.ShouldAlmostEqual, offset.Z)
+	test.That(t, cal.Scale.X*0.4, test.ShouldAlmostEqual, cal.Scale.Z*0.5*math.Sin(utils.DegToRad(80)))
+}
+
+// bodyFrame rotates a vector in the north-east-down frame into the frame of a sensor with the
+// given heading, pitch and roll, in degrees.
+func bodyFrame(v r3.Vector, heading, pitch, roll float64) r3.Vector {
+	h, p, r := utils.DegToRad(heading), utils.DegToRad(pitch), utils.DegToRad(roll)
+	v = r3.Vector{X: math.Cos(h)*v.X + math.Sin(h)*v.Y, Y: -math.Sin(h)*v.X + math.Cos(h)*v.Y, Z: v.Z}
+	v = r3.Vector{X: math.Cos(p)*v.X - math.Sin(p)*v.Z, Y: v.Y, Z: math.Sin(p)*v.X + math.Cos(p)*v.Z}
+	return r3.Vector{X: v.X, Y: math.Cos(r)*v.Y + math.Sin(r)*v.Z, Z: -math.Sin(r)*v.Y + math.Cos(r)*v.Z}
+}
+
+func TestTiltCompensatedHeading(t *testing.T) {
+	// the earth's magnetic field points north and dips into the ground
+	field := r3.Vector{X: 0.2, Z: 0.4}
+	gravity := r3.Vector{Z: 9806.65}
+	for _, heading := range []float64{10, 90, 200, 300} {
+		level := bodyFrame(field, heading, 0, 0)
+		test.That(t, MagnetometerHeading(level), test.ShouldAlmostEqual, heading)
+		test.That(t, TiltCompensatedHeading(level, bodyFrame(gravity, heading, 0, 0)), test.ShouldAlmostEqual, heading)
+
+		tilted := bodyFrame(field, heading, 15, -20)
+		test.That(t, math.Abs(MagnetometerHeading(tilted)-heading), test.ShouldBeGreaterThan, 1)
+		test.That(t, TiltCompensatedHeading(tilted, bodyFrame(gravity, heading, 15, -20)), test.ShouldAlmostEqual, heading)
+	}
+}
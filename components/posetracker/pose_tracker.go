@@ -92,6 +92,11 @@ func FromRobot(r robot.Robot, name string) (PoseTracker, error) {
 	return part, nil
 }
 
+// NamesFromRobot is a helper for getting all pose tracker names from the given Robot.
+func NamesFromRobot(r robot.Robot) []string {
+	return robot.NamesBySubtype(r, Subtype)
+}
+
 // Readings is a helper for getting all readings from a PoseTracker.
 func Readings(ctx context.Context, poseTracker PoseTracker) (map[string]interface{}, error) {
 	poseLookup, err := poseTracker.Poses(ctx, []string{}, map[string]interface{}{})
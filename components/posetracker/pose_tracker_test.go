@@ -86,6 +86,13 @@ func TestFromRobot(t *testing.T) {
 	})
 }
 
+func TestNamesFromRobot(t *testing.T) {
+	r := setupInjectRobot()
+
+	names := posetracker.NamesFromRobot(r)
+	test.That(t, names, test.ShouldResemble, []string{workingPTName})
+}
+
 func TestPoseTrackerName(t *testing.T) {
 	for _, tc := range []struct {
 		TestName string
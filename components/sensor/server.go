@@ -4,13 +4,16 @@ package sensor
 import (
 	"context"
 
-	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/sensor/v1"
 
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
+var readingsCounter = metrics.NewCounter("rdk_sensor_readings_total", "Readings taken from sensors.", "sensor")
+
 // subtypeServer implements the SensorService from sensor.proto.
 type subtypeServer struct {
 	pb.UnimplementedSensorServiceServer
@@ -26,11 +29,11 @@ func NewServer(s subtype.Service) pb.SensorServiceServer {
 func (s *subtypeServer) getSensor(name string) (Sensor, error) {
 	resource := s.s.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no generic sensor with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("generic sensor", name)
 	}
 	sensor, ok := resource.(Sensor)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a generic sensor", name)
+		return nil, utils.NewWrongTypeByNameError("a generic sensor", name)
 	}
 	return sensor, nil
 }
@@ -48,6 +51,7 @@ func (s *subtypeServer) GetReadings(
 	if err != nil {
 		return nil, err
 	}
+	readingsCounter.Inc(req.Name)
 	m, err := protoutils.ReadingGoToProto(readings)
 	if err != nil {
 		return nil, err
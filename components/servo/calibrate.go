@@ -0,0 +1,62 @@
+package servo
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+)
+
+// TargetOffset measures how far from the center of a camera image a visual target is, in any unit
+// that changes sign as the target crosses the center, and whether the target is in view at all.
+type TargetOffset func(ctx context.Context) (offset float64, inView bool, err error)
+
+// A CenterSweep is the range of angles FindCenter sweeps a servo through, in steps of StepDeg,
+// waiting Settle at each step before measuring for the camera image to steady.
+type CenterSweep struct {
+	MinDeg, MaxDeg, StepDeg uint32
+	Settle                  time.Duration
+}
+
+// FindCenter finds the angle of a servo, like the pan or tilt servo of a camera, at which the
+// camera points straight at a visual target. It sweeps the servo, measuring the offset of the
+// target at each step, and interpolates between the steps on either side of where the target
+// crosses the center of the image. The servo is left at the angle found, which can then be
+// configured as its starting position.
+func FindCenter(ctx context.Context, s Servo, sweep CenterSweep, offset TargetOffset) (uint32, error) {
+	if sweep.StepDeg == 0 || sweep.MinDeg >= sweep.MaxDeg {
+		return 0, errors.New("a center sweep needs a positive step and a minimum angle below the maximum")
+	}
+	var lastAngle uint32
+	var lastOffset float64
+	seen := false
+	for angle := sweep.MinDeg; angle <= sweep.MaxDeg; angle += sweep.StepDeg {
+		if err := s.Move(ctx, angle, nil); err != nil {
+			return 0, err
+		}
+		if !utils.SelectContextOrWait(ctx, sweep.Settle) {
+			return 0, ctx.Err()
+		}
+		off, inView, err := offset(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if !inView {
+			seen = false
+			continue
+		}
+		if off == 0 || (seen && math.Signbit(off) != math.Signbit(lastOffset)) {
+			center := float64(angle)
+			if off != 0 {
+				// where the line between the two measurements crosses zero
+				center = float64(lastAngle) + float64(angle-lastAngle)*lastOffset/(lastOffset-off)
+			}
+			centerDeg := uint32(math.Round(center))
+			return centerDeg, s.Move(ctx, centerDeg, nil)
+		}
+		lastAngle, lastOffset, seen = angle, off, true
+	}
+	return 0, errors.Errorf("target never crossed the center of the image between %d and %d degrees", sweep.MinDeg, sweep.MaxDeg)
+}
@@ -0,0 +1,50 @@
+package servo_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/servo"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestFindCenter(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(3))
+
+	// a pan servo whose true center is 97.3 degrees, and a camera with a 60 degree field of view
+	// 640 pixels wide seeing a target straight ahead of the robot
+	const trueCenter, fov, width = 97.3, 60., 640.
+	var angle uint32
+	pan := &inject.Servo{}
+	pan.MoveFunc = func(ctx context.Context, angleDeg uint32, extra map[string]interface{}) error {
+		angle = angleDeg
+		return nil
+	}
+	offset := func(ctx context.Context) (float64, bool, error) {
+		off := (trueCenter - float64(angle)) / fov * width
+		if math.Abs(off) > width/2 {
+			return 0, false, nil
+		}
+		return off + rng.NormFloat64(), true, nil
+	}
+
+	center, err := servo.FindCenter(ctx, pan, servo.CenterSweep{MinDeg: 30, MaxDeg: 150, StepDeg: 10}, offset)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, center, test.ShouldEqual, 97)
+	test.That(t, angle, test.ShouldEqual, 97)
+
+	t.Run("target out of range", func(t *testing.T) {
+		_, err := servo.FindCenter(ctx, pan, servo.CenterSweep{MinDeg: 0, MaxDeg: 60, StepDeg: 10}, offset)
+		test.That(t, err, test.ShouldBeError, "target never crossed the center of the image between 0 and 60 degrees")
+	})
+
+	t.Run("bad sweep", func(t *testing.T) {
+		_, err := servo.FindCenter(ctx, pan, servo.CenterSweep{MinDeg: 90, MaxDeg: 90, StepDeg: 10}, offset)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
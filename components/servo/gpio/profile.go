@@ -0,0 +1,53 @@
+package gpio
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The profiles a servo can move by. With no profile the servo is sent straight to its target and
+// moves as fast as it can.
+const (
+	ProfileLinear    = "linear"
+	ProfileEaseIn    = "ease_in"
+	ProfileEaseOut   = "ease_out"
+	ProfileEaseInOut = "ease_in_out"
+)
+
+// profileStepInterval is how often a profiled move sets the next position, once per period of a
+// 50Hz servo signal.
+const profileStepInterval = 20 * time.Millisecond
+
+// moveProfiles map the fraction of the duration of a move that has passed to the fraction of the
+// way to the target the servo should be at.
+var moveProfiles = map[string]func(float64) float64{
+	ProfileLinear:    func(t float64) float64 { return t },
+	ProfileEaseIn:    func(t float64) float64 { return t * t },
+	ProfileEaseOut:   func(t float64) float64 { return t * (2 - t) },
+	ProfileEaseInOut: func(t float64) float64 { return t * t * (3 - 2*t) },
+}
+
+func validateProfile(profile string) error {
+	if _, ok := moveProfiles[profile]; profile != "" && !ok {
+		return errors.Errorf("move_profile must be one of %s, %s, %s or %s, not %q",
+			ProfileLinear, ProfileEaseIn, ProfileEaseOut, ProfileEaseInOut, profile)
+	}
+	return nil
+}
+
+// profileSteps returns the angles, one per step interval, that move from the angle to the target
+// over the duration following the named profile. The last one is the target.
+func profileSteps(profile string, from, to float64, duration time.Duration) []float64 {
+	curve, ok := moveProfiles[profile]
+	steps := int(math.Ceil(float64(duration) / float64(profileStepInterval)))
+	if !ok || steps < 1 {
+		return []float64{to}
+	}
+	angles := make([]float64, 0, steps)
+	for i := 1; i <= steps; i++ {
+		angles = append(angles, from+(to-from)*curve(float64(i)/float64(steps)))
+	}
+	return angles
+}
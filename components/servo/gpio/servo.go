@@ -44,6 +44,11 @@ type servoConfig struct {
 	MinWidthUS *uint `json:"min_width_us"`
 	// MaxWidthUS Override the safe maximum width in us this affect PWM calculation
 	MaxWidthUS *uint `json:"max_width_us"`
+	// MoveProfile the profile moves follow from one position to the next, one of linear, ease_in,
+	// ease_out or ease_in_out. When left out moves go straight to their target
+	MoveProfile string `json:"move_profile,omitempty"`
+	// MoveDurationMs how long a move following the move profile takes
+	MoveDurationMs uint `json:"move_duration_ms,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -79,6 +84,12 @@ func (config *servoConfig) Validate(path string) ([]string, error) {
 	if config.MaxWidthUS != nil && *config.MaxWidthUS > maxWidthUs {
 		return nil, viamutils.NewConfigValidationError(path, errors.Errorf("max_width_us cannot be higher than %d", maxWidthUs))
 	}
+	if err := validateProfile(config.MoveProfile); err != nil {
+		return nil, viamutils.NewConfigValidationError(path, err)
+	}
+	if config.MoveProfile != "" && config.MoveDurationMs == 0 {
+		return nil, viamutils.NewConfigValidationFieldRequiredError(path, "move_duration_ms")
+	}
 	return deps, nil
 }
 
@@ -109,6 +120,9 @@ type servoGPIO struct {
 	maxUs     uint
 	pwmRes    uint
 	currPct   float64
+
+	profile      string
+	moveDuration time.Duration
 }
 
 func newGPIOServo(ctx context.Context, deps registry.Dependencies, cfg config.Component, logger golog.Logger) (interface{}, error) {
@@ -187,6 +201,9 @@ func newGPIOServo(ctx context.Context, deps registry.Dependencies, cfg config.Co
 			return nil, errors.Wrap(err, "couldn't move servo to start position")
 		}
 	}
+	// the servo goes straight to its start position since where it was is unknown
+	servo.profile = attr.MoveProfile
+	servo.moveDuration = time.Duration(attr.MoveDurationMs) * time.Millisecond
 	return servo, nil
 }
 
@@ -289,6 +306,8 @@ func (s *servoGPIO) findPWMResolution(ctx context.Context) error {
 
 // Move moves the servo to the given angle (0-180 degrees)
 // This will block until done or a new operation cancels this one.
+// The move follows the configured move profile, which extra["move_profile"] and
+// extra["move_duration_ms"] override for this move. A profile of "" goes straight to the angle.
 func (s *servoGPIO) Move(ctx context.Context, ang uint32, extra map[string]interface{}) error {
 	ctx, done := s.opMgr.New(ctx)
 	defer done()
@@ -299,6 +318,31 @@ func (s *servoGPIO) Move(ctx context.Context, ang uint32, extra map[string]inter
 	if angle > s.max {
 		angle = s.max
 	}
+
+	profile, duration := s.profile, s.moveDuration
+	if p, ok := extra["move_profile"].(string); ok {
+		if err := validateProfile(p); err != nil {
+			return err
+		}
+		profile = p
+	}
+	if ms, ok := extra["move_duration_ms"].(float64); ok {
+		duration = time.Duration(ms * float64(time.Millisecond))
+	}
+	from := mapDutyCylePctToDeg(s.minUs, s.maxUs, s.min, s.max, s.currPct, s.frequency)
+	for i, step := range profileSteps(profile, from, angle, duration) {
+		if i > 0 && !viamutils.SelectContextOrWait(ctx, profileStepInterval) {
+			return ctx.Err()
+		}
+		if err := s.setAngle(ctx, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setAngle sets the pwm of the servo for the angle, which must be within its range.
+func (s *servoGPIO) setAngle(ctx context.Context, angle float64) error {
 	pct := mapDegToDutyCylePct(s.minUs, s.maxUs, s.min, s.max, angle, s.frequency)
 	if s.pwmRes != 0 {
 		realTick := math.Round(pct * float64(s.pwmRes))
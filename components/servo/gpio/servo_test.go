@@ -4,6 +4,7 @@ package gpio
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"go.viam.com/test"
@@ -95,6 +96,25 @@ func TestValidate(t *testing.T) {
 	test.That(t, err.Error(),
 		test.ShouldContainSubstring,
 		"error validating \"test\": \"pin\" is required")
+	cfg.Pin = "a"
+
+	cfg.MoveProfile = "bounce"
+	_, err = cfg.Validate("test")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(),
+		test.ShouldContainSubstring,
+		"move_profile must be one of linear, ease_in, ease_out or ease_in_out, not \"bounce\"")
+
+	cfg.MoveProfile = ProfileEaseInOut
+	_, err = cfg.Validate("test")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(),
+		test.ShouldContainSubstring,
+		"error validating \"test\": \"move_duration_ms\" is required")
+
+	cfg.MoveDurationMs = 500
+	_, err = cfg.Validate("test")
+	test.That(t, err, test.ShouldBeNil)
 }
 
 func setupDependencies(t *testing.T) registry.Dependencies {
@@ -113,6 +133,7 @@ type mockGPIO struct {
 	scale     int
 	frequency int
 	innerTick int
+	history   []float64
 }
 
 func (g *mockGPIO) PWMFreq(ctx context.Context, extra map[string]interface{}) (uint, error) {
@@ -131,6 +152,7 @@ func (g *mockGPIO) PWM(ctx context.Context, extra map[string]interface{}) (float
 
 func (g *mockGPIO) SetPWM(ctx context.Context, dutyCyclePct float64, extra map[string]interface{}) error {
 	g.innerTick = rdkutils.ScaleByPct(g.scale, dutyCyclePct)
+	g.history = append(g.history, dutyCyclePct)
 	return nil
 }
 
@@ -199,3 +221,49 @@ func TestServoMove(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, pos, test.ShouldEqual, 63)
 }
+
+func TestServoMoveProfile(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	deps := setupDependencies(t)
+	ctx := context.Background()
+
+	cfg := config.Component{
+		ConvertedAttributes: &servoConfig{
+			Pin:            "1",
+			Board:          "mock",
+			StartPos:       Ptr(0.0),
+			MoveProfile:    ProfileEaseInOut,
+			MoveDurationMs: 100,
+		},
+	}
+	servo, err := newGPIOServo(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	realServo := servo.(*servoGPIO)
+	pin := realServo.pin.(*mockGPIO)
+
+	moveAngles := func(ang uint32, extra map[string]interface{}) []float64 {
+		pin.history = nil
+		test.That(t, realServo.Move(ctx, ang, extra), test.ShouldBeNil)
+		angles := make([]float64, 0, len(pin.history))
+		for _, pct := range pin.history {
+			angles = append(angles, mapDutyCylePctToDeg(realServo.minUs, realServo.maxUs, realServo.min, realServo.max, pct, realServo.frequency))
+		}
+		return angles
+	}
+
+	// 100ms is 5 steps, slow at either end and fast in the middle
+	test.That(t, moveAngles(100, nil), test.ShouldResemble, []float64{10, 35, 65, 90, 100})
+	test.That(t, moveAngles(0, map[string]interface{}{"move_profile": ProfileLinear}), test.ShouldResemble, []float64{80, 60, 40, 20, 0})
+	test.That(t, moveAngles(90, map[string]interface{}{"move_profile": ProfileEaseIn, "move_duration_ms": 60.}),
+		test.ShouldResemble, []float64{10, 40, 90})
+	test.That(t, moveAngles(45, map[string]interface{}{"move_profile": ""}), test.ShouldResemble, []float64{45})
+
+	err = realServo.Move(ctx, 45, map[string]interface{}{"move_profile": "bounce"})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// giving up on a move leaves the servo where it got to
+	cancelCtx, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+	defer cancel()
+	err = realServo.Move(cancelCtx, 180, map[string]interface{}{"move_duration_ms": 1000.})
+	test.That(t, err, test.ShouldBeError, context.DeadlineExceeded)
+}
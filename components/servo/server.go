@@ -4,11 +4,12 @@ package servo
 import (
 	"context"
 
-	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/servo/v1"
 
+	"go.viam.com/rdk/commandlog"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
 )
 
 type subtypeServer struct {
@@ -25,11 +26,11 @@ func NewServer(service subtype.Service) pb.ServoServiceServer {
 func (server *subtypeServer) getServo(name string) (Servo, error) {
 	resource := server.service.Resource(name)
 	if resource == nil {
-		return nil, errors.Errorf("no servo with name (%s)", name)
+		return nil, utils.NewNotFoundByNameError("servo", name)
 	}
 	servo, ok := resource.(Servo)
 	if !ok {
-		return nil, errors.Errorf("resource with name (%s) is not a servo", name)
+		return nil, utils.NewWrongTypeByNameError("a servo", name)
 	}
 	return servo, nil
 }
@@ -40,6 +41,7 @@ func (server *subtypeServer) Move(ctx context.Context, req *pb.MoveRequest) (*pb
 	if err != nil {
 		return nil, err
 	}
+	commandlog.Record(Named(req.GetName()).String(), "Move", map[string]interface{}{"angle_deg": req.GetAngleDeg()})
 	return &pb.MoveResponse{}, servo.Move(ctx, req.GetAngleDeg(), req.Extra.AsMap())
 }
 
@@ -64,5 +66,6 @@ func (server *subtypeServer) Stop(ctx context.Context, req *pb.StopRequest) (*pb
 	if err != nil {
 		return nil, err
 	}
+	commandlog.Record(Named(req.Name).String(), "Stop", nil)
 	return &pb.StopResponse{}, servo.Stop(ctx, req.Extra.AsMap())
 }
@@ -50,6 +50,9 @@ type Config struct {
 	// DisablePartialStart ensures that a robot will only start when all the components,
 	// services, and remotes pass config validation. This value is false by default
 	DisablePartialStart bool `json:"disable_partial_start"`
+
+	// SafeMode, when set, caps how fast the bases of the robot move, whatever speed they are asked for.
+	SafeMode *SafeModeConfig `json:"safe_mode,omitempty"`
 }
 
 // Ensure ensures all parts of the config are valid.
@@ -111,6 +114,12 @@ func (c *Config) Ensure(fromCloud bool) error {
 		return err
 	}
 
+	if c.SafeMode != nil {
+		if err := c.SafeMode.Validate("safe_mode"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -524,6 +533,29 @@ func (sc *SessionsConfig) Validate(path string) error {
 	return nil
 }
 
+// SafeModeConfig describes the speed caps of safe mode, for robots shared with people nearby. Only
+// bases are capped; arms and servos take no speed in their APIs to cap. A cap left at zero uses its
+// default.
+type SafeModeConfig struct {
+	MaxMMPerSec   float64 `json:"max_mm_per_sec,omitempty"`
+	MaxDegsPerSec float64 `json:"max_degs_per_sec,omitempty"`
+}
+
+const (
+	// DefaultSafeModeMMPerSec is the default linear speed cap of safe mode to use when not specified.
+	DefaultSafeModeMMPerSec = 100.
+	// DefaultSafeModeDegsPerSec is the default angular speed cap of safe mode to use when not specified.
+	DefaultSafeModeDegsPerSec = 30.
+)
+
+// Validate ensures all parts of the config are valid.
+func (smc *SafeModeConfig) Validate(path string) error {
+	if smc.MaxMMPerSec < 0 || smc.MaxDegsPerSec < 0 {
+		return utils.NewConfigValidationError(path, errors.New("max_mm_per_sec and max_degs_per_sec cannot be negative"))
+	}
+	return nil
+}
+
 // AuthConfig describes authentication and authorization settings for the web server.
 type AuthConfig struct {
 	Handlers        []AuthHandlerConfig `json:"handlers"`
@@ -342,6 +342,16 @@ func TestConfigEnsure(t *testing.T) {
 	}
 
 	test.That(t, invalidAuthConfig.Ensure(false), test.ShouldBeNil)
+
+	safeModeConfig := config.Config{SafeMode: &config.SafeModeConfig{MaxMMPerSec: -1}}
+	err = safeModeConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `safe_mode`)
+
+	// the caps left out are defaulted by the robot, not written into the config
+	safeModeConfig.SafeMode = &config.SafeModeConfig{}
+	test.That(t, safeModeConfig.Ensure(false), test.ShouldBeNil)
+	test.That(t, safeModeConfig.SafeMode, test.ShouldResemble, &config.SafeModeConfig{})
 }
 
 func TestConfigEnsurePartialStart(t *testing.T) {
@@ -517,6 +527,16 @@ func TestConfigEnsurePartialStart(t *testing.T) {
 	}
 
 	test.That(t, invalidAuthConfig.Ensure(false), test.ShouldBeNil)
+
+	safeModeConfig := config.Config{SafeMode: &config.SafeModeConfig{MaxMMPerSec: -1}}
+	err = safeModeConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `safe_mode`)
+
+	// the caps left out are defaulted by the robot, not written into the config
+	safeModeConfig.SafeMode = &config.SafeModeConfig{}
+	test.That(t, safeModeConfig.Ensure(false), test.ShouldBeNil)
+	test.That(t, safeModeConfig.SafeMode, test.ShouldResemble, &config.SafeModeConfig{})
 }
 
 func TestCopyOnlyPublicFields(t *testing.T) {
@@ -0,0 +1,113 @@
+// Package metrics keeps counters and gauges of what the robot is doing in a Prometheus registry, so
+// they can be scraped from the web server when enabled.
+package metrics
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+)
+
+// registry holds the metrics of the robot, apart from the process metrics of the default registry.
+var registry = prometheus.NewRegistry()
+
+// Handler serves every metric of the robot in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// WriteText writes every metric with at least one series in the Prometheus text exposition format.
+func WriteText(w io.Writer) error {
+	families, err := registry.Gather()
+	if err != nil {
+		return err
+	}
+	enc := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, family := range families {
+		if err := enc.Encode(family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A Counter is a value that only goes up, like the number of commands received.
+type Counter struct {
+	vec *prometheus.CounterVec
+}
+
+// NewCounter registers a counter with the given name, help text and label names. It is meant to be
+// called when a package is initialized and panics if the name is already registered.
+func NewCounter(name, help string, labels ...string) *Counter {
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+	registry.MustRegister(vec)
+	return &Counter{vec}
+}
+
+// Inc adds one to the counter of the label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds a non-negative amount to the counter of the label values. It does nothing if the label
+// values do not match the labels of the counter.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	counter, err := c.vec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return
+	}
+	counter.Add(v)
+}
+
+// A Gauge is a value that goes up and down, like the fix quality of a gps.
+type Gauge struct {
+	vec *prometheus.GaugeVec
+}
+
+// NewGauge registers a gauge with the given name, help text and label names. It is meant to be
+// called when a package is initialized and panics if the name is already registered.
+func NewGauge(name, help string, labels ...string) *Gauge {
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)
+	registry.MustRegister(vec)
+	return &Gauge{vec}
+}
+
+// Set sets the gauge of the label values. It does nothing if the label values do not match the
+// labels of the gauge.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	gauge, err := g.vec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return
+	}
+	gauge.Set(v)
+}
+
+// A Summary tracks the count and sum of observations, like how long planning takes, so that their
+// rate and average can be computed.
+type Summary struct {
+	vec *prometheus.SummaryVec
+}
+
+// NewSummary registers a summary with the given name, help text and label names. It is meant to be
+// called when a package is initialized and panics if the name is already registered.
+func NewSummary(name, help string, labels ...string) *Summary {
+	vec := prometheus.NewSummaryVec(prometheus.SummaryOpts{Name: name, Help: help}, labels)
+	registry.MustRegister(vec)
+	return &Summary{vec}
+}
+
+// Observe records an observation in the summary of the label values. It does nothing if the label
+// values do not match the labels of the summary.
+func (s *Summary) Observe(v float64, labelValues ...string) {
+	summary, err := s.vec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return
+	}
+	summary.Observe(v)
+}
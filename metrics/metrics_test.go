@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestWriteText(t *testing.T) {
+	commands := NewCounter("test_commands_total", "Commands received.", "name", "method")
+	quality := NewGauge("test_fix_quality", "Fix quality.", "device")
+	latency := NewSummary("test_latency_seconds", "Latency\nof calls.")
+	NewCounter("test_unused_total", "Never counted.")
+
+	test.That(t, func() { NewCounter("test_commands_total", "Again.") }, test.ShouldPanic)
+	// label values that do not match the labels are dropped rather than panicking in a request
+	test.That(t, func() { commands.Inc("m1") }, test.ShouldNotPanic)
+
+	commands.Inc("m1", "GoFor")
+	commands.Inc("m1", "GoFor")
+	commands.Add(-3, "m1", "GoFor")
+	commands.Inc(`m"2`, "Stop")
+	quality.Set(4, "/dev/ttyUSB0")
+	quality.Set(1, "/dev/ttyUSB0")
+	latency.Observe(0.5)
+	latency.Observe(0.25)
+
+	var b strings.Builder
+	test.That(t, WriteText(&b), test.ShouldBeNil)
+	test.That(t, b.String(), test.ShouldEqual, strings.Join([]string{
+		"# HELP test_commands_total Commands received.",
+		"# TYPE test_commands_total counter",
+		`test_commands_total{method="GoFor",name="m1"} 2`,
+		`test_commands_total{method="Stop",name="m\"2"} 1`,
+		"# HELP test_fix_quality Fix quality.",
+		"# TYPE test_fix_quality gauge",
+		`test_fix_quality{device="/dev/ttyUSB0"} 1`,
+		`# HELP test_latency_seconds Latency\nof calls.`,
+		"# TYPE test_latency_seconds summary",
+		"test_latency_seconds_sum 0.75",
+		"test_latency_seconds_count 2",
+		"",
+	}, "\n"))
+}
@@ -0,0 +1,387 @@
+package motionplan
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+// analyticTolerance is how far, in mm or radians, the geometry of a model may be from the ideal and
+// still be solved analytically.
+const analyticTolerance = 1e-6
+
+// AnalyticIK solves for the joint positions of 6 DoF arms with a spherical wrist in closed form,
+// as most industrial arms are built. The first joint turns the plane the next two joints move the
+// wrist center in, and the last three joints turn about the wrist center. It returns every solution
+// within the joint limits, up to eight, without iterating.
+type AnalyticIK struct {
+	model  referenceframe.Frame
+	logger golog.Logger
+	limits []referenceframe.Limit
+
+	// The axis of each joint and a point on it, with every joint at zero, and the pose of the end
+	// effector there.
+	axes   [6]r3.Vector
+	points [6]r3.Vector
+	home   spatial.Pose
+
+	// Where the wrist center is relative to the end effector, which does not change as the wrist turns.
+	wristInEE r3.Vector
+
+	// The plane of the second and third joints with every joint at zero, and the links that move the
+	// wrist center in it.
+	planeX, planeY r3.Vector
+	shoulder       r2.Point
+	upperArm       r2.Point
+	forearm        r2.Point
+	planeSigns     [2]float64
+
+	// The wrist joints' rotations are solved as X-Y-X euler angles in this basis.
+	wristBasis [3]r3.Vector
+	wristSign  float64
+}
+
+// CreateAnalyticIKSolver creates an AnalyticIK for the given Frame, which must move its end effector
+// like a 6 DoF arm with a spherical wrist. The geometry of the arm is read from Transform() rather
+// than from how the frame is built, so frames that include a fixed base work as well as bare models.
+// An error is returned if the frame does not qualify.
+func CreateAnalyticIKSolver(model referenceframe.Frame, logger golog.Logger) (*AnalyticIK, error) {
+	ik := &AnalyticIK{model: model, logger: logger, limits: model.DoF()}
+	if len(ik.limits) != 6 {
+		return nil, errors.Errorf("analytic IK needs 6 joints, not %d", len(ik.limits))
+	}
+	var err error
+	ik.home, err = ik.transform(make([]float64, 6))
+	if err != nil {
+		return nil, err
+	}
+	for i := range ik.axes {
+		if err := ik.findJoint(i); err != nil {
+			return nil, err
+		}
+	}
+	if err := ik.checkChain(); err != nil {
+		return nil, err
+	}
+	if err := ik.findArm(); err != nil {
+		return nil, err
+	}
+	return ik, nil
+}
+
+// transform returns the pose of the end effector at the given joint positions, which may be out of
+// bounds.
+func (ik *AnalyticIK) transform(joints []float64) (spatial.Pose, error) {
+	pose, err := ik.model.Transform(referenceframe.FloatsToInputs(joints))
+	if pose == nil || (err != nil && !strings.Contains(err.Error(), referenceframe.OOBErrString)) {
+		return nil, err
+	}
+	return pose, nil
+}
+
+// findJoint finds the axis of joint i by turning it alone by one radian, which must rotate the end
+// effector by one radian about a fixed line.
+func (ik *AnalyticIK) findJoint(i int) error {
+	joints := make([]float64, 6)
+	joints[i] = 1
+	pose, err := ik.transform(joints)
+	if err != nil {
+		return err
+	}
+	motion := spatial.Compose(pose, spatial.PoseInverse(ik.home))
+	aa := motion.Orientation().AxisAngles()
+	axis := r3.Vector{aa.RX, aa.RY, aa.RZ}.Normalize()
+	if math.Abs(aa.Theta-1) > analyticTolerance || math.Abs(motion.Point().Dot(axis)) > analyticTolerance {
+		return errors.Errorf("analytic IK needs revolute joints, joint %d is not", i)
+	}
+	// A rotation by theta about a line through q moves the origin by t = (I-R)q, so the point of the
+	// line closest to the origin is t/2 + cot(theta/2)(axis x t)/2.
+	t := motion.Point()
+	ik.axes[i] = axis
+	ik.points[i] = t.Mul(0.5).Add(axis.Cross(t).Mul(0.5 / math.Tan(0.5)))
+	return nil
+}
+
+// checkChain checks that the joints found by findJoint move the end effector together as they do
+// alone, as the joints of a serial arm do.
+func (ik *AnalyticIK) checkChain() error {
+	joints := []float64{0.3, -0.4, 0.5, -0.6, 0.7, -0.8}
+	pose, err := ik.transform(joints)
+	if err != nil {
+		return err
+	}
+	if !spatial.PoseAlmostEqualEps(pose, ik.forward(joints), analyticTolerance) {
+		return errors.New("analytic IK needs a serial arm, the joints do not move the end effector independently")
+	}
+	return nil
+}
+
+// forward returns the pose of the end effector at the given joint positions as composed from the
+// joints found by findJoint.
+func (ik *AnalyticIK) forward(joints []float64) spatial.Pose {
+	pose := spatial.NewZeroPose()
+	for i, theta := range joints {
+		a, q := ik.axes[i], ik.points[i]
+		motion := spatial.NewPoseFromOrientation(q.Sub(rotate(q, a, theta)), &spatial.R4AA{theta, a.X, a.Y, a.Z})
+		pose = spatial.Compose(pose, motion)
+	}
+	return spatial.Compose(pose, ik.home)
+}
+
+// findArm finds the wrist center, the plane the second and third joints move it in, and the basis the
+// wrist joints turn in.
+func (ik *AnalyticIK) findArm() error {
+	a := ik.axes
+	if math.Abs(a[0].Dot(a[1])) > analyticTolerance || a[1].Cross(a[2]).Norm() > analyticTolerance {
+		return errors.New("analytic IK needs the second joint perpendicular to the first and parallel to the third")
+	}
+	if math.Abs(a[3].Dot(a[4])) > analyticTolerance || math.Abs(a[4].Dot(a[5])) > analyticTolerance ||
+		a[3].Cross(a[5]).Norm() > analyticTolerance {
+		return errors.New("analytic IK needs the middle wrist joint perpendicular to the others")
+	}
+	wrist, dist := closestPoint(ik.points[3], a[3], ik.points[4], a[4])
+	if dist > analyticTolerance || wrist.Sub(ik.points[5]).Cross(a[5]).Norm() > analyticTolerance {
+		return errors.New("analytic IK needs a spherical wrist, the wrist joints do not meet at a point")
+	}
+	if math.Abs(wrist.Sub(ik.points[0]).Dot(a[1])) > analyticTolerance {
+		return errors.New("analytic IK needs the wrist center in the plane the second and third joints move in")
+	}
+	ik.wristInEE = unorient(ik.home.Orientation(), wrist.Sub(ik.home.Point()))
+
+	ik.planeX = a[1].Cross(a[0])
+	ik.planeY = a[0]
+	ik.shoulder = ik.toPlane(ik.points[1])
+	ik.upperArm = ik.toPlane(ik.points[2]).Sub(ik.shoulder)
+	ik.forearm = ik.toPlane(wrist).Sub(ik.toPlane(ik.points[2]))
+	if ik.upperArm.Norm() < analyticTolerance || ik.forearm.Norm() < analyticTolerance {
+		return errors.New("analytic IK needs the wrist center away from the second and third joints")
+	}
+	normal := ik.planeX.Cross(ik.planeY)
+	ik.planeSigns = [2]float64{normal.Dot(a[1]), normal.Dot(a[2])}
+
+	ik.wristBasis = [3]r3.Vector{a[3], a[4], a[3].Cross(a[4])}
+	ik.wristSign = a[5].Dot(a[3])
+	return nil
+}
+
+// toPlane returns where a point falls in the plane of the second and third joints.
+func (ik *AnalyticIK) toPlane(p r3.Vector) r2.Point {
+	rel := p.Sub(ik.points[0])
+	return r2.Point{rel.Dot(ik.planeX), rel.Dot(ik.planeY)}
+}
+
+// Solve sends every solution for the goal within the joint limits to the channel, those closest to
+// the seed first. A solution is only sent if the metric between the pose it reaches and the goal is
+// as small as the nlopt solver requires. If there are none, errNoSolve is returned.
+func (ik *AnalyticIK) Solve(ctx context.Context,
+	c chan<- []referenceframe.Input,
+	newGoal spatial.Pose,
+	seed []referenceframe.Input,
+	m Metric,
+	rseed int,
+) error {
+	if len(seed) != 6 {
+		return referenceframe.NewIncorrectInputLengthError(len(seed), 6)
+	}
+	seedFloats := referenceframe.InputsToFloats(seed)
+
+	var solutions [][]float64
+	for _, candidate := range ik.candidates(newGoal, seedFloats) {
+		joints, ok := ik.wrapToLimits(candidate, seedFloats)
+		if !ok || containsJoints(solutions, joints) {
+			continue
+		}
+		pose, err := ik.transform(joints)
+		if err != nil {
+			return err
+		}
+		if m(pose, newGoal) < defaultEpsilon*defaultEpsilon {
+			solutions = append(solutions, joints)
+		}
+	}
+	if len(solutions) == 0 {
+		return errNoSolve
+	}
+	sort.Slice(solutions, func(i, j int) bool {
+		return jointDistance(solutions[i], seedFloats) < jointDistance(solutions[j], seedFloats)
+	})
+	for _, joints := range solutions {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c <- referenceframe.FloatsToInputs(joints):
+		}
+	}
+	return nil
+}
+
+// candidates returns the joint positions of every branch of the solution for the goal, ignoring
+// joint limits. Where a joint is free, as at a singularity, it is left where the seed has it.
+func (ik *AnalyticIK) candidates(goal spatial.Pose, seed []float64) [][]float64 {
+	wrist := goal.Point().Add(orient(goal.Orientation(), ik.wristInEE)).Sub(ik.points[0])
+
+	// The first joint turns the plane of the arm to the wrist center, facing it or facing away.
+	a1 := ik.axes[0]
+	flat := wrist.Sub(a1.Mul(wrist.Dot(a1)))
+	theta1 := seed[0]
+	if flat.Norm() > analyticTolerance {
+		theta1 = math.Atan2(flat.Dot(a1.Cross(ik.planeX)), flat.Dot(ik.planeX))
+	}
+
+	var candidates [][]float64
+	for _, t1 := range []float64{theta1, theta1 + math.Pi} {
+		target := ik.toPlane(ik.points[0].Add(rotate(wrist, a1, -t1))).Sub(ik.shoulder)
+		for _, arm := range ik.solveArm(target) {
+			t2, t3 := arm[0], arm[1]
+			for _, w := range ik.solveWrist(goal.Orientation(), t1, t2, t3, seed[5]) {
+				candidates = append(candidates, []float64{t1, t2, t3, w[0], w[1], w[2]})
+			}
+		}
+	}
+	return candidates
+}
+
+// solveArm returns the positions of the second and third joints, elbow up and down, that put the
+// wrist center at the target in the plane of the arm, relative to the second joint.
+func (ik *AnalyticIK) solveArm(target r2.Point) [][2]float64 {
+	l1, l2 := ik.upperArm.Norm(), ik.forearm.Norm()
+	cos := (target.Dot(target) - l1*l1 - l2*l2) / (2 * l1 * l2)
+	if math.Abs(cos) > 1+analyticTolerance {
+		return nil
+	}
+	cos = math.Max(-1, math.Min(1, cos))
+	offset := math.Atan2(ik.forearm.Y, ik.forearm.X) - math.Atan2(ik.upperArm.Y, ik.upperArm.X)
+
+	var arms [][2]float64
+	for _, bend := range []float64{math.Acos(cos), -math.Acos(cos)} {
+		phi3 := bend - offset
+		reach := ik.upperArm.Add(rotate2(ik.forearm, phi3))
+		phi2 := math.Atan2(target.Y, target.X) - math.Atan2(reach.Y, reach.X)
+		arms = append(arms, [2]float64{ik.planeSigns[0] * phi2, ik.planeSigns[1] * phi3})
+	}
+	return arms
+}
+
+// solveWrist returns the positions of the wrist joints, flipped and not, that turn the end effector
+// to the goal orientation once the first three joints are at the given positions.
+func (ik *AnalyticIK) solveWrist(goalOrient spatial.Orientation, t1, t2, t3, seed6 float64) [][3]float64 {
+	// q is the rotation the wrist must make, from the orientation at zero to the goal, in the
+	// wrist basis where it is X-Y-X euler angles.
+	var q [3][3]float64
+	for j, b := range ik.wristBasis {
+		v := orient(goalOrient, unorient(ik.home.Orientation(), b))
+		v = rotate(rotate(rotate(v, ik.axes[0], -t1), ik.axes[1], -t2), ik.axes[2], -t3)
+		for i, row := range ik.wristBasis {
+			q[i][j] = row.Dot(v)
+		}
+	}
+
+	beta := math.Acos(math.Max(-1, math.Min(1, q[0][0])))
+	if math.Sin(beta) < analyticTolerance {
+		// The first and last wrist joints line up, only their sum or difference matters.
+		gamma := ik.wristSign * seed6
+		alpha := math.Atan2(q[2][1], q[1][1]) - gamma
+		if q[0][0] < 0 {
+			alpha += 2 * gamma
+		}
+		return [][3]float64{{alpha, beta, ik.wristSign * gamma}}
+	}
+	var wrists [][3]float64
+	for _, s := range []float64{1, -1} {
+		alpha := math.Atan2(s*q[1][0], -s*q[2][0])
+		gamma := math.Atan2(s*q[0][1], s*q[0][2])
+		wrists = append(wrists, [3]float64{alpha, s * beta, ik.wristSign * gamma})
+	}
+	return wrists
+}
+
+// wrapToLimits turns each joint of a candidate by whole turns into its limits, nearest the seed
+// where there is more than one way. It returns false if a joint cannot be.
+func (ik *AnalyticIK) wrapToLimits(candidate, seed []float64) ([]float64, bool) {
+	joints := make([]float64, len(candidate))
+	for i, theta := range candidate {
+		theta = math.Remainder(theta, 2*math.Pi)
+		best, found := 0., false
+		for turns := -2.; turns <= 2; turns++ {
+			t := theta + turns*2*math.Pi
+			if t < ik.limits[i].Min || t > ik.limits[i].Max {
+				continue
+			}
+			if !found || math.Abs(t-seed[i]) < math.Abs(best-seed[i]) {
+				best, found = t, true
+			}
+		}
+		if !found {
+			return nil, false
+		}
+		joints[i] = best
+	}
+	return joints, true
+}
+
+// Frame returns the associated referenceframe.
+func (ik *AnalyticIK) Frame() referenceframe.Frame {
+	return ik.model
+}
+
+// rotate rotates v by theta about the unit axis.
+func rotate(v, axis r3.Vector, theta float64) r3.Vector {
+	sin, cos := math.Sincos(theta)
+	return v.Mul(cos).Add(axis.Cross(v).Mul(sin)).Add(axis.Mul(axis.Dot(v) * (1 - cos)))
+}
+
+// orient rotates v from the frame of the orientation to the frame it is in.
+func orient(o spatial.Orientation, v r3.Vector) r3.Vector {
+	aa := o.AxisAngles()
+	return rotate(v, r3.Vector{aa.RX, aa.RY, aa.RZ}, aa.Theta)
+}
+
+// unorient rotates v into the frame of the orientation.
+func unorient(o spatial.Orientation, v r3.Vector) r3.Vector {
+	aa := o.AxisAngles()
+	return rotate(v, r3.Vector{aa.RX, aa.RY, aa.RZ}, -aa.Theta)
+}
+
+func rotate2(p r2.Point, theta float64) r2.Point {
+	sin, cos := math.Sincos(theta)
+	return r2.Point{p.X*cos - p.Y*sin, p.X*sin + p.Y*cos}
+}
+
+// closestPoint returns the point of the first line closest to the second, and how far apart they are.
+func closestPoint(p1, a1, p2, a2 r3.Vector) (r3.Vector, float64) {
+	w := p1.Sub(p2)
+	cos, d, e := a1.Dot(a2), a1.Dot(w), a2.Dot(w)
+	denom := 1 - cos*cos
+	if denom < analyticTolerance {
+		return p1, w.Sub(a2.Mul(e)).Norm()
+	}
+	closest1 := p1.Add(a1.Mul((cos*e - d) / denom))
+	closest2 := p2.Add(a2.Mul((e - cos*d) / denom))
+	return closest1, closest1.Sub(closest2).Norm()
+}
+
+func jointDistance(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+func containsJoints(solutions [][]float64, joints []float64) bool {
+	for _, s := range solutions {
+		if jointDistance(s, joints) < analyticTolerance {
+			return true
+		}
+	}
+	return false
+}
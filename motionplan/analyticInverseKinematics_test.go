@@ -0,0 +1,96 @@
+package motionplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+func TestAnalyticIKSolver(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	m, err := referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/trossen/trossen_wx250s_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	ik, err := CreateAnalyticIKSolver(m, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, known := range [][]float64{
+		{0.5, 0.3, -0.4, 0.8, 0.6, -1.2},
+		{-1.5, -0.9, 1.0, -2.0, -1.1, 2.5},
+		{2.5, 0.1, 0.2, 0.3, 1.5, 0.4},
+		// wrist singularity, where the first and last wrist joints line up
+		{0.2, 0.4, -0.6, 0.7, 0, -0.3},
+	} {
+		goal, err := m.Transform(referenceframe.FloatsToInputs(known))
+		test.That(t, err, test.ShouldBeNil)
+
+		solutions := make(chan []referenceframe.Input, 8)
+		seed := referenceframe.FloatsToInputs(make([]float64, 6))
+		test.That(t, ik.Solve(context.Background(), solutions, goal, seed, NewSquaredNormMetric(), 1), test.ShouldBeNil)
+		close(solutions)
+
+		// at the singularity only the sum of the first and last wrist joints is fixed
+		singular := known[4] == 0
+		if singular {
+			known = []float64{known[0], known[1], known[2], known[3] + known[5], 0, 0}
+		}
+		found := false
+		count := 0
+		for solution := range solutions {
+			count++
+			pose, err := m.Transform(solution)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, spatialmath.PoseAlmostEqualEps(pose, goal, 1e-4), test.ShouldBeTrue)
+			pos := referenceframe.InputsToFloats(solution)
+			if singular {
+				pos[3], pos[5] = pos[3]+pos[5], 0
+			}
+			if jointDistance(pos, known) < 1e-6 {
+				found = true
+			}
+		}
+		test.That(t, count, test.ShouldBeGreaterThan, 0)
+		test.That(t, found, test.ShouldBeTrue)
+	}
+
+	// out of reach
+	solutions := make(chan []referenceframe.Input, 8)
+	goal := spatialmath.NewPoseFromPoint(r3.Vector{Z: 2000})
+	err = ik.Solve(context.Background(), solutions, goal, referenceframe.FloatsToInputs(make([]float64, 6)), NewSquaredNormMetric(), 1)
+	test.That(t, err, test.ShouldEqual, errNoSolve)
+}
+
+func TestAnalyticIKSolverGeometry(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	for _, file := range []string{
+		"components/arm/trossen/trossen_wx250s_test.json",
+		"components/arm/xarm/xarm6_kinematics.json",
+		"components/arm/xarm/xarm7_kinematics.json",
+	} {
+		m, err := referenceframe.ParseModelJSONFile(utils.ResolveFile(file), "")
+		test.That(t, err, test.ShouldBeNil)
+		_, err = CreateAnalyticIKSolver(m, logger)
+		test.That(t, err, test.ShouldNotBeNil)
+	}
+}
+
+func TestCombinedIKAnalytic(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	m, err := referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/trossen/trossen_wx250s_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	ik, err := CreateCombinedIKSolver(m, logger, 1)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ik.analytic, test.ShouldNotBeNil)
+
+	m, err = referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	ik, err = CreateCombinedIKSolver(m, logger, 1)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ik.analytic, test.ShouldBeNil)
+}
@@ -251,6 +251,10 @@ func (cg *collisionGraph) collisions() []Collision {
 // CollisionSystem is an object that checks for and records collisions between CollisionEntities.
 type CollisionSystem struct {
 	graphs []*collisionGraph
+
+	// allowed holds pairs of entity names that are reported as colliding whether or not they do, so that systems using
+	// this one as a reference never check them
+	allowed map[[2]string]bool
 }
 
 // NewCollisionSystemFromReference creates a new collision system that checks for collisions
@@ -263,7 +267,7 @@ func NewCollisionSystemFromReference(
 	reference *CollisionSystem,
 	reportDistances bool,
 ) (*CollisionSystem, error) {
-	cs := &CollisionSystem{make([]*collisionGraph, 0)}
+	cs := &CollisionSystem{graphs: make([]*collisionGraph, 0)}
 	graph, err := newCollisionGraph(key, key, reference, reportDistances)
 	if err != nil {
 		return nil, err
@@ -305,6 +309,9 @@ func (cs *CollisionSystem) Collisions() []Collision {
 
 // CollisionBetween returns a bool describing if a collision between the two named entities was reported in the CollisionSystem.
 func (cs *CollisionSystem) CollisionBetween(keyName, testName string) bool {
+	if cs.allowed[[2]string{keyName, testName}] || cs.allowed[[2]string{testName, keyName}] {
+		return true
+	}
 	for _, graph := range cs.graphs {
 		if graph.collisionBetween(keyName, testName) {
 			return true
@@ -312,3 +319,14 @@ func (cs *CollisionSystem) CollisionBetween(keyName, testName string) bool {
 	}
 	return false
 }
+
+// allowCollisions marks pairs of named entities as always colliding in the CollisionSystem, for example links of a model that are
+// always touching. Collision systems created with this one as their reference will then ignore collisions between them.
+func (cs *CollisionSystem) allowCollisions(pairs [][2]string) {
+	if cs.allowed == nil {
+		cs.allowed = map[[2]string]bool{}
+	}
+	for _, pair := range pairs {
+		cs.allowed[pair] = true
+	}
+}
@@ -0,0 +1,49 @@
+package motionplan
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type collisionTimerKey struct{}
+
+// A CollisionCheckTimer adds up the time spent checking for collisions while planning.
+type CollisionCheckTimer struct {
+	nanos int64
+}
+
+// WithCollisionCheckTimer returns a context which has planning under it add the time it spends checking
+// for collisions to the returned timer. Collision checks run concurrently, so the time can exceed the
+// time planning took.
+func WithCollisionCheckTimer(ctx context.Context) (context.Context, *CollisionCheckTimer) {
+	timer := &CollisionCheckTimer{}
+	return context.WithValue(ctx, collisionTimerKey{}, timer), timer
+}
+
+// Elapsed returns the time spent checking for collisions so far.
+func (t *CollisionCheckTimer) Elapsed() time.Duration {
+	return time.Duration(atomic.LoadInt64(&t.nanos))
+}
+
+// timeCollisionChecks wraps the collision constraint of the options and their fallbacks to add the time
+// they take to the timer of the context, if it has one.
+func timeCollisionChecks(ctx context.Context, opt *plannerOptions) {
+	timer, ok := ctx.Value(collisionTimerKey{}).(*CollisionCheckTimer)
+	if !ok {
+		return
+	}
+	for ; opt != nil; opt = opt.Fallback {
+		check, ok := opt.constraints[defaultCollisionConstraintName]
+		if !ok {
+			continue
+		}
+		opt.constraints[defaultCollisionConstraintName] = func(ci *ConstraintInput) (bool, float64) {
+			start := time.Now()
+			defer func() {
+				atomic.AddInt64(&timer.nanos, int64(time.Since(start)))
+			}()
+			return check(ci)
+		}
+	}
+}
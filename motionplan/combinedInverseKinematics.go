@@ -14,14 +14,16 @@ import (
 
 // CombinedIK defines the fields necessary to run a combined solver.
 type CombinedIK struct {
-	solvers []InverseKinematics
-	model   referenceframe.Frame
-	logger  golog.Logger
+	solvers  []InverseKinematics
+	analytic *AnalyticIK
+	model    referenceframe.Frame
+	logger   golog.Logger
 }
 
 // CreateCombinedIKSolver creates a combined parallel IK solver with a number of nlopt solvers equal to the nCPU
 // passed in. Each will be given a different random seed. When asked to solve, all solvers will be run in parallel
-// and the first valid found solution will be returned.
+// and the first valid found solution will be returned. If the model is a 6 DoF arm with a spherical wrist, its analytic
+// solutions are found first.
 func CreateCombinedIKSolver(model referenceframe.Frame, logger golog.Logger, nCPU int) (*CombinedIK, error) {
 	ik := &CombinedIK{}
 	ik.model = model
@@ -37,6 +39,11 @@ func CreateCombinedIKSolver(model referenceframe.Frame, logger golog.Logger, nCP
 		ik.solvers = append(ik.solvers, nlopt)
 	}
 	ik.logger = logger
+	if analytic, err := CreateAnalyticIKSolver(model, logger); err == nil {
+		ik.analytic = analytic
+	} else {
+		logger.Debugw("not solving analytically", "error", err)
+	}
 	return ik, nil
 }
 
@@ -69,6 +76,15 @@ func (ik *CombinedIK) Solve(ctx context.Context,
 	ik.logger.Debugf("starting pose: %v", spatialmath.PoseToProtobuf(startPos))
 	ik.logger.Debugf("goal pose: %v", spatialmath.PoseToProtobuf(newGoal))
 
+	if ik.analytic != nil {
+		if err := ik.analytic.Solve(ctx, c, newGoal, seed, m, rseed); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			ik.logger.Debugw("no analytic solution", "error", err)
+		}
+	}
+
 	ctxWithCancel, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -124,10 +124,13 @@ func (c *constraintHandler) CheckConstraints(cInput *ConstraintInput) (bool, flo
 
 // NewCollisionConstraint is a helper function for creating a collision Constraint that takes a frame and geometries
 // representing obstacles and interaction spaces and will construct a collision avoidance constraint from them.
+// The constraint covers collisions of the frame with itself as well. Collisions present at goodInput are ignored, as are
+// collisions between the pairs of geometry names in allowedCollisions, such as links of an arm that are always touching.
 func NewCollisionConstraint(
 	frame referenceframe.Frame,
 	goodInput []referenceframe.Input,
 	obstacles, interactionSpaces map[string]spatial.Geometry,
+	allowedCollisions [][2]string,
 	reportDistances bool,
 ) Constraint {
 	zeroVols, err := frame.Geometries(goodInput)
@@ -150,6 +153,7 @@ func NewCollisionConstraint(
 	if err != nil {
 		return nil
 	}
+	zeroCG.allowCollisions(allowedCollisions)
 
 	constraint := func(cInput *ConstraintInput) (bool, float64) {
 		internal, err := cInput.Frame.Geometries(cInput.StartInput)
@@ -193,6 +197,7 @@ func NewCollisionConstraintFromWorldState(
 	fs referenceframe.FrameSystem,
 	worldState *referenceframe.WorldState,
 	observationInput map[string][]referenceframe.Input,
+	allowedCollisions [][2]string,
 	reportDistances bool,
 ) (Constraint, error) {
 	// TODO(rb) it is bad practice to assume that the current inputs of the robot correspond to the passed in world state
@@ -219,6 +224,7 @@ func NewCollisionConstraintFromWorldState(
 		goodInputs,
 		worldState.Obstacles[0].Geometries(),
 		worldState.InteractionSpaces[0].Geometries(),
+		allowedCollisions,
 		reportDistances,
 	), nil
 }
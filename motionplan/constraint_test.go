@@ -200,7 +200,7 @@ func TestCollisionConstraint(t *testing.T) {
 	model, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
 	test.That(t, err, test.ShouldBeNil)
 	handler := &constraintHandler{}
-	handler.AddConstraint("collision", NewCollisionConstraint(model, zeroPos, obstacles, map[string]spatial.Geometry{}, false))
+	handler.AddConstraint("collision", NewCollisionConstraint(model, zeroPos, obstacles, map[string]spatial.Geometry{}, nil, false))
 
 	// loop through cases and check constraint handler processes them correctly
 	for i, c := range cases {
@@ -211,6 +211,58 @@ func TestCollisionConstraint(t *testing.T) {
 	}
 }
 
+func TestSelfCollisionConstraint(t *testing.T) {
+	model, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	zeroPos := frame.FloatsToInputs([]float64{0, 0, 0, 0, 0, 0})
+	// folding the wrist all the way back drives it into the base and the upper arm
+	folded := frame.FloatsToInputs([]float64{0, 0, 0, 0, 2.8, 0})
+	allowed := [][2]string{{"xArm6:wrist_link", "xArm6:base_top"}, {"xArm6:upper_arm", "xArm6:wrist_link"}}
+
+	handler := &constraintHandler{}
+	handler.AddConstraint("collision", NewCollisionConstraint(model, zeroPos, nil, nil, nil, false))
+	pass, _ := handler.CheckConstraints(&ConstraintInput{StartInput: folded, Frame: model})
+	test.That(t, pass, test.ShouldBeFalse)
+
+	// allowing only one of the two collisions is not enough
+	handler = &constraintHandler{}
+	handler.AddConstraint("collision", NewCollisionConstraint(model, zeroPos, nil, nil, allowed[:1], false))
+	pass, _ = handler.CheckConstraints(&ConstraintInput{StartInput: folded, Frame: model})
+	test.That(t, pass, test.ShouldBeFalse)
+
+	handler = &constraintHandler{}
+	handler.AddConstraint("collision", NewCollisionConstraint(model, zeroPos, nil, nil, allowed, false))
+	pass, _ = handler.CheckConstraints(&ConstraintInput{StartInput: folded, Frame: model})
+	test.That(t, pass, test.ShouldBeTrue)
+
+	// the planner rejects the folded configuration unless the collisions are allowed through its options
+	fs := frame.NewEmptySimpleFrameSystem("test")
+	fs.AddFrame(model, fs.World())
+	sFrames, err := fs.TracebackFrame(model)
+	test.That(t, err, test.ShouldBeNil)
+	sf, err := newSolverFrame(fs, sFrames, frame.World, frame.StartPositions(fs))
+	test.That(t, err, test.ShouldBeNil)
+	pm, err := newPlanManager(sf, fs, logger.Sugar(), 1)
+	test.That(t, err, test.ShouldBeNil)
+	for _, c := range []struct {
+		planningOpts map[string]interface{}
+		expected     bool
+	}{
+		{nil, false},
+		{map[string]interface{}{"allowed_collisions": []interface{}{
+			[]interface{}{"xArm6:wrist_link", "xArm6:base_top"},
+			[]interface{}{"xArm6:upper_arm", "xArm6:wrist_link"},
+		}}, true},
+	} {
+		opt, err := pm.plannerSetupFromMoveRequest(
+			spatial.NewZeroPose(), spatial.NewZeroPose(), sf.sliceToMap(zeroPos), nil, c.planningOpts,
+		)
+		test.That(t, err, test.ShouldBeNil)
+		pass, _ := opt.constraints[defaultCollisionConstraintName](&ConstraintInput{StartInput: folded, Frame: sf})
+		test.That(t, pass, test.ShouldEqual, c.expected)
+	}
+}
+
 var bt bool
 
 func BenchmarkCollisionConstraint(b *testing.B) {
@@ -226,7 +278,7 @@ func BenchmarkCollisionConstraint(b *testing.B) {
 	model, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
 	test.That(b, err, test.ShouldBeNil)
 	handler := &constraintHandler{}
-	handler.AddConstraint("collision", NewCollisionConstraint(model, zeroPos, obstacles, map[string]spatial.Geometry{}, false))
+	handler.AddConstraint("collision", NewCollisionConstraint(model, zeroPos, obstacles, map[string]spatial.Geometry{}, nil, false))
 
 	rseed := rand.New(rand.NewSource(1))
 	var b1 bool
@@ -7,3 +7,9 @@ var errIKSolve = errors.New("no IK solutions, check if goal outside workspace")
 var errPlannerFailed = errors.New("motion planner failed to find path")
 
 var errNoPlannerOptions = errors.New("PlannerOptions are required but have not been specified")
+
+// IsNoSolution returns whether err is the planner finding no IK solution or no path to the goal,
+// as opposed to failing to run.
+func IsNoSolution(err error) bool {
+	return errors.Is(err, errIKSolve) || errors.Is(err, errPlannerFailed)
+}
@@ -106,6 +106,9 @@ func (pm *planManager) PlanSingleWaypoint(ctx context.Context,
 		return nil, err
 	}
 	opts = append(opts, opt)
+	for _, opt := range opts {
+		timeCollisionChecks(ctx, opt)
+	}
 
 	resultSlices, err := pm.planMotion(ctx, goals, seed, opts, nil, 0)
 	if err != nil {
@@ -364,7 +367,17 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 	// not yet fully supported, but could be used by cbirrt
 	getColDepth := false
 
-	collisionConstraint, err := NewCollisionConstraintFromWorldState(pm.frame, pm.fs, worldState, seedMap, getColDepth)
+	// convert map to json, then to a struct, overwriting present defaults
+	jsonString, err := json.Marshal(planningOpts)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(jsonString, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	collisionConstraint, err := NewCollisionConstraintFromWorldState(pm.frame, pm.fs, worldState, seedMap, opt.AllowedCollisions, getColDepth)
 	if err != nil {
 		return nil, err
 	}
@@ -380,16 +393,6 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 		}
 	}
 
-	// convert map to json, then to a struct, overwriting present defaults
-	jsonString, err := json.Marshal(planningOpts)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(jsonString, opt)
-	if err != nil {
-		return nil, err
-	}
-
 	var planAlg string
 	alg, ok := planningOpts["planning_alg"]
 	if ok {
@@ -127,6 +127,9 @@ type plannerOptions struct {
 	// Number of cpu cores to use
 	NumThreads int `json:"num_threads"`
 
+	// Pairs of geometry names that may collide, such as adjacent links of an arm that are always touching
+	AllowedCollisions [][2]string `json:"allowed_collisions"`
+
 	// Function to use to measure distance between two inputs
 	// TODO(rb): this should really become a Metric once we change the way the constraint system works, its awkward to return 2 values here
 	DistanceFunc Constraint
@@ -0,0 +1,109 @@
+package motionplan
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+)
+
+// maxWorkspaceSamples bounds how many configurations SampleWorkspace will compute, the count grows as the number of
+// samples per joint to the power of the number of joints.
+const maxWorkspaceSamples = 10000000
+
+type voxel [3]int
+
+// Workspace is the set of positions a frame can reach, kept as the voxels its samples fell in.
+type Workspace struct {
+	voxelSizeMm float64
+	voxels      map[voxel]bool
+}
+
+// SampleWorkspace computes the position of the end of a frame at evenly spaced configurations covering the limits of
+// each of its joints, and records the voxels of the given size that those positions fall in.
+func SampleWorkspace(model referenceframe.Frame, samplesPerJoint int, voxelSizeMm float64) (*Workspace, error) {
+	if samplesPerJoint < 1 {
+		return nil, errors.New("samples per joint must be at least 1")
+	}
+	if voxelSizeMm <= 0 {
+		return nil, errors.New("voxel size must be greater than 0")
+	}
+	limits := model.DoF()
+	total := 1.
+	for _, limit := range limits {
+		if math.IsInf(limit.Min, 0) || math.IsInf(limit.Max, 0) {
+			return nil, errors.Errorf("cannot sample the unbounded joint limits of %s", model.Name())
+		}
+		total *= float64(samplesPerJoint)
+	}
+	if total > maxWorkspaceSamples {
+		return nil, errors.Errorf("sampling %d joints %d times each exceeds %d samples", len(limits), samplesPerJoint, maxWorkspaceSamples)
+	}
+
+	ws := &Workspace{voxelSizeMm: voxelSizeMm, voxels: map[voxel]bool{}}
+	// steps works like an odometer over the samples of each joint
+	steps := make([]int, len(limits))
+	inputs := make([]referenceframe.Input, len(limits))
+	for {
+		for i, limit := range limits {
+			inputs[i] = referenceframe.Input{Value: sampleLimit(limit, steps[i], samplesPerJoint)}
+		}
+		pose, err := model.Transform(inputs)
+		if err != nil {
+			return nil, err
+		}
+		ws.voxels[ws.voxelOf(pose.Point())] = true
+
+		i := 0
+		for ; i < len(steps); i++ {
+			steps[i]++
+			if steps[i] < samplesPerJoint {
+				break
+			}
+			steps[i] = 0
+		}
+		if i == len(steps) {
+			return ws, nil
+		}
+	}
+}
+
+// sampleLimit returns the value of a sample spaced evenly between the limits, a single sample lies in the middle.
+func sampleLimit(limit referenceframe.Limit, step, samples int) float64 {
+	switch {
+	case samples == 1:
+		return (limit.Min + limit.Max) / 2
+	case step == samples-1:
+		return limit.Max
+	default:
+		return limit.Min + (limit.Max-limit.Min)*float64(step)/float64(samples-1)
+	}
+}
+
+func (ws *Workspace) voxelOf(pt r3.Vector) voxel {
+	return voxel{
+		int(math.Floor(pt.X / ws.voxelSizeMm)),
+		int(math.Floor(pt.Y / ws.voxelSizeMm)),
+		int(math.Floor(pt.Z / ws.voxelSizeMm)),
+	}
+}
+
+// Reachable returns whether a sample of the workspace fell in the voxel of the given point.
+func (ws *Workspace) Reachable(pt r3.Vector) bool {
+	return ws.voxels[ws.voxelOf(pt)]
+}
+
+// PointCloud returns the centers of the voxels of the workspace.
+func (ws *Workspace) PointCloud() (pointcloud.PointCloud, error) {
+	pc := pointcloud.NewWithPrealloc(len(ws.voxels))
+	for v := range ws.voxels {
+		center := r3.Vector{X: float64(v[0]) + 0.5, Y: float64(v[1]) + 0.5, Z: float64(v[2]) + 0.5}.Mul(ws.voxelSizeMm)
+		if err := pc.Set(center, pointcloud.NewBasicData()); err != nil {
+			return nil, err
+		}
+	}
+	return pc, nil
+}
@@ -0,0 +1,68 @@
+package motionplan
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/pointcloud"
+	frame "go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+// twoLinkModel makes a planar arm turning about z, with a 100mm link followed by a 50mm link.
+func twoLinkModel(t *testing.T) frame.Model {
+	t.Helper()
+	joint := frame.Limit{Min: -math.Pi, Max: math.Pi}
+	shoulder, err := frame.NewRotationalFrame("shoulder", spatial.R4AA{RZ: 1}, joint)
+	test.That(t, err, test.ShouldBeNil)
+	upper, err := frame.NewStaticFrame("upper", spatial.NewPoseFromPoint(r3.Vector{X: 100}))
+	test.That(t, err, test.ShouldBeNil)
+	elbow, err := frame.NewRotationalFrame("elbow", spatial.R4AA{RZ: 1}, joint)
+	test.That(t, err, test.ShouldBeNil)
+	fore, err := frame.NewStaticFrame("fore", spatial.NewPoseFromPoint(r3.Vector{X: 50}))
+	test.That(t, err, test.ShouldBeNil)
+	m := frame.NewSimpleModel("two-link")
+	m.OrdTransforms = []frame.Frame{shoulder, upper, elbow, fore}
+	return m
+}
+
+func TestSampleWorkspace(t *testing.T) {
+	m := twoLinkModel(t)
+
+	_, err := SampleWorkspace(m, 0, 10)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = SampleWorkspace(m, 10, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = SampleWorkspace(m, maxWorkspaceSamples, 10)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	ws, err := SampleWorkspace(m, 181, 10)
+	test.That(t, err, test.ShouldBeNil)
+	// the arm reaches the ring between 50mm and 150mm from the shoulder
+	test.That(t, ws.Reachable(r3.Vector{X: 150}), test.ShouldBeTrue)
+	test.That(t, ws.Reachable(r3.Vector{X: 55}), test.ShouldBeTrue)
+	test.That(t, ws.Reachable(r3.Vector{X: -70, Y: 70}), test.ShouldBeTrue)
+	test.That(t, ws.Reachable(r3.Vector{}), test.ShouldBeFalse)
+	test.That(t, ws.Reachable(r3.Vector{X: 175}), test.ShouldBeFalse)
+	test.That(t, ws.Reachable(r3.Vector{X: 100, Z: 20}), test.ShouldBeFalse)
+
+	pc, err := ws.PointCloud()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldBeGreaterThan, 0)
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		dist := math.Hypot(p.X, p.Y)
+		test.That(t, dist, test.ShouldBeBetweenOrEqual, 50-10, 150+10)
+		test.That(t, ws.Reachable(p), test.ShouldBeTrue)
+		return true
+	})
+
+	// a coarser sampling reaches fewer voxels
+	coarse, err := SampleWorkspace(m, 5, 10)
+	test.That(t, err, test.ShouldBeNil)
+	coarsePC, err := coarse.PointCloud()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, coarsePC.Size(), test.ShouldBeLessThan, pc.Size())
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
 	"go.viam.com/utils"
 	"gonum.org/v1/gonum/diff/fd"
 	"gonum.org/v1/gonum/mat"
@@ -17,6 +18,26 @@ import (
 type IcpMergeResultInfo struct {
 	X0        []float64
 	OptResult optimize.Result
+	// Pose is the transform that registers the source pointcloud to the target.
+	Pose spatialmath.Pose
+}
+
+// TransformICP finds the transform that aligns a source pointcloud to a target pointcloud using ICP,
+// starting from an initial guess. The optimizer can report a failed line search even once the clouds
+// line up, so convergence is judged by the residual, the average distance between matched points,
+// which must not be more than maxResidual.
+func TransformICP(pcSrc, pcTarget PointCloud, guess spatialmath.Pose, maxResidual float64) (spatialmath.Pose, error) {
+	if pcSrc.Size() == 0 || pcTarget.Size() == 0 {
+		return nil, errors.New("cannot register empty pointclouds")
+	}
+	_, info, err := RegisterPointCloudICP(pcSrc, ToKDTree(pcTarget), guess, false, numThreadsPointCloud)
+	if err != nil {
+		return nil, err
+	}
+	if residual := info.OptResult.F; residual > maxResidual {
+		return nil, errors.Errorf("ICP did not converge, residual %f is more than %f", residual, maxResidual)
+	}
+	return info.Pose, nil
 }
 
 // RegisterPointCloudICP registers a source pointcloud to a target pointcloud, starting from an initial guess using ICP.
@@ -159,5 +180,5 @@ func RegisterPointCloudICP(pcSrc PointCloud, target *KDTree, guess spatialmath.P
 		return err == nil
 	})
 
-	return registeredPointCloud, IcpMergeResultInfo{X0: x0, OptResult: *res}, nil
+	return registeredPointCloud, IcpMergeResultInfo{X0: x0, OptResult: *res, Pose: pose}, nil
 }
@@ -51,3 +51,33 @@ func TestICPRegistration(t *testing.T) {
 
 	test.That(t, info.OptResult.F, test.ShouldBeLessThan, 20.)
 }
+
+func TestTransformICP(t *testing.T) {
+	target := New()
+	source := New()
+	for x := 0.; x < 10; x++ {
+		for y := 0.; y < 6; y++ {
+			z := x*x/5 + y/2 + float64(int(x*y)%3)
+			test.That(t, target.Set(NewVector(x, y, z), nil), test.ShouldBeNil)
+			test.That(t, source.Set(NewVector(x+0.5, y-0.3, z+0.2), nil), test.ShouldBeNil)
+		}
+	}
+
+	pose, err := TransformICP(source, target, spatialmath.NewZeroPose(), 1e-3)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.R3VectorAlmostEqual(pose.Point(), r3.Vector{-0.5, 0.3, -0.2}, 1e-3), test.ShouldBeTrue)
+
+	// a flat cloud can't be matched onto the curved one
+	flat := New()
+	for x := 0.; x < 10; x++ {
+		for y := 0.; y < 6; y++ {
+			test.That(t, flat.Set(NewVector(x, y, 0), nil), test.ShouldBeNil)
+		}
+	}
+	_, err = TransformICP(flat, target, spatialmath.NewZeroPose(), 1e-3)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "did not converge")
+
+	_, err = TransformICP(New(), target, spatialmath.NewZeroPose(), 1e-3)
+	test.That(t, err, test.ShouldNotBeNil)
+}
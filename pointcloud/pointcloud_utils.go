@@ -98,3 +98,37 @@ func StatisticalOutlierFilter(meanK int, stdDevThresh float64) (func(PointCloud)
 	}
 	return filterFunc, nil
 }
+
+// VoxelDownsample reduces a point cloud by splitting space into cubes of the given leaf size and
+// replacing the points in each cube by their centroid, which keeps the structure of the cloud.
+// The data of the first point seen in a cube is kept.
+func VoxelDownsample(cloud PointCloud, leafSize float64) (PointCloud, error) {
+	if leafSize <= 0 {
+		return nil, errors.Errorf("leaf size must be positive, got %f", leafSize)
+	}
+	type leaf struct {
+		sum  r3.Vector
+		n    float64
+		data Data
+	}
+	// voxel coordinates are taken from the origin so that the grid doesn't depend on the cloud
+	leaves := map[VoxelCoords]*leaf{}
+	cloud.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		coords := GetVoxelCoordinates(p, r3.Vector{}, leafSize)
+		l, ok := leaves[coords]
+		if !ok {
+			l = &leaf{data: d}
+			leaves[coords] = l
+		}
+		l.sum = l.sum.Add(p)
+		l.n++
+		return true
+	})
+	downsampled := NewWithPrealloc(len(leaves))
+	for _, l := range leaves {
+		if err := downsampled.Set(l.sum.Mul(1/l.n), l.data); err != nil {
+			return nil, err
+		}
+	}
+	return downsampled, nil
+}
@@ -1,6 +1,8 @@
 package pointcloud
 
 import (
+	"image/color"
+	"math"
 	"testing"
 
 	"github.com/golang/geo/r3"
@@ -70,3 +72,35 @@ func TestPrune(t *testing.T) {
 	test.That(t, len(clouds), test.ShouldEqual, 1)
 	test.That(t, clouds[0].Size(), test.ShouldEqual, 5)
 }
+
+func TestVoxelDownsample(t *testing.T) {
+	// 1000 points spaced 1 apart fill a 10x10x10 cube, with negative coordinates too
+	cloud := New()
+	for x := -6.; x < 4; x++ {
+		for y := -6.; y < 4; y++ {
+			for z := 0.; z < 10; z++ {
+				test.That(t, cloud.Set(NewVector(x, y, z), NewColoredData(color.NRGBA{255, 0, 0, 255})), test.ShouldBeNil)
+			}
+		}
+	}
+
+	downsampled, err := VoxelDownsample(cloud, 2)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, downsampled.Size(), test.ShouldEqual, 125)
+	downsampled.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		// each voxel holds 8 points that are 1 apart, so the centroid is in the middle of the voxel
+		test.That(t, math.Mod(p.X+100, 2), test.ShouldAlmostEqual, 0.5)
+		test.That(t, math.Mod(p.Y+100, 2), test.ShouldAlmostEqual, 0.5)
+		test.That(t, math.Mod(p.Z+100, 2), test.ShouldAlmostEqual, 0.5)
+		test.That(t, d.HasColor(), test.ShouldBeTrue)
+		return true
+	})
+	test.That(t, spatialmath.R3VectorAlmostEqual(CloudCentroid(downsampled), CloudCentroid(cloud), 1e-9), test.ShouldBeTrue)
+
+	same, err := VoxelDownsample(cloud, 0.5)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, same.Size(), test.ShouldEqual, cloud.Size())
+
+	_, err = VoxelDownsample(cloud, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
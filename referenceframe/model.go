@@ -120,6 +120,21 @@ func (m *SimpleModel) Geometries(inputs []Input) (*GeometriesInFrame, error) {
 	return NewGeometriesInFrame(m.name, geometryMap), errAll
 }
 
+// BoundingBox returns the smallest axis-aligned box, in the frame the model is attached to, that holds every link geometry
+// of the model at the given inputs.
+func BoundingBox(m Model, inputs []Input) (spatialmath.Geometry, error) {
+	// links without geometry are reported as errors alongside the geometries of the others, and are skipped
+	geometries, err := m.Geometries(inputs)
+	if geometries == nil {
+		return nil, err
+	}
+	all := make([]spatialmath.Geometry, 0, len(geometries.Geometries()))
+	for _, g := range geometries.Geometries() {
+		all = append(all, g)
+	}
+	return spatialmath.BoundingBox(all, m.Name()+":bounding_box")
+}
+
 // CachedTransform will check a sync.Map cache to see if the exact given set of inputs has been computed yet. If so
 // it returns without redoing the calculation. Thread safe, but so far has tended to be slightly slower than just doing
 // the calculation. This may change with higher DOF models and longer runtimes.
@@ -116,3 +116,39 @@ func TestModelGeometries(t *testing.T) {
 	link2 = geometries.Geometries()["test:link2"].Pose().Point()
 	test.That(t, spatial.R3VectorAlmostEqual(link2, r3.Vector{10, 0, 10}, 1e-8), test.ShouldBeTrue)
 }
+
+func TestBoundingBox(t *testing.T) {
+	// the ur5e is the arm of services/motion/data/moving_arm.json
+	m, err := ParseModelJSONFile(utils.ResolveFile("components/arm/universalrobots/ur5e.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	inputs := make([]Input, len(m.DoF()))
+	geometries, _ := m.Geometries(inputs)
+	test.That(t, geometries.Geometries(), test.ShouldNotBeEmpty)
+
+	bbox, err := BoundingBox(m, inputs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, bbox.Label(), test.ShouldEqual, m.Name()+":bounding_box")
+	test.That(t, spatial.OrientationAlmostEqual(bbox.Pose().Orientation(), spatial.NewZeroOrientation()), test.ShouldBeTrue)
+
+	// every link is inside the box, and every face of the box touches a link
+	lo := r3.Vector{X: math.Inf(1), Y: math.Inf(1), Z: math.Inf(1)}
+	hi := r3.Vector{X: math.Inf(-1), Y: math.Inf(-1), Z: math.Inf(-1)}
+	for _, g := range geometries.Geometries() {
+		encompassed, err := g.EncompassedBy(bbox)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, encompassed, test.ShouldBeTrue)
+		for _, v := range g.Vertices() {
+			lo = r3.Vector{X: math.Min(lo.X, v.X), Y: math.Min(lo.Y, v.Y), Z: math.Min(lo.Z, v.Z)}
+			hi = r3.Vector{X: math.Max(hi.X, v.X), Y: math.Max(hi.Y, v.Y), Z: math.Max(hi.Z, v.Z)}
+		}
+	}
+	expected, err := spatial.NewBox(spatial.NewPoseFromPoint(lo.Add(hi).Mul(0.5)), hi.Sub(lo), bbox.Label())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, bbox.AlmostEqual(expected), test.ShouldBeTrue)
+
+	// moving a joint moves the box
+	inputs[1] = Input{-math.Pi / 2}
+	moved, err := BoundingBox(m, inputs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moved.AlmostEqual(bbox), test.ShouldBeFalse)
+}
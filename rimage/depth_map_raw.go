@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"compress/gzip"
 	"encoding/binary"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -168,8 +169,33 @@ func readDepthMapFormat2(r *bufio.Reader) (*DepthMap, error) {
 	return &dm, nil
 }
 
+// RawDepthMapOptions choose how WriteRawDepthMapToFileWithOptions stores a depth map, trading
+// CPU for disk space. Every combination can be read back by ParseRawDepthMap.
+type RawDepthMapOptions struct {
+	// Compact stores each depth in 2 bytes instead of 8. Depths are 16 bit, so no precision is lost.
+	Compact bool
+	// GzipLevel is the compression level of files whose name ends in .gz, from gzip.HuffmanOnly
+	// to gzip.BestCompression. 0 means gzip.DefaultCompression; files not ending in .gz are not
+	// compressed at all.
+	GzipLevel int
+}
+
 // WriteRawDepthMapToFile writes the raw depth map to the given file.
-func WriteRawDepthMapToFile(dm *DepthMap, fn string) (err error) {
+func WriteRawDepthMapToFile(dm *DepthMap, fn string) error {
+	return WriteRawDepthMapToFileWithOptions(dm, fn, RawDepthMapOptions{})
+}
+
+// WriteRawDepthMapToFileWithOptions writes the raw depth map to the given file the way the
+// options say.
+func WriteRawDepthMapToFileWithOptions(dm *DepthMap, fn string, opts RawDepthMapOptions) (err error) {
+	level := opts.GzipLevel
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		return errors.Errorf("invalid gzip level %d", level)
+	}
+
 	//nolint:gosec
 	f, err := os.Create(fn)
 	if err != nil {
@@ -183,14 +209,21 @@ func WriteRawDepthMapToFile(dm *DepthMap, fn string) (err error) {
 	var out io.Writer = f
 
 	if filepath.Ext(fn) == ".gz" {
-		gout = gzip.NewWriter(f)
+		gout, err = gzip.NewWriterLevel(f, level)
+		if err != nil {
+			return err
+		}
 		out = gout
 		defer func() {
 			err = multierr.Combine(err, gout.Close())
 		}()
 	}
 
-	_, err = WriteRawDepthMapTo(dm, out)
+	if opts.Compact {
+		_, err = WriteCompactDepthMapTo(dm, out)
+	} else {
+		_, err = WriteRawDepthMapTo(dm, out)
+	}
 	if err != nil {
 		return err
 	}
@@ -236,3 +269,28 @@ func WriteRawDepthMapTo(dm *DepthMap, out io.Writer) (int64, error) {
 
 	return totalN, nil
 }
+
+// WriteCompactDepthMapTo writes this depth map to the given writer with 2 bytes per depth, in the
+// format ReadRawDepthMap knows by its VERSIONX header.
+func WriteCompactDepthMapTo(dm *DepthMap, out io.Writer) (int64, error) {
+	// depths are in millimeters and the format stores its units in meters
+	n, err := fmt.Fprintf(out, "VERSIONX\n2\n0.001\n%d\n%d\n", dm.width, dm.height)
+	totalN := int64(n)
+	if err != nil {
+		return totalN, err
+	}
+
+	row := make([]byte, 2*dm.width)
+	for y := 0; y < dm.height; y++ {
+		for x := 0; x < dm.width; x++ {
+			binary.LittleEndian.PutUint16(row[2*x:], uint16(dm.GetDepth(x, y)))
+		}
+		n, err = out.Write(row)
+		totalN += int64(n)
+		if err != nil {
+			return totalN, err
+		}
+	}
+
+	return totalN, nil
+}
@@ -3,6 +3,7 @@ package rimage
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"image"
 	"image/color"
@@ -10,6 +11,7 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"go.viam.com/test"
@@ -331,3 +333,39 @@ func TestDepthColorModel(t *testing.T) {
 	test.That(t, convGray, test.ShouldHaveSameTypeAs, gray)
 	test.That(t, convGray.(color.Gray16).Y, test.ShouldEqual, 6168)
 }
+
+func TestRawDepthMapOptions(t *testing.T) {
+	dm := NewEmptyDepthMap(40, 30)
+	for x := 0; x < dm.Width(); x++ {
+		for y := 0; y < dm.Height(); y++ {
+			dm.Set(x, y, Depth(1000+10*y+rand.Intn(10)))
+		}
+	}
+	dir := t.TempDir()
+	sizes := map[string]int64{}
+	for name, opts := range map[string]RawDepthMapOptions{
+		"raw.dat":             {},
+		"compact.dat":         {Compact: true},
+		"gzip.dat.gz":         {},
+		"gzip-fast.dat.gz":    {GzipLevel: gzip.BestSpeed},
+		"gzip-huffman.dat.gz": {GzipLevel: gzip.HuffmanOnly},
+		"compact-best.dat.gz": {Compact: true, GzipLevel: gzip.BestCompression},
+	} {
+		fn := filepath.Join(dir, name)
+		test.That(t, WriteRawDepthMapToFileWithOptions(dm, fn, opts), test.ShouldBeNil)
+		read, err := ParseRawDepthMap(fn)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, read.Width(), test.ShouldEqual, dm.Width())
+		test.That(t, read.Height(), test.ShouldEqual, dm.Height())
+		test.That(t, read.data, test.ShouldResemble, dm.data)
+		info, err := os.Stat(fn)
+		test.That(t, err, test.ShouldBeNil)
+		sizes[name] = info.Size()
+	}
+	test.That(t, sizes["compact.dat"], test.ShouldBeLessThan, sizes["raw.dat"]/3)
+	test.That(t, sizes["gzip.dat.gz"], test.ShouldBeLessThan, sizes["raw.dat"]/3)
+	test.That(t, sizes["compact-best.dat.gz"], test.ShouldBeLessThan, sizes["compact.dat"])
+
+	err := WriteRawDepthMapToFileWithOptions(dm, filepath.Join(dir, "bad.dat.gz"), RawDepthMapOptions{GzipLevel: 10})
+	test.That(t, err, test.ShouldNotBeNil)
+}
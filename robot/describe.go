@@ -0,0 +1,57 @@
+package robot
+
+import (
+	"context"
+
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils"
+)
+
+// ResourceDescription tells what kind of resource a resource on a robot is.
+type ResourceDescription struct {
+	Name resource.Name
+	// Subtype is the API the resource implements, like rdk:component:arm.
+	Subtype resource.Subtype
+	// Model is the model the resource was configured with, like fake. It is empty for resources
+	// of remote robots, since their config is not known locally.
+	Model string
+}
+
+// DescribeResource returns the subtype and model of the named resource of the given robot.
+func DescribeResource(ctx context.Context, r LocalRobot, name resource.Name) (ResourceDescription, error) {
+	found := false
+	for _, n := range r.ResourceNames() {
+		if n == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ResourceDescription{}, utils.NewResourceNotFoundError(name)
+	}
+	desc := ResourceDescription{Name: name, Subtype: name.Subtype}
+
+	cfg, err := r.Config(ctx)
+	if err != nil {
+		return ResourceDescription{}, err
+	}
+	for _, c := range cfg.Components {
+		if c.ResourceName() == name {
+			desc.Model = c.Model
+			return desc, nil
+		}
+	}
+	for _, s := range cfg.Services {
+		if s.ResourceName() == name {
+			desc.Model = s.Model
+			return desc, nil
+		}
+	}
+	for _, n := range resource.DefaultServices {
+		if n == name {
+			desc.Model = resource.DefaultModelName
+			return desc, nil
+		}
+	}
+	return desc, nil
+}
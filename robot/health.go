@@ -0,0 +1,68 @@
+package robot
+
+import (
+	"context"
+
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils"
+)
+
+// ResourceHealth describes whether a single resource on a robot is operational.
+type ResourceHealth struct {
+	Name    resource.Name
+	Healthy bool
+	// Status is the resource's status when it could be retrieved.
+	Status interface{}
+	// Err is the reason the resource is considered unhealthy, if any.
+	Err error
+}
+
+// Health reports the health of every resource on the given robot. A resource is healthy
+// when the robot can retrieve its status; this says nothing about whether the resource is
+// in a state the caller wants it in (e.g. a motor that is stopped is still healthy).
+//
+// Statuses are retrieved in a single batch. If that fails, or leaves out some resources,
+// those resources are retrieved individually so that one resource which errors is flagged
+// as unhealthy without hiding the health of the rest of the robot.
+func Health(ctx context.Context, r Robot) []ResourceHealth {
+	names := r.ResourceNames()
+	byName := make(map[resource.Name]Status, len(names))
+	if statuses, err := r.Status(ctx, names); err == nil {
+		for _, status := range statuses {
+			byName[status.Name] = status
+		}
+	}
+
+	health := make([]ResourceHealth, 0, len(names))
+	for _, name := range names {
+		resHealth := ResourceHealth{Name: name}
+		status, ok := byName[name]
+		if !ok {
+			statuses, err := r.Status(ctx, []resource.Name{name})
+			switch {
+			case err != nil:
+				resHealth.Err = err
+			case len(statuses) != 1:
+				resHealth.Err = utils.NewResourceNotFoundError(name)
+			default:
+				status, ok = statuses[0], true
+			}
+		}
+		if ok {
+			resHealth.Healthy = true
+			resHealth.Status = status.Status
+		}
+		health = append(health, resHealth)
+	}
+	return health
+}
+
+// Healthy returns whether every resource in the given health report is healthy.
+func Healthy(health []ResourceHealth) bool {
+	for _, h := range health {
+		if !h.Healthy {
+			return false
+		}
+	}
+	return true
+}
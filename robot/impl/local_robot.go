@@ -17,6 +17,7 @@ import (
 	"go.viam.com/utils/pexec"
 	"go.viam.com/utils/rpc"
 
+	"go.viam.com/rdk/components/base"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/discovery"
 	"go.viam.com/rdk/operation"
@@ -64,6 +65,14 @@ type localRobot struct {
 	triggerConfig              chan bool
 	configTimer                *time.Ticker
 	revealSensitiveConfigDiffs bool
+
+	// retryDue is signaled when a component waiting on a dependency is due to be retried.
+	retryDue   chan struct{}
+	retryMu    sync.Mutex
+	retryTimer *time.Timer
+
+	// speedLimiter caps the speeds of every base of the robot while safe mode is on.
+	speedLimiter *base.SpeedLimiter
 }
 
 // webService returns the localRobot's web service. Raises if the service has not been initialized.
@@ -151,6 +160,11 @@ func (r *localRobot) Close(ctx context.Context) error {
 		if r.configTimer != nil {
 			r.configTimer.Stop()
 		}
+		r.retryMu.Lock()
+		if r.retryTimer != nil {
+			r.retryTimer.Stop()
+		}
+		r.retryMu.Unlock()
 		close(r.triggerConfig)
 	}
 	r.activeBackgroundWorkers.Wait()
@@ -368,6 +382,9 @@ func newWithResources(
 	for _, opt := range opts {
 		opt.apply(&rOpts)
 	}
+	if len(rOpts.criticalResources) != 0 && rOpts.dependencyRetry != nil && rOpts.dependencyRetry.maxAttempts <= 0 {
+		return nil, errors.New("critical resources require the dependency retry policy to have a maximum number of attempts")
+	}
 	closeCtx, cancel := context.WithCancel(ctx)
 	r := &localRobot{
 		manager: newResourceManager(
@@ -377,6 +394,7 @@ func newWithResources(
 				allowInsecureCreds: cfg.AllowInsecureCreds,
 				untrustedEnv:       cfg.UntrustedEnv,
 				tlsConfig:          cfg.Network.TLSConfig,
+				dependencyRetry:    rOpts.dependencyRetry,
 			},
 			logger,
 		),
@@ -390,7 +408,10 @@ func newWithResources(
 		triggerConfig:              make(chan bool),
 		configTimer:                nil,
 		revealSensitiveConfigDiffs: rOpts.revealSensitiveConfigDiffs,
+		retryDue:                   make(chan struct{}, 1),
+		speedLimiter:               &base.SpeedLimiter{},
 	}
+	r.applySafeMode(cfg.SafeMode)
 	var heartbeatWindow time.Duration
 	if cfg.Network.Sessions.HeartbeatWindow == 0 {
 		heartbeatWindow = config.DefaultSessionHeartbeatWindow
@@ -445,17 +466,22 @@ func newWithResources(
 			if closeCtx.Err() != nil {
 				return
 			}
+			// dependency retries only complete the config, remotes are refreshed on the regular timer
+			refreshRemotes := true
 			select {
 			case <-closeCtx.Done():
 				return
 			case <-r.triggerConfig:
 			case <-r.configTimer.C:
+			case <-r.retryDue:
+				refreshRemotes = false
 			}
 			if r.manager.anyResourcesNotConfigured() {
 				r.manager.completeConfig(closeCtx, r)
+				r.scheduleDependencyRetry()
 				r.updateDefaultServices(ctx)
 			}
-			if r.manager.updateRemotesResourceNames(ctx, r) {
+			if refreshRemotes && r.manager.updateRemotesResourceNames(ctx, r) {
 				r.updateDefaultServices(ctx)
 			}
 		}
@@ -477,10 +503,81 @@ func newWithResources(
 		r.updateDefaultServices(ctx)
 	}
 
+	if err := r.checkCriticalResources(ctx, rOpts.criticalResources); err != nil {
+		return nil, err
+	}
+
 	successful = true
 	return r, nil
 }
 
+// scheduleDependencyRetry arms a timer that wakes up the config loop once the earliest
+// component waiting on a dependency is due to be retried.
+func (r *localRobot) scheduleDependencyRetry() {
+	next, ok := r.manager.nextRetry()
+	r.retryMu.Lock()
+	defer r.retryMu.Unlock()
+	if r.retryTimer != nil {
+		r.retryTimer.Stop()
+		r.retryTimer = nil
+	}
+	if !ok || r.closeContext.Err() != nil {
+		return
+	}
+	r.retryTimer = time.AfterFunc(time.Until(next), func() {
+		select {
+		case r.retryDue <- struct{}{}:
+		default:
+		}
+	})
+}
+
+// checkCriticalResources logs every resource that is unavailable after startup and
+// returns an error if any of the given critical resources is among them. Critical
+// resources that are still being retried under the dependency retry policy are waited
+// on until they are either built or given up on.
+func (r *localRobot) checkCriticalResources(ctx context.Context, critical []resource.Name) error {
+	isCritical := make(map[resource.Name]bool, len(critical))
+	for _, name := range critical {
+		isCritical[name] = true
+	}
+	for r.manager.anyRetrying(critical...) {
+		if !goutils.SelectContextOrWait(ctx, r.manager.opts.dependencyRetry.interval) {
+			return ctx.Err()
+		}
+	}
+	for name, err := range r.manager.unavailableResources() {
+		if !isCritical[name] {
+			r.logger.Warnw("continuing without unavailable resource", "resource", name, "error", err)
+		}
+	}
+
+	var allErrs error
+	for _, name := range critical {
+		if _, err := r.ResourceByName(name); err != nil {
+			allErrs = multierr.Combine(allErrs, errors.Wrapf(err, "critical resource %q unavailable", name))
+		}
+	}
+	return allErrs
+}
+
+// applySafeMode caps the speeds of the bases of the robot as safe mode asks, or lifts the caps
+// when it is off.
+func (r *localRobot) applySafeMode(safeMode *config.SafeModeConfig) {
+	if safeMode == nil {
+		r.speedLimiter.SetLimits(0, 0)
+		return
+	}
+	maxMMPerSec, maxDegsPerSec := safeMode.MaxMMPerSec, safeMode.MaxDegsPerSec
+	if maxMMPerSec == 0 {
+		maxMMPerSec = config.DefaultSafeModeMMPerSec
+	}
+	if maxDegsPerSec == 0 {
+		maxDegsPerSec = config.DefaultSafeModeDegsPerSec
+	}
+	r.speedLimiter.SetLimits(maxMMPerSec, maxDegsPerSec)
+}
+
 // New returns a new robot with parts sourced from the given config.
 func New(
 	ctx context.Context,
@@ -571,6 +668,10 @@ func (r *localRobot) newResource(ctx context.Context, config config.Component) (
 	if err != nil {
 		return nil, err
 	}
+	// every base is limited so that safe mode can be turned on and off without rebuilding them
+	if b, ok := newResource.(base.Base); ok && rName.Subtype == base.Subtype {
+		newResource = base.LimitSpeeds(b, r.speedLimiter)
+	}
 
 	c := registry.ResourceSubtypeLookup(rName.Subtype)
 	if c == nil || c.Reconfigurable == nil {
@@ -749,6 +850,7 @@ func (r *localRobot) Reconfigure(ctx context.Context, newConfig *config.Config)
 	var allErrs error
 
 	newConfig = r.updateDefaultServiceNames(newConfig)
+	r.applySafeMode(newConfig.SafeMode)
 	diff, err := config.DiffConfigs(*r.config, *newConfig, r.revealSensitiveConfigDiffs)
 	if err != nil {
 		r.logger.Errorw("error diffing the configs", "error", err)
@@ -804,6 +906,7 @@ func (r *localRobot) Reconfigure(ctx context.Context, newConfig *config.Config)
 	allErrs = multierr.Combine(allErrs, filtered.Close(ctx))
 	// Third we attempt to complete the config (see function for details)
 	r.manager.completeConfig(ctx, r)
+	r.scheduleDependencyRetry()
 	r.updateDefaultServices(ctx)
 	if allErrs != nil {
 		r.logger.Errorw("the following errors were gathered during reconfiguration", "errors", allErrs)
@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -37,6 +38,7 @@ import (
 	"go.viam.com/rdk/components/board"
 	"go.viam.com/rdk/components/camera"
 	"go.viam.com/rdk/components/gripper"
+	"go.viam.com/rdk/components/motor"
 	"go.viam.com/rdk/components/movementsensor"
 	_ "go.viam.com/rdk/components/register"
 	"go.viam.com/rdk/config"
@@ -2149,3 +2151,298 @@ func TestCheckMaxInstanceSkipRemote(t *testing.T) {
 	_, err = r.ResourceByName(datamanager.Named("remote:builtin"))
 	test.That(t, err, test.ShouldBeNil)
 }
+
+func TestDependencyRetry(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	modelName := utils.RandomAlphaString(8)
+	var boardAttempts int32
+	registry.RegisterComponent(
+		board.Subtype,
+		modelName,
+		registry.Component{Constructor: func(
+			ctx context.Context,
+			deps registry.Dependencies,
+			config config.Component,
+			logger golog.Logger,
+		) (interface{}, error) {
+			// the board only shows up after a few attempts
+			if atomic.AddInt32(&boardAttempts, 1) < 4 {
+				return nil, errors.New("board not available yet")
+			}
+			return &dummyBoard{}, nil
+		}})
+
+	cfg := &config.Config{
+		Components: []config.Component{
+			{
+				Name:      "board1",
+				Model:     modelName,
+				Namespace: resource.ResourceNamespaceRDK,
+				Type:      board.SubtypeName,
+			},
+			{
+				Name:      "motor1",
+				Model:     "fake",
+				Namespace: resource.ResourceNamespaceRDK,
+				Type:      motor.SubtypeName,
+				DependsOn: []string{"board1"},
+			},
+		},
+	}
+
+	t.Run("dependency eventually appears", func(t *testing.T) {
+		r, err := robotimpl.New(ctx, cfg, logger, robotimpl.WithDependencyRetry(10*time.Millisecond, 50*time.Millisecond, 50))
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, r.Close(ctx), test.ShouldBeNil)
+		}()
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			_, err := r.ResourceByName(motor.Named("motor1"))
+			test.That(tb, err, test.ShouldBeNil)
+		})
+		_, err = r.ResourceByName(board.Named("board1"))
+		test.That(t, err, test.ShouldBeNil)
+	})
+
+	missingCfg := &config.Config{
+		Components: []config.Component{
+			{
+				Name:      "motor1",
+				Model:     "fake",
+				Namespace: resource.ResourceNamespaceRDK,
+				Type:      motor.SubtypeName,
+				DependsOn: []string{"board1"},
+			},
+		},
+	}
+
+	t.Run("dependency never appears", func(t *testing.T) {
+		r, err := robotimpl.New(ctx, missingCfg, logger, robotimpl.WithDependencyRetry(10*time.Millisecond, 20*time.Millisecond, 3))
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, r.Close(ctx), test.ShouldBeNil)
+		}()
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			_, err := r.ResourceByName(motor.Named("motor1"))
+			test.That(tb, err, test.ShouldNotBeNil)
+			test.That(tb, err.Error(), test.ShouldContainSubstring, "gave up waiting for dependency \"board1\" after 3 attempts")
+		})
+	})
+
+	t.Run("dependency added after giving up", func(t *testing.T) {
+		r, err := robotimpl.New(ctx, missingCfg, logger, robotimpl.WithDependencyRetry(10*time.Millisecond, 20*time.Millisecond, 3))
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, r.Close(ctx), test.ShouldBeNil)
+		}()
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			_, err := r.ResourceByName(motor.Named("motor1"))
+			test.That(tb, err, test.ShouldNotBeNil)
+			test.That(tb, err.Error(), test.ShouldContainSubstring, "gave up waiting for dependency")
+		})
+
+		addedCfg := &config.Config{
+			Components: append([]config.Component{
+				{
+					Name:      "board1",
+					Model:     modelName,
+					Namespace: resource.ResourceNamespaceRDK,
+					Type:      board.SubtypeName,
+				},
+			}, missingCfg.Components...),
+		}
+		r.Reconfigure(ctx, addedCfg)
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			_, err := r.ResourceByName(motor.Named("motor1"))
+			test.That(tb, err, test.ShouldBeNil)
+		})
+	})
+}
+
+func TestMissingOptionalResource(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	cfg := &config.Config{
+		Components: []config.Component{
+			{
+				Name:      "arm1",
+				Model:     "fake",
+				Namespace: resource.ResourceNamespaceRDK,
+				Type:      arm.SubtypeName,
+			},
+			{
+				Name:      "front",
+				Model:     "does-not-exist",
+				Namespace: resource.ResourceNamespaceRDK,
+				Type:      camera.SubtypeName,
+			},
+		},
+	}
+
+	r, err := robotimpl.New(ctx, cfg, logger, robotimpl.WithCriticalResources(arm.Named("arm1")))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(arm.Named("arm1"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(camera.Named("front"))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, r.Close(ctx), test.ShouldBeNil)
+
+	_, err = robotimpl.New(ctx, cfg, logger, robotimpl.WithCriticalResources(arm.Named("arm1"), camera.Named("front")))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "critical resource")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "front")
+
+	// only the optional resource is warned about, the critical one fails startup
+	observedLogger, logs := golog.NewObservedTestLogger(t)
+	cfg.Components = append(cfg.Components, config.Component{
+		Name:      "back",
+		Model:     "does-not-exist",
+		Namespace: resource.ResourceNamespaceRDK,
+		Type:      camera.SubtypeName,
+	})
+	_, err = robotimpl.New(ctx, cfg, observedLogger, robotimpl.WithCriticalResources(camera.Named("front")))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "front")
+	test.That(t, err.Error(), test.ShouldNotContainSubstring, "back")
+	warnings := logs.FilterMessage("continuing without unavailable resource").All()
+	test.That(t, warnings, test.ShouldHaveLength, 1)
+	test.That(t, warnings[0].ContextMap()["resource"], test.ShouldEqual, camera.Named("back").String())
+}
+
+func TestCriticalResourceRetry(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	modelName := utils.RandomAlphaString(8)
+	var boardAttempts int32
+	registry.RegisterComponent(
+		board.Subtype,
+		modelName,
+		registry.Component{Constructor: func(
+			ctx context.Context,
+			deps registry.Dependencies,
+			config config.Component,
+			logger golog.Logger,
+		) (interface{}, error) {
+			if atomic.AddInt32(&boardAttempts, 1) < 4 {
+				return nil, errors.New("board not available yet")
+			}
+			return &dummyBoard{}, nil
+		}})
+
+	cfg := &config.Config{
+		Components: []config.Component{
+			{
+				Name:      "board1",
+				Model:     modelName,
+				Namespace: resource.ResourceNamespaceRDK,
+				Type:      board.SubtypeName,
+			},
+			{
+				Name:      "motor1",
+				Model:     "fake",
+				Namespace: resource.ResourceNamespaceRDK,
+				Type:      motor.SubtypeName,
+				DependsOn: []string{"board1"},
+			},
+		},
+	}
+
+	_, err := robotimpl.New(ctx, cfg, logger,
+		robotimpl.WithDependencyRetry(10*time.Millisecond, 20*time.Millisecond, 0),
+		robotimpl.WithCriticalResources(motor.Named("motor1")))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "maximum number of attempts")
+
+	r, err := robotimpl.New(ctx, cfg, logger,
+		robotimpl.WithDependencyRetry(10*time.Millisecond, 20*time.Millisecond, 50),
+		robotimpl.WithCriticalResources(motor.Named("motor1")))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(motor.Named("motor1"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, r.Close(ctx), test.ShouldBeNil)
+}
+
+func TestSafeMode(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	var mmPerSec, degsPerSec float64
+	var spinExtra map[string]interface{}
+	modelName := utils.RandomAlphaString(8)
+	registry.RegisterComponent(
+		base.Subtype,
+		modelName,
+		registry.Component{Constructor: func(
+			ctx context.Context,
+			deps registry.Dependencies,
+			config config.Component,
+			logger golog.Logger,
+		) (interface{}, error) {
+			b := &inject.Base{}
+			b.MoveStraightFunc = func(ctx context.Context, distanceMm int, speed float64, extra map[string]interface{}) error {
+				mmPerSec = speed
+				return nil
+			}
+			b.SpinFunc = func(ctx context.Context, angleDeg, speed float64, extra map[string]interface{}) error {
+				degsPerSec = speed
+				spinExtra = extra
+				return nil
+			}
+			return b, nil
+		}})
+
+	cfg := &config.Config{
+		Components: []config.Component{
+			{
+				Name:      "base1",
+				Model:     modelName,
+				Namespace: resource.ResourceNamespaceRDK,
+				Type:      base.SubtypeName,
+			},
+		},
+		SafeMode: &config.SafeModeConfig{},
+	}
+	r, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(ctx), test.ShouldBeNil)
+	}()
+	b, err := base.FromRobot(r, "base1")
+	test.That(t, err, test.ShouldBeNil)
+
+	// commands above the caps are limited, the ones below are not
+	test.That(t, b.MoveStraight(ctx, 1000, -500, nil), test.ShouldBeNil)
+	test.That(t, mmPerSec, test.ShouldEqual, -config.DefaultSafeModeMMPerSec)
+	test.That(t, b.MoveStraight(ctx, 1000, 50, nil), test.ShouldBeNil)
+	test.That(t, mmPerSec, test.ShouldEqual, 50)
+	test.That(t, b.Spin(ctx, 90, 180, map[string]interface{}{"no_spin_floor": false}), test.ShouldBeNil)
+	test.That(t, degsPerSec, test.ShouldEqual, config.DefaultSafeModeDegsPerSec)
+	// the speed floor of spins would push them past the cap
+	test.That(t, spinExtra, test.ShouldResemble, map[string]interface{}{"no_spin_floor": true})
+
+	// safe mode can be changed and turned off without rebuilding the base
+	newCfg := *cfg
+	newCfg.SafeMode = &config.SafeModeConfig{MaxMMPerSec: 200, MaxDegsPerSec: 10}
+	r.Reconfigure(ctx, &newCfg)
+	test.That(t, b.MoveStraight(ctx, 1000, 500, nil), test.ShouldBeNil)
+	test.That(t, mmPerSec, test.ShouldEqual, 200)
+
+	newCfg.SafeMode = nil
+	r.Reconfigure(ctx, &newCfg)
+	test.That(t, b.MoveStraight(ctx, 1000, 500, nil), test.ShouldBeNil)
+	test.That(t, mmPerSec, test.ShouldEqual, 500)
+	test.That(t, b.Spin(ctx, 90, 180, nil), test.ShouldBeNil)
+	test.That(t, degsPerSec, test.ShouldEqual, 180)
+	test.That(t, spinExtra, test.ShouldBeNil)
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/jhump/protoreflect/desc"
@@ -60,6 +61,11 @@ type resourcePlaceholder struct {
 	real   interface{}
 	config interface{}
 	err    error
+
+	// attempts and nextAttempt track retries of a component whose dependencies
+	// are not ready when a dependency retry policy is in use.
+	attempts    int
+	nextAttempt time.Time
 }
 
 type resourceManagerOptions struct {
@@ -68,6 +74,7 @@ type resourceManagerOptions struct {
 	allowInsecureCreds bool
 	untrustedEnv       bool
 	tlsConfig          *tls.Config
+	dependencyRetry    *dependencyRetry
 }
 
 func (w *resourcePlaceholder) Close(ctx context.Context) error {
@@ -252,6 +259,25 @@ func (manager *resourceManager) anyResourcesNotConfigured() bool {
 	return false
 }
 
+// unavailableResources returns the local resources that have not been built yet along
+// with the reason why.
+func (manager *resourceManager) unavailableResources() map[resource.Name]error {
+	unavailable := map[resource.Name]error{}
+	for _, name := range manager.resources.Names() {
+		if name.ResourceType == remoteTypeName || name.ContainsRemoteNames() {
+			continue
+		}
+		iface, ok := manager.resources.Node(name)
+		if !ok {
+			continue
+		}
+		if ph, ok := iface.(*resourcePlaceholder); ok {
+			unavailable[name] = ph.err
+		}
+	}
+	return unavailable
+}
+
 // ResourceNames returns the names of all resources in the manager.
 func (manager *resourceManager) ResourceNames() []resource.Name {
 	names := []resource.Name{}
@@ -336,7 +362,8 @@ func (manager *resourceManager) mergeResourceRPCSubtypesWithRemote(r robot.Robot
 	}
 }
 
-// Close attempts to close/stop all parts.
+// Close attempts to close/stop all parts. Resources close before the resources they depend on,
+// so a motor closes before its board.
 func (manager *resourceManager) Close(ctx context.Context) error {
 	var allErrs error
 	if err := manager.processManager.Stop(); err != nil {
@@ -377,6 +404,9 @@ func (manager *resourceManager) completeConfig(
 		}
 		manager.logger.Debugw("we are now handling the resource", "resource", r)
 		if c, ok := wrap.config.(config.Component); ok {
+			if !manager.shouldAttempt(wrap) {
+				continue
+			}
 			_, err := c.Validate("")
 			if err != nil {
 				wrap.err = errors.Wrap(err, "Config validation error found in component: "+c.Name)
@@ -386,9 +416,11 @@ func (manager *resourceManager) completeConfig(
 			if err != nil {
 				manager.logger.Errorw("error building component", "resource", c.ResourceName(), "model", c.Model, "error", err)
 				wrap.err = errors.Wrap(err, "component build error")
+				manager.scheduleRetry(r, wrap, err)
 				continue
 			}
 			manager.resources.AddNode(r, iface)
+			manager.resetRetries(manager.resources.GetAllChildrenOf(r)...)
 		} else if s, ok := wrap.config.(config.Service); ok {
 			_, err := s.Validate("")
 			if err != nil {
@@ -402,6 +434,7 @@ func (manager *resourceManager) completeConfig(
 				continue
 			}
 			manager.resources.AddNode(r, iface)
+			manager.resetRetries(manager.resources.GetAllChildrenOf(r)...)
 		} else if rc, ok := wrap.config.(config.Remote); ok {
 			err := rc.Validate("")
 			if err != nil {
@@ -433,6 +466,91 @@ func (manager *resourceManager) completeConfig(
 	}
 }
 
+// shouldAttempt returns whether a component placeholder is due to be built. Without a
+// dependency retry policy every placeholder is attempted on every pass.
+func (manager *resourceManager) shouldAttempt(wrap *resourcePlaceholder) bool {
+	retry := manager.opts.dependencyRetry
+	if retry == nil {
+		return true
+	}
+	if retry.maxAttempts > 0 && wrap.attempts >= retry.maxAttempts {
+		return false
+	}
+	return !time.Now().Before(wrap.nextAttempt)
+}
+
+// scheduleRetry records a failed build caused by a dependency that is not ready yet and
+// schedules the next attempt according to the dependency retry policy, if any.
+func (manager *resourceManager) scheduleRetry(name resource.Name, wrap *resourcePlaceholder, err error) {
+	retry := manager.opts.dependencyRetry
+	var depErr *registry.DependencyNotReadyError
+	if retry == nil || !errors.As(err, &depErr) {
+		return
+	}
+	wrap.attempts++
+	if retry.maxAttempts > 0 && wrap.attempts >= retry.maxAttempts {
+		wrap.err = errors.Wrapf(wrap.err, "gave up waiting for dependency %q after %d attempts", depErr.Name, wrap.attempts)
+		manager.logger.Errorw("giving up building resource", "resource", name, "dependency", depErr.Name, "attempts", wrap.attempts)
+		wrap.nextAttempt = time.Time{}
+		return
+	}
+	wrap.nextAttempt = time.Now().Add(retry.backoff(wrap.attempts))
+}
+
+// nextRetry returns the earliest time at which a component waiting on a dependency
+// is due to be attempted again, if any is.
+func (manager *resourceManager) nextRetry() (time.Time, bool) {
+	manager.configLock.Lock()
+	defer manager.configLock.Unlock()
+	var next time.Time
+	for _, name := range manager.resources.Names() {
+		iface, ok := manager.resources.Node(name)
+		if !ok {
+			continue
+		}
+		wrap, ok := iface.(*resourcePlaceholder)
+		if !ok || wrap.nextAttempt.IsZero() {
+			continue
+		}
+		if next.IsZero() || wrap.nextAttempt.Before(next) {
+			next = wrap.nextAttempt
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// anyRetrying returns whether any of the given resources is still waiting on a dependency
+// and due to be retried.
+func (manager *resourceManager) anyRetrying(names ...resource.Name) bool {
+	manager.configLock.Lock()
+	defer manager.configLock.Unlock()
+	for _, name := range names {
+		iface, ok := manager.resources.Node(name)
+		if !ok {
+			continue
+		}
+		if wrap, ok := iface.(*resourcePlaceholder); ok && !wrap.nextAttempt.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// resetRetries clears the retry state of the given placeholders so that they are
+// attempted again on the next pass, including those that were given up on.
+func (manager *resourceManager) resetRetries(names ...resource.Name) {
+	for _, name := range names {
+		iface, ok := manager.resources.Node(name)
+		if !ok {
+			continue
+		}
+		if wrap, ok := iface.(*resourcePlaceholder); ok {
+			wrap.attempts = 0
+			wrap.nextAttempt = time.Time{}
+		}
+	}
+}
+
 // cleanAppImageEnv attempts to revert environment variable changes so
 // normal, non-AppImage processes can be executed correctly.
 func cleanAppImageEnv() error {
@@ -752,6 +870,9 @@ func (manager *resourceManager) updateResources(
 		rName := fromRemoteNameToRemoteNodeName(r.Name)
 		allErrs = multierr.Combine(allErrs, manager.wrapResource(rName, r, []string{}, fn))
 	}
+	// the graph may now hold a dependency that previously failed resources were waiting on
+	manager.resetRetries(manager.resources.Names()...)
+
 	// processes are not added into the resource tree as they belong to a process manager
 	if err := cleanAppImageEnv(); err != nil {
 		manager.logger.Errorw("error cleaning up app image environement", "error", err)
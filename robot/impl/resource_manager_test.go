@@ -7,6 +7,7 @@ import (
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/google/go-cmp/cmp"
@@ -435,6 +436,44 @@ func TestManagerWithSameNameInBaseAndRemote(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestDependencyRetryBackoff(t *testing.T) {
+	retry := &dependencyRetry{interval: 10 * time.Millisecond, maxInterval: 50 * time.Millisecond}
+	test.That(t, retry.backoff(1), test.ShouldEqual, 10*time.Millisecond)
+	test.That(t, retry.backoff(2), test.ShouldEqual, 20*time.Millisecond)
+	test.That(t, retry.backoff(3), test.ShouldEqual, 40*time.Millisecond)
+	test.That(t, retry.backoff(4), test.ShouldEqual, 50*time.Millisecond)
+	test.That(t, retry.backoff(100), test.ShouldEqual, 50*time.Millisecond)
+}
+
+func TestManagerDependencyRetryAttempts(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	manager := newResourceManager(resourceManagerOptions{
+		dependencyRetry: &dependencyRetry{maxAttempts: 3},
+	}, logger)
+	name := arm.Named("arm1")
+	wrap := &resourcePlaceholder{err: errors.New("resource not initialized yet")}
+	manager.addResource(name, wrap)
+	depErr := &registry.DependencyNotReadyError{Name: "board1"}
+
+	attempts := 0
+	for i := 0; i < 10 && manager.shouldAttempt(wrap); i++ {
+		attempts++
+		manager.scheduleRetry(name, wrap, depErr)
+	}
+	test.That(t, attempts, test.ShouldEqual, 3)
+	test.That(t, wrap.err.Error(), test.ShouldContainSubstring, "gave up waiting for dependency \"board1\" after 3 attempts")
+	_, ok := manager.nextRetry()
+	test.That(t, ok, test.ShouldBeFalse)
+
+	// errors other than missing dependencies are not counted as attempts
+	other := &resourcePlaceholder{}
+	manager.scheduleRetry(name, other, errors.New("bad config"))
+	test.That(t, other.attempts, test.ShouldEqual, 0)
+
+	manager.resetRetries(name)
+	test.That(t, manager.shouldAttempt(wrap), test.ShouldBeTrue)
+}
+
 func TestManagerAdd(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	manager := newResourceManager(resourceManagerOptions{}, logger)
@@ -518,6 +557,36 @@ func TestManagerAdd(t *testing.T) {
 	test.That(t, objectSegmentationService, test.ShouldEqual, injectVisionService)
 }
 
+// A closeRecorder records when it is closed.
+type closeRecorder struct {
+	name   string
+	closed *[]string
+}
+
+func (c *closeRecorder) Close(ctx context.Context) error {
+	*c.closed = append(*c.closed, c.name)
+	return nil
+}
+
+func TestManagerCloseOrder(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	manager := newResourceManager(resourceManagerOptions{}, logger)
+
+	var closed []string
+	boardName := board.Named("board1")
+	motorName := motor.Named("motor1")
+	baseName := base.Named("base1")
+	manager.addResource(baseName, &closeRecorder{"base1", &closed})
+	manager.addResource(boardName, &closeRecorder{"board1", &closed})
+	manager.addResource(motorName, &closeRecorder{"motor1", &closed})
+	test.That(t, manager.resources.AddChildren(motorName, boardName), test.ShouldBeNil)
+	test.That(t, manager.resources.AddChildren(baseName, motorName), test.ShouldBeNil)
+
+	// dependents close before what they depend on
+	test.That(t, manager.Close(context.Background()), test.ShouldBeNil)
+	test.That(t, closed, test.ShouldResemble, []string{"base1", "motor1", "board1"})
+}
+
 func TestManagerNewComponent(t *testing.T) {
 	cfg := &config.Config{
 		Components: []config.Component{
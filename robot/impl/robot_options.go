@@ -1,6 +1,11 @@
 package robotimpl
 
-import "go.viam.com/rdk/robot/web"
+import (
+	"time"
+
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/web"
+)
 
 // options configures a Robot.
 type options struct {
@@ -10,6 +15,34 @@ type options struct {
 	// revealSensitiveConfigDiffs will display config diffs - which may contain secret
 	// information - in log statements
 	revealSensitiveConfigDiffs bool
+
+	// dependencyRetry, if set, controls how components whose dependencies are
+	// not ready yet are retried.
+	dependencyRetry *dependencyRetry
+
+	// criticalResources must be available for the robot to start.
+	criticalResources []resource.Name
+}
+
+// dependencyRetry describes how often and how many times a component whose
+// dependencies are not ready yet should be retried.
+type dependencyRetry struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	maxAttempts int
+}
+
+// backoff returns how long to wait before the next attempt after the given
+// number of failed attempts.
+func (dr *dependencyRetry) backoff(attempts int) time.Duration {
+	wait := dr.interval
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= dr.maxInterval {
+			return dr.maxInterval
+		}
+	}
+	return wait
 }
 
 // Option configures how we set up the web service.
@@ -50,3 +83,31 @@ func WithRevealSensitiveConfigDiffs() Option {
 		o.revealSensitiveConfigDiffs = true
 	})
 }
+
+// WithDependencyRetry returns an Option which retries building components
+// whose dependencies are not available yet. Attempts start interval apart and
+// back off exponentially up to maxInterval. After maxAttempts failed attempts
+// the component is given up on and reports why; a maxAttempts of 0 retries forever.
+func WithDependencyRetry(interval, maxInterval time.Duration, maxAttempts int) Option {
+	return newFuncOption(func(o *options) {
+		if maxInterval < interval {
+			maxInterval = interval
+		}
+		o.dependencyRetry = &dependencyRetry{
+			interval:    interval,
+			maxInterval: maxInterval,
+			maxAttempts: maxAttempts,
+		}
+	})
+}
+
+// WithCriticalResources returns an Option which requires the given resources to be
+// available once the robot has started; otherwise creating the robot fails. Any other
+// resource that cannot be built is logged and left unavailable. When combined with
+// WithDependencyRetry, startup waits for critical resources until they are built or
+// given up on, so the retry policy must limit its number of attempts.
+func WithCriticalResources(names ...resource.Name) Option {
+	return newFuncOption(func(o *options) {
+		o.criticalResources = append(o.criticalResources, names...)
+	})
+}
@@ -0,0 +1,95 @@
+package robot
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/resource"
+	rutils "go.viam.com/rdk/utils"
+)
+
+// A Readier is a resource that cannot be used as soon as it is constructed, such as a camera
+// that has not started streaming, a GPS without a fix, or an arm that has not been homed.
+type Readier interface {
+	// Ready returns whether the resource can be used yet.
+	Ready(ctx context.Context) (bool, error)
+}
+
+// readyPollInterval is how often WaitForReady asks resources that are not ready yet whether they are.
+const readyPollInterval = 100 * time.Millisecond
+
+// A NotReadyError is returned by WaitForReady when it gives up on resources that never became ready.
+type NotReadyError struct {
+	Names []resource.Name
+	// Err holds the errors the resources returned the last time they were asked, if any.
+	Err error
+}
+
+func (e *NotReadyError) Error() string {
+	names := make([]string, 0, len(e.Names))
+	for _, name := range e.Names {
+		names = append(names, name.String())
+	}
+	msg := "resources not ready: " + strings.Join(names, ", ")
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *NotReadyError) Unwrap() error {
+	return e.Err
+}
+
+// WaitForReady blocks until every resource on the given robot that is a Readier reports that it
+// is ready. Resources that are not Readiers are ready as soon as they exist. A resource that
+// errors while being asked is asked again, since hardware often errors while it is coming up.
+// WaitForReady gives up when ctx is done, so callers bound the wait with a deadline on ctx,
+// and returns a *NotReadyError naming the resources that were still not ready.
+func WaitForReady(ctx context.Context, r Robot) error {
+	pending := map[resource.Name]Readier{}
+	for _, name := range r.ResourceNames() {
+		res, err := r.ResourceByName(name)
+		if err != nil {
+			continue
+		}
+		if readier, ok := rutils.UnwrapProxy(res).(Readier); ok {
+			pending[name] = readier
+		}
+	}
+
+	errs := map[resource.Name]error{}
+	for {
+		for name, readier := range pending {
+			ready, err := readier.Ready(ctx)
+			errs[name] = err
+			if err == nil && ready {
+				delete(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if !utils.SelectContextOrWait(ctx, readyPollInterval) {
+			notReady := &NotReadyError{}
+			for name := range pending {
+				notReady.Names = append(notReady.Names, name)
+			}
+			sort.Slice(notReady.Names, func(i, j int) bool {
+				return notReady.Names[i].String() < notReady.Names[j].String()
+			})
+			for _, name := range notReady.Names {
+				if errs[name] != nil {
+					notReady.Err = multierr.Combine(notReady.Err, errors.Wrapf(errs[name], "%s", name))
+				}
+			}
+			return notReady
+		}
+	}
+}
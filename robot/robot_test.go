@@ -1,16 +1,25 @@
 package robot_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/edaniels/golog"
 	"go.viam.com/test"
 	"go.viam.com/utils"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/components/gantry"
+	// register fake components.
+	_ "go.viam.com/rdk/components/register"
 	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
+	robotimpl "go.viam.com/rdk/robot/impl"
+	"go.viam.com/rdk/services/motion"
 	"go.viam.com/rdk/testutils"
 	"go.viam.com/rdk/testutils/inject"
 	rutils "go.viam.com/rdk/utils"
@@ -73,3 +82,109 @@ func TestNamesFromRobot(t *testing.T) {
 	names = robot.NamesBySubtype(r, arm.Subtype)
 	test.That(t, utils.NewStringSet(names...), test.ShouldResemble, utils.NewStringSet(testutils.ExtractNames(armNames...)...))
 }
+
+func TestHealth(t *testing.T) {
+	r := setupInjectRobot()
+	var calls int
+	r.StatusFunc = func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
+		calls++
+		statuses := make([]robot.Status, 0, len(resourceNames))
+		for _, name := range resourceNames {
+			if name == sensor.Named("sensor1") {
+				return nil, errors.New("sensor unreachable")
+			}
+			statuses = append(statuses, robot.Status{Name: name, Status: map[string]interface{}{"ok": true}})
+		}
+		return statuses, nil
+	}
+
+	health := robot.Health(context.Background(), r)
+	test.That(t, health, test.ShouldHaveLength, len(r.ResourceNames()))
+	test.That(t, robot.Healthy(health), test.ShouldBeFalse)
+	for _, h := range health {
+		if h.Name == sensor.Named("sensor1") {
+			test.That(t, h.Healthy, test.ShouldBeFalse)
+			test.That(t, h.Err, test.ShouldBeError, errors.New("sensor unreachable"))
+			continue
+		}
+		test.That(t, h.Healthy, test.ShouldBeTrue)
+		test.That(t, h.Err, test.ShouldBeNil)
+		test.That(t, h.Status, test.ShouldResemble, map[string]interface{}{"ok": true})
+	}
+
+	r.ResourceNamesFunc = func() []resource.Name { return armNames }
+	calls = 0
+	test.That(t, robot.Healthy(robot.Health(context.Background(), r)), test.ShouldBeTrue)
+	test.That(t, calls, test.ShouldEqual, 1)
+
+	// resources left out of the batch are retrieved on their own
+	r.StatusFunc = func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
+		calls++
+		return []robot.Status{{Name: resourceNames[0], Status: map[string]interface{}{"ok": true}}}, nil
+	}
+	calls = 0
+	test.That(t, robot.Healthy(robot.Health(context.Background(), r)), test.ShouldBeTrue)
+	test.That(t, calls, test.ShouldEqual, len(armNames))
+}
+
+type delayedReadier struct {
+	readyAt time.Time
+	asked   int
+}
+
+func (d *delayedReadier) Ready(ctx context.Context) (bool, error) {
+	d.asked++
+	if d.asked == 1 {
+		return false, errors.New("still booting")
+	}
+	return !time.Now().Before(d.readyAt), nil
+}
+
+func TestWaitForReady(t *testing.T) {
+	r := setupInjectRobot()
+	gps := &delayedReadier{readyAt: time.Now().Add(300 * time.Millisecond)}
+	r.ResourceByNameFunc = func(name resource.Name) (interface{}, error) {
+		if name == sensor.Named("sensor1") {
+			return gps, nil
+		}
+		return "here", nil
+	}
+
+	start := time.Now()
+	test.That(t, robot.WaitForReady(context.Background(), r), test.ShouldBeNil)
+	test.That(t, time.Since(start), test.ShouldBeGreaterThanOrEqualTo, 300*time.Millisecond)
+	test.That(t, gps.asked, test.ShouldBeGreaterThan, 2)
+
+	gps = &delayedReadier{readyAt: time.Now().Add(time.Hour)}
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	err := robot.WaitForReady(ctx, r)
+	var notReady *robot.NotReadyError
+	test.That(t, errors.As(err, &notReady), test.ShouldBeTrue)
+	test.That(t, notReady.Names, test.ShouldResemble, []resource.Name{sensor.Named("sensor1")})
+	test.That(t, notReady.Err, test.ShouldBeNil)
+}
+
+func TestDescribeResource(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg, err := config.Read(ctx, rutils.ResolveFile("services/motion/data/arm_gantry.json"), logger)
+	test.That(t, err, test.ShouldBeNil)
+	r, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	desc, err := robot.DescribeResource(ctx, r, arm.Named("arm1"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, desc, test.ShouldResemble, robot.ResourceDescription{Name: arm.Named("arm1"), Subtype: arm.Subtype, Model: "fake"})
+
+	desc, err = robot.DescribeResource(ctx, r, motion.Named(resource.DefaultModelName))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, desc.Subtype, test.ShouldResemble, motion.Subtype)
+	test.That(t, desc.Model, test.ShouldEqual, resource.DefaultModelName)
+
+	_, err = robot.DescribeResource(ctx, r, arm.Named("arm2"))
+	test.That(t, err, test.ShouldBeError, rutils.NewResourceNotFoundError(arm.Named("arm2")))
+}
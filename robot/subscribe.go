@@ -0,0 +1,128 @@
+package robot
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+	"google.golang.org/protobuf/proto"
+
+	"go.viam.com/rdk/resource"
+)
+
+// ResourceState is what a subscription reports about a resource whenever it changes.
+type ResourceState struct {
+	Name   resource.Name
+	Status interface{}
+	// Readings are only set for resources that have readings, like sensors.
+	Readings map[string]interface{}
+	// Err is set when the state of the resource could not be retrieved.
+	Err error
+}
+
+func (rs ResourceState) equal(other ResourceState) bool {
+	if (rs.Err == nil) != (other.Err == nil) || (rs.Err != nil && rs.Err.Error() != other.Err.Error()) {
+		return false
+	}
+	if !reflect.DeepEqual(rs.Readings, other.Readings) {
+		return false
+	}
+	if msg, ok := rs.Status.(proto.Message); ok {
+		if otherMsg, ok := other.Status.(proto.Message); ok {
+			return proto.Equal(msg, otherMsg)
+		}
+	}
+	return reflect.DeepEqual(rs.Status, other.Status)
+}
+
+type readingsResource interface {
+	Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error)
+}
+
+func currentResourceState(ctx context.Context, r Robot, name resource.Name) ResourceState {
+	state := ResourceState{Name: name}
+	statuses, err := r.Status(ctx, []resource.Name{name})
+	if err != nil {
+		state.Err = err
+		return state
+	}
+	if len(statuses) == 1 {
+		state.Status = statuses[0].Status
+	}
+	res, err := r.ResourceByName(name)
+	if err != nil {
+		state.Err = err
+		return state
+	}
+	if sensor, ok := res.(readingsResource); ok {
+		state.Readings, state.Err = sensor.Readings(ctx, nil)
+	}
+	return state
+}
+
+// Subscribe polls the state of the named resource every interval and calls onChange with the first
+// state and with every state that differs from the last one delivered. onChange is called from a
+// single goroutine, one state at a time. A subscriber that is slower than the resource changes does
+// not hold up polling and does not build up a backlog: states it has not picked up yet are replaced
+// by newer ones, so it always sees the latest state next.
+//
+// The returned function unsubscribes; once it returns onChange is not called again. It must not be
+// called from within onChange.
+func Subscribe(
+	ctx context.Context,
+	r Robot,
+	name resource.Name,
+	interval time.Duration,
+	onChange func(ResourceState),
+) (func(), error) {
+	if interval <= 0 {
+		return nil, errors.New("subscription interval must be greater than 0")
+	}
+	if _, err := r.ResourceByName(name); err != nil {
+		return nil, err
+	}
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	latest := make(chan ResourceState, 1)
+	var activeBackgroundWorkers sync.WaitGroup
+	activeBackgroundWorkers.Add(2)
+	utils.PanicCapturingGo(func() {
+		defer activeBackgroundWorkers.Done()
+		defer close(latest)
+		var last *ResourceState
+		for {
+			state := currentResourceState(cancelCtx, r, name)
+			if cancelCtx.Err() != nil {
+				return
+			}
+			if last == nil || !state.equal(*last) {
+				last = &state
+				// this is the only sender, so once any undelivered state is dropped the send cannot block
+				select {
+				case <-latest:
+				default:
+				}
+				latest <- state
+			}
+			if !utils.SelectContextOrWait(cancelCtx, interval) {
+				return
+			}
+		}
+	})
+	utils.PanicCapturingGo(func() {
+		defer activeBackgroundWorkers.Done()
+		for state := range latest {
+			if cancelCtx.Err() != nil {
+				return
+			}
+			onChange(state)
+		}
+	})
+	return func() {
+		cancel()
+		activeBackgroundWorkers.Wait()
+	}, nil
+}
@@ -0,0 +1,111 @@
+package robot_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/testutils/inject"
+	rutils "go.viam.com/rdk/utils"
+)
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	reading := 1
+	injectSensor := &inject.Sensor{}
+	injectSensor.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return map[string]interface{}{"a": reading}, nil
+	}
+	setReading := func(v int) {
+		mu.Lock()
+		defer mu.Unlock()
+		reading = v
+	}
+
+	r := &inject.Robot{}
+	r.ResourceByNameFunc = func(name resource.Name) (interface{}, error) {
+		if name == sensor.Named("sensor1") {
+			return injectSensor, nil
+		}
+		return nil, rutils.NewResourceNotFoundError(name)
+	}
+	r.StatusFunc = func(ctx context.Context, names []resource.Name) ([]robot.Status, error) {
+		return []robot.Status{{Name: names[0], Status: struct{}{}}}, nil
+	}
+
+	_, err := robot.Subscribe(ctx, r, sensor.Named("nope"), time.Millisecond, func(robot.ResourceState) {})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = robot.Subscribe(ctx, r, sensor.Named("sensor1"), 0, func(robot.ResourceState) {})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	t.Run("change events", func(t *testing.T) {
+		var changes []robot.ResourceState
+		unsubscribe, err := robot.Subscribe(ctx, r, sensor.Named("sensor1"), time.Millisecond, func(state robot.ResourceState) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, state)
+		})
+		test.That(t, err, test.ShouldBeNil)
+		received := func() []robot.ResourceState {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]robot.ResourceState{}, changes...)
+		}
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, received(), test.ShouldHaveLength, 1)
+		})
+		// the reading has not changed, so nothing new is delivered
+		time.Sleep(20 * time.Millisecond)
+		test.That(t, received(), test.ShouldHaveLength, 1)
+		test.That(t, received()[0].Name, test.ShouldResemble, sensor.Named("sensor1"))
+		test.That(t, received()[0].Readings, test.ShouldResemble, map[string]interface{}{"a": 1})
+
+		setReading(2)
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, received(), test.ShouldHaveLength, 2)
+		})
+		test.That(t, received()[1].Readings, test.ShouldResemble, map[string]interface{}{"a": 2})
+
+		unsubscribe()
+		setReading(3)
+		time.Sleep(20 * time.Millisecond)
+		test.That(t, received(), test.ShouldHaveLength, 2)
+	})
+
+	t.Run("slow subscriber gets the latest state", func(t *testing.T) {
+		setReading(1)
+		release := make(chan struct{})
+		states := make(chan robot.ResourceState, 10)
+		unsubscribe, err := robot.Subscribe(ctx, r, sensor.Named("sensor1"), time.Millisecond, func(state robot.ResourceState) {
+			states <- state
+			<-release
+		})
+		test.That(t, err, test.ShouldBeNil)
+		defer unsubscribe()
+
+		first := <-states
+		test.That(t, first.Readings, test.ShouldResemble, map[string]interface{}{"a": 1})
+		// while the subscriber is busy, the reading changes a few times
+		for v := 2; v <= 4; v++ {
+			setReading(v)
+			time.Sleep(10 * time.Millisecond)
+		}
+		close(release)
+		test.That(t, (<-states).Readings, test.ShouldResemble, map[string]interface{}{"a": 4})
+		time.Sleep(20 * time.Millisecond)
+		test.That(t, states, test.ShouldHaveLength, 0)
+	})
+}
@@ -20,6 +20,15 @@ type Options struct {
 	// Pprof turns on the pprof profiler accessible at /debug
 	Pprof bool
 
+	// HealthCheck turns on the unauthenticated resource health report accessible at /health
+	HealthCheck bool
+
+	// Metrics turns on the unauthenticated Prometheus metrics accessible at /metrics
+	Metrics bool
+
+	// Describe turns on the unauthenticated resource descriptions accessible at /describe
+	Describe bool
+
 	// SharedDir is the location of static web assets.
 	SharedDir string
 
@@ -3,6 +3,7 @@ package web
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
@@ -40,6 +41,7 @@ import (
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/grpc"
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
@@ -924,6 +926,16 @@ func (svc *webService) initMux(options weboptions.Options) (*goji.Mux, error) {
 		})
 	}
 
+	if options.HealthCheck {
+		mux.HandleFunc(pat.Get("/health"), svc.handleHealth)
+	}
+	if options.Metrics {
+		mux.Handle(pat.Get("/metrics"), metrics.Handler())
+	}
+	if options.Describe {
+		mux.HandleFunc(pat.Get("/describe"), svc.handleDescribe)
+	}
+
 	// for urls with /api, add /viam to the path so that it matches with the paths defined in protobuf.
 	mux.Handle(pat.New("/api/*"), addPrefix(svc.rpcServer.GatewayHandler()))
 	mux.Handle(pat.New("/*"), svc.rpcServer.GRPCHandler())
@@ -931,6 +943,78 @@ func (svc *webService) initMux(options weboptions.Options) (*goji.Mux, error) {
 	return mux, nil
 }
 
+type resourceHealthResponse struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+type healthResponse struct {
+	Healthy   bool                     `json:"healthy"`
+	Resources []resourceHealthResponse `json:"resources"`
+}
+
+// handleHealth reports the health of every resource on the robot as JSON. It responds
+// with http.StatusServiceUnavailable when any resource is unhealthy.
+func (svc *webService) handleHealth(w http.ResponseWriter, r *http.Request) {
+	health := robot.Health(r.Context(), svc.r)
+	resp := healthResponse{
+		Healthy:   robot.Healthy(health),
+		Resources: make([]resourceHealthResponse, 0, len(health)),
+	}
+	for _, h := range health {
+		resHealth := resourceHealthResponse{Name: h.Name.String(), Healthy: h.Healthy}
+		if h.Err != nil {
+			resHealth.Error = h.Err.Error()
+		}
+		resp.Resources = append(resp.Resources, resHealth)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		svc.logger.Debugw("failed to write health response", "error", err)
+	}
+}
+
+type describeResponse struct {
+	Name    string `json:"name"`
+	Subtype string `json:"subtype"`
+	Model   string `json:"model,omitempty"`
+}
+
+// handleDescribe reports the subtype and model of the resource named by the name query parameter,
+// like rdk:component:arm/arm1, as JSON.
+func (svc *webService) handleDescribe(w http.ResponseWriter, r *http.Request) {
+	localRobot, ok := svc.r.(robot.LocalRobot)
+	if !ok {
+		http.Error(w, "resources of this robot cannot be described", http.StatusNotImplemented)
+		return
+	}
+	name, err := resource.NewFromString(r.URL.Query().Get("name"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	desc, err := robot.DescribeResource(r.Context(), localRobot, name)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, rutils.ErrResourceNotFound) {
+			code = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := describeResponse{Name: desc.Name.String(), Subtype: desc.Subtype.String(), Model: desc.Model}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		svc.logger.Debugw("failed to write describe response", "error", err)
+	}
+}
+
 func (svc *webService) foreignServiceHandler(srv interface{}, stream googlegrpc.ServerStream) error {
 	method, ok := googlegrpc.MethodFromServerStream(stream)
 	if !ok {
@@ -4,7 +4,12 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
 	"net"
+	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/edaniels/golog"
@@ -26,6 +31,8 @@ import (
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/config"
 	mycomppb "go.viam.com/rdk/examples/mycomponent/proto/api/component/mycomponent/v1"
 	rgrpc "go.viam.com/rdk/grpc"
@@ -690,6 +697,173 @@ func setupRobotCtx(t *testing.T) (context.Context, robot.Robot) {
 	return context.Background(), injectRobot
 }
 
+func TestWebHealth(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx, r := setupRobotCtx(t)
+	injectRobot := r.(*inject.Robot)
+
+	var failing bool
+	injectRobot.StatusFunc = func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
+		if failing {
+			return nil, errors.New("arm unreachable")
+		}
+		return []robot.Status{{Name: resourceNames[0], Status: map[string]interface{}{}}}, nil
+	}
+
+	svc := web.New(ctx, injectRobot, logger)
+	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	test.That(t, svc.Start(ctx, options), test.ShouldBeNil)
+
+	// the health report is not served unless asked for
+	resp, err := http.Get("http://" + addr + "/health")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Body.Close(), test.ShouldBeNil)
+	test.That(t, resp.StatusCode, test.ShouldNotEqual, http.StatusOK)
+	test.That(t, resp.Header.Get("Content-Type"), test.ShouldNotEqual, "application/json")
+	test.That(t, utils.TryClose(context.Background(), svc), test.ShouldBeNil)
+
+	svc = web.New(ctx, injectRobot, logger)
+	options, _, addr = robottestutils.CreateBaseOptionsAndListener(t)
+	options.HealthCheck = true
+	test.That(t, svc.Start(ctx, options), test.ShouldBeNil)
+	defer func() {
+		test.That(t, utils.TryClose(context.Background(), svc), test.ShouldBeNil)
+	}()
+
+	getHealth := func() (int, map[string]interface{}) {
+		resp, err := http.Get("http://" + addr + "/health")
+		test.That(t, err, test.ShouldBeNil)
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		test.That(t, json.NewDecoder(resp.Body).Decode(&body), test.ShouldBeNil)
+		return resp.StatusCode, body
+	}
+
+	code, body := getHealth()
+	test.That(t, code, test.ShouldEqual, http.StatusOK)
+	test.That(t, body["healthy"], test.ShouldBeTrue)
+
+	failing = true
+	code, body = getHealth()
+	test.That(t, code, test.ShouldEqual, http.StatusServiceUnavailable)
+	test.That(t, body["healthy"], test.ShouldBeFalse)
+	resourceHealth := body["resources"].([]interface{})
+	test.That(t, resourceHealth, test.ShouldHaveLength, 1)
+	test.That(t, resourceHealth[0].(map[string]interface{})["name"], test.ShouldEqual, arm.Named(arm1String).String())
+	test.That(t, resourceHealth[0].(map[string]interface{})["error"], test.ShouldEqual, "arm unreachable")
+}
+
+func TestWebDescribe(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx, r := setupRobotCtx(t)
+	injectRobot := r.(*inject.Robot)
+	injectRobot.ConfigFunc = func(ctx context.Context) (*config.Config, error) {
+		return &config.Config{Components: []config.Component{
+			{Name: arm1String, Namespace: resource.ResourceNamespaceRDK, Type: arm.SubtypeName, Model: "fake"},
+		}}, nil
+	}
+
+	svc := web.New(ctx, injectRobot, logger)
+	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	options.Describe = true
+	test.That(t, svc.Start(ctx, options), test.ShouldBeNil)
+	defer func() {
+		test.That(t, utils.TryClose(context.Background(), svc), test.ShouldBeNil)
+	}()
+
+	describe := func(name string) (int, map[string]interface{}) {
+		resp, err := http.Get("http://" + addr + "/describe?" + url.Values{"name": {name}}.Encode())
+		test.That(t, err, test.ShouldBeNil)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		var body map[string]interface{}
+		test.That(t, json.NewDecoder(resp.Body).Decode(&body), test.ShouldBeNil)
+		return resp.StatusCode, body
+	}
+
+	code, body := describe(arm.Named(arm1String).String())
+	test.That(t, code, test.ShouldEqual, http.StatusOK)
+	test.That(t, body, test.ShouldResemble, map[string]interface{}{
+		"name":    arm.Named(arm1String).String(),
+		"subtype": arm.Subtype.String(),
+		"model":   "fake",
+	})
+
+	code, _ = describe(arm.Named("arm2").String())
+	test.That(t, code, test.ShouldEqual, http.StatusNotFound)
+	code, _ = describe("arm1")
+	test.That(t, code, test.ShouldEqual, http.StatusBadRequest)
+}
+
+func TestWebMetrics(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx, r := setupRobotCtx(t)
+	injectRobot := r.(*inject.Robot)
+
+	injectMotor := &inject.Motor{}
+	injectMotor.SetPowerFunc = func(ctx context.Context, powerPct float64, extra map[string]interface{}) error {
+		return nil
+	}
+	injectSensor := &inject.Sensor{}
+	injectSensor.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"temp": 21.5}, nil
+	}
+	injectRobot.ResourceNamesFunc = func() []resource.Name {
+		return append([]resource.Name{motor.Named("motor1"), sensor.Named("sensor1")}, resources...)
+	}
+	injectRobot.ResourceByNameFunc = func(name resource.Name) (interface{}, error) {
+		switch name.Subtype {
+		case motor.Subtype:
+			return injectMotor, nil
+		case sensor.Subtype:
+			return injectSensor, nil
+		default:
+			return &inject.Arm{}, nil
+		}
+	}
+
+	svc := web.New(ctx, injectRobot, logger)
+	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	test.That(t, svc.Start(ctx, options), test.ShouldBeNil)
+
+	// the metrics are not served unless asked for
+	resp, err := http.Get("http://" + addr + "/metrics")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Body.Close(), test.ShouldBeNil)
+	test.That(t, resp.StatusCode, test.ShouldNotEqual, http.StatusOK)
+	test.That(t, utils.TryClose(context.Background(), svc), test.ShouldBeNil)
+
+	svc = web.New(ctx, injectRobot, logger)
+	options, _, addr = robottestutils.CreateBaseOptionsAndListener(t)
+	options.Metrics = true
+	test.That(t, svc.Start(ctx, options), test.ShouldBeNil)
+	defer func() {
+		test.That(t, utils.TryClose(context.Background(), svc), test.ShouldBeNil)
+	}()
+
+	conn, err := rgrpc.Dial(context.Background(), addr, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	}()
+	test.That(t, motor.NewClientFromConn(ctx, conn, "motor1", logger).SetPower(ctx, 0.5, nil), test.ShouldBeNil)
+	_, err = sensor.NewClientFromConn(ctx, conn, "sensor1", logger).Readings(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+
+	resp, err = http.Get("http://" + addr + "/metrics")
+	test.That(t, err, test.ShouldBeNil)
+	defer resp.Body.Close()
+	test.That(t, resp.StatusCode, test.ShouldEqual, http.StatusOK)
+	test.That(t, resp.Header.Get("Content-Type"), test.ShouldStartWith, "text/plain")
+	body, err := io.ReadAll(resp.Body)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(body), test.ShouldContainSubstring, "# TYPE rdk_motor_commands_total counter")
+	test.That(t, string(body), test.ShouldContainSubstring, `rdk_motor_commands_total{method="SetPower",motor="motor1"} 1`)
+	test.That(t, string(body), test.ShouldContainSubstring, `rdk_sensor_readings_total{sensor="sensor1"} 1`)
+}
+
 func TestForeignResource(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	ctx, robot := setupRobotCtx(t)
@@ -0,0 +1,129 @@
+package datacapture
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	v1 "go.viam.com/api/app/datasync/v1"
+	goutils "go.viam.com/utils"
+)
+
+// A Reading is a captured reading along with the metadata of the file it was captured to, which
+// tells the component and method it came from.
+type Reading struct {
+	Metadata *v1.DataCaptureMetadata
+	Data     *v1.SensorData
+}
+
+// Replay reads back the readings of every data capture file under a capture directory, across
+// all components and methods, in the order they were requested, so a recorded session of a
+// whole robot can be played back offline.
+type Replay struct {
+	files []*File
+	// heads holds the next reading of each file, nil once the file is exhausted.
+	heads []*v1.SensorData
+}
+
+// NewReplay opens every data capture file under the capture directory.
+func NewReplay(captureDir string) (*Replay, error) {
+	r := &Replay{}
+	err := filepath.Walk(captureDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != FileExt {
+			return err
+		}
+		//nolint:gosec
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		file, err := ReadFile(f)
+		if err != nil {
+			return multierr.Combine(err, f.Close())
+		}
+		r.files = append(r.files, file)
+		head, err := readHead(file)
+		if err != nil {
+			return err
+		}
+		r.heads = append(r.heads, head)
+		return nil
+	})
+	if err != nil {
+		return nil, multierr.Combine(err, r.Close())
+	}
+	return r, nil
+}
+
+// readHead returns the next reading of the file, or nil at its end.
+func readHead(f *File) (*v1.SensorData, error) {
+	data, err := f.ReadNext()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Next returns the earliest requested reading not returned yet, or io.EOF once every reading
+// has been returned.
+func (r *Replay) Next() (Reading, error) {
+	earliest := -1
+	for i, head := range r.heads {
+		if head == nil {
+			continue
+		}
+		if earliest == -1 || head.GetMetadata().GetTimeRequested().AsTime().Before(
+			r.heads[earliest].GetMetadata().GetTimeRequested().AsTime()) {
+			earliest = i
+		}
+	}
+	if earliest == -1 {
+		return Reading{}, io.EOF
+	}
+	reading := Reading{Metadata: r.files[earliest].ReadMetadata(), Data: r.heads[earliest]}
+	head, err := readHead(r.files[earliest])
+	if err != nil {
+		return Reading{}, err
+	}
+	r.heads[earliest] = head
+	return reading, nil
+}
+
+// Play hands every reading of the replay to handle in the order they were requested. With a
+// speed greater than 0 it waits between readings as long as passed between them when they were
+// recorded, divided by the speed, otherwise it hands them over as fast as handle returns.
+func (r *Replay) Play(ctx context.Context, speed float64, handle func(Reading) error) error {
+	var last time.Time
+	for {
+		reading, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		requested := reading.Data.GetMetadata().GetTimeRequested().AsTime()
+		if speed > 0 && !last.IsZero() {
+			if !goutils.SelectContextOrWait(ctx, time.Duration(float64(requested.Sub(last))/speed)) {
+				return ctx.Err()
+			}
+		}
+		last = requested
+		if err := handle(reading); err != nil {
+			return err
+		}
+	}
+}
+
+// Close closes every file of the replay.
+func (r *Replay) Close() error {
+	var err error
+	for _, f := range r.files {
+		err = multierr.Combine(err, f.Close())
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+package datacapture
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	v1 "go.viam.com/api/app/datasync/v1"
+	"go.viam.com/test"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.viam.com/rdk/resource"
+)
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now()
+	at := func(ms int) *v1.SensorMetadata {
+		requested := timestamppb.New(start.Add(time.Duration(ms) * time.Millisecond))
+		return &v1.SensorMetadata{TimeRequested: requested, TimeReceived: requested}
+	}
+	tabular := func(t *testing.T, ms int, fields map[string]interface{}) *v1.SensorData {
+		t.Helper()
+		s, err := structpb.NewStruct(fields)
+		test.That(t, err, test.ShouldBeNil)
+		return &v1.SensorData{Metadata: at(ms), Data: &v1.SensorData_Struct{Struct: s}}
+	}
+
+	// a short session of a robot with a gps, a lidar and an arm
+	record := func(componentType resource.SubtypeName, name, method string, readings ...*v1.SensorData) {
+		md, err := BuildCaptureMetadata(componentType, name, "fake", method, nil, nil)
+		test.That(t, err, test.ShouldBeNil)
+		f, err := NewFile(dir, md)
+		test.That(t, err, test.ShouldBeNil)
+		for _, r := range readings {
+			test.That(t, f.WriteNext(r), test.ShouldBeNil)
+		}
+		test.That(t, f.Close(), test.ShouldBeNil)
+	}
+	record("movement_sensor", "gps", "Position",
+		tabular(t, 0, map[string]interface{}{"lat": 40.1, "lng": -73.1}),
+		tabular(t, 20, map[string]interface{}{"lat": 40.2, "lng": -73.2}),
+	)
+	record("camera", "lidar", nextPointCloud,
+		&v1.SensorData{Metadata: at(10), Data: &v1.SensorData_Binary{Binary: []byte("scan 1")}},
+		&v1.SensorData{Metadata: at(30), Data: &v1.SensorData_Binary{Binary: []byte("scan 2")}},
+	)
+	record("arm", "arm1", "JointPositions",
+		tabular(t, 15, map[string]interface{}{"values": []interface{}{0.0, 90.0}}),
+	)
+
+	replay, err := NewReplay(dir)
+	test.That(t, err, test.ShouldBeNil)
+	var order []string
+	for {
+		reading, err := replay.Next()
+		if err == io.EOF {
+			break
+		}
+		test.That(t, err, test.ShouldBeNil)
+		order = append(order, reading.Metadata.GetComponentName())
+		switch reading.Metadata.GetComponentName() {
+		case "gps":
+			test.That(t, reading.Data.GetStruct().GetFields(), test.ShouldContainKey, "lat")
+		case "lidar":
+			test.That(t, string(reading.Data.GetBinary()), test.ShouldStartWith, "scan")
+		case "arm1":
+			test.That(t, reading.Metadata.GetMethodName(), test.ShouldEqual, "JointPositions")
+		}
+	}
+	test.That(t, order, test.ShouldResemble, []string{"gps", "lidar", "arm1", "gps", "lidar"})
+	test.That(t, replay.Close(), test.ShouldBeNil)
+
+	t.Run("play", func(t *testing.T) {
+		replay, err := NewReplay(dir)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, replay.Close(), test.ShouldBeNil)
+		}()
+		var played []string
+		began := time.Now()
+		// the session lasted 30ms, at a tenth of the speed it takes 300ms
+		err = replay.Play(context.Background(), 0.1, func(r Reading) error {
+			played = append(played, r.Metadata.GetComponentName())
+			return nil
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, time.Since(began), test.ShouldBeGreaterThanOrEqualTo, 300*time.Millisecond)
+		test.That(t, played, test.ShouldResemble, order)
+	})
+}
@@ -4,12 +4,19 @@ package builtin
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/motionplan"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
@@ -21,26 +28,76 @@ import (
 	"go.viam.com/rdk/spatialmath"
 )
 
+var planningSummary = metrics.NewSummary("rdk_motion_planning_seconds", "Time spent planning moves.", "component")
+
 func init() {
 	registry.RegisterService(motion.Subtype, resource.DefaultModelName, registry.Service{
 		RobotConstructor: func(ctx context.Context, r robot.Robot, c config.Service, logger golog.Logger) (interface{}, error) {
 			return NewBuiltIn(ctx, r, c, logger)
 		},
 	})
+	config.RegisterServiceAttributeMapConverter(config.ServiceType(motion.SubtypeName), func(attributes config.AttributeMap) (interface{}, error) {
+		var conf Config
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &conf})
+		if err != nil {
+			return nil, err
+		}
+		if err := decoder.Decode(attributes); err != nil {
+			return nil, err
+		}
+		return &conf, nil
+	}, &Config{})
 	resource.AddDefaultService(motion.Named(resource.DefaultModelName))
 }
 
+// Config describes how to configure the service.
+type Config struct {
+	// PlanCacheSize is how many plans of recent moves are kept to be reused by identical moves, 0 disables the cache.
+	PlanCacheSize int `json:"plan_cache_size"`
+	// HomePositions are the joint positions in degrees that GoToHome returns each arm to, by arm name.
+	HomePositions map[string][]float64 `json:"home_positions,omitempty"`
+}
+
 // NewBuiltIn returns a new move and grab service for the given robot.
 func NewBuiltIn(ctx context.Context, r robot.Robot, config config.Service, logger golog.Logger) (motion.Service, error) {
+	var planCacheSize int
+	var homePositions map[string][]float64
+	if svcConfig, ok := config.ConvertedAttributes.(*Config); ok {
+		planCacheSize = svcConfig.PlanCacheSize
+		homePositions = svcConfig.HomePositions
+	}
 	return &builtIn{
-		r:      r,
-		logger: logger,
+		r:             r,
+		logger:        logger,
+		obstacles:     map[string]*referenceframe.GeometriesInFrame{},
+		plans:         newPlanCache(planCacheSize),
+		planMotion:    motionplan.PlanMotion,
+		homePositions: homePositions,
 	}, nil
 }
 
+type planMotionFunc func(
+	ctx context.Context,
+	logger golog.Logger,
+	dst *referenceframe.PoseInFrame,
+	f referenceframe.Frame,
+	seedMap map[string][]referenceframe.Input,
+	fs referenceframe.FrameSystem,
+	worldState *referenceframe.WorldState,
+	planningOpts map[string]interface{},
+) ([]map[string][]referenceframe.Input, error)
+
 type builtIn struct {
 	r      robot.Robot
 	logger golog.Logger
+
+	obstaclesMu sync.Mutex
+	obstacles   map[string]*referenceframe.GeometriesInFrame
+
+	plans      *planCache
+	planMotion planMotionFunc
+
+	homePositions map[string][]float64
 }
 
 // Move takes a goal location and will plan and execute a movement to move a component specified by its name to that destination.
@@ -52,7 +109,15 @@ func (ms *builtIn) Move(
 	extra map[string]interface{},
 ) (bool, error) {
 	operation.CancelOtherWithLabel(ctx, "motion-service")
+	// the spans of a move are recorded when sampled, or always when asked for with the trace extra parameter
+	var spanOpts []trace.StartOption
+	if traced, ok := extra["trace"].(bool); ok && traced {
+		spanOpts = append(spanOpts, trace.WithSampler(trace.AlwaysSample()))
+	}
+	ctx, span := trace.StartSpan(ctx, "motion::builtin::Move", spanOpts...)
+	defer span.End()
 	logger := ms.r.Logger()
+	worldState = ms.withObstacles(worldState)
 
 	// get goal frame
 	goalFrameName := destination.FrameName()
@@ -76,29 +141,58 @@ func (ms *builtIn) Move(
 		return false, fmt.Errorf("component named %s not found in robot frame system", componentName.ShortName())
 	}
 
-	// re-evaluate goalPose to be in the frame of World
+	// re-evaluate goalPose to be in the frame of World, or in the planning frame given in extra. Planning in the frame of a base the
+	// component is mounted on keeps the base out of the plan, and keeps the plan valid while the base moves.
 	solvingFrame := referenceframe.World // TODO(erh): this should really be the parent of rootName
+	if planningFrame, ok := extra["planning_frame"].(string); ok {
+		if frameSys.Frame(planningFrame) == nil {
+			return false, referenceframe.NewFrameMissingError(planningFrame)
+		}
+		solvingFrame = planningFrame
+	}
 	tf, err := frameSys.Transform(fsInputs, destination, solvingFrame)
 	if err != nil {
 		return false, err
 	}
 	goalPose, _ := tf.(*referenceframe.PoseInFrame)
 
-	// the goal is to move the component to goalPose which is specified in coordinates of goalFrameName
-	output, err := motionplan.PlanMotion(ctx,
-		logger,
-		goalPose,
-		movingFrame,
-		fsInputs,
-		frameSys,
-		worldState,
-		extra,
-	)
+	// reuse the plan of an identical earlier move when plans are cached
+	planKey, err := planCacheKey(componentName, goalPose, fsInputs, frameSys, worldState, extra)
 	if err != nil {
 		return false, err
 	}
+	output, ok := ms.plans.get(planKey)
+	if !ok {
+		// the goal is to move the component to goalPose which is specified in coordinates of goalFrameName
+		planCtx, planSpan := trace.StartSpan(ctx, "motion::builtin::Move::plan")
+		var collisionTimer *motionplan.CollisionCheckTimer
+		if planSpan.IsRecordingEvents() {
+			planCtx, collisionTimer = motionplan.WithCollisionCheckTimer(planCtx)
+		}
+		planStart := time.Now()
+		output, err = ms.planMotion(planCtx,
+			logger,
+			goalPose,
+			movingFrame,
+			fsInputs,
+			frameSys,
+			worldState,
+			extra,
+		)
+		planningSummary.Observe(time.Since(planStart).Seconds(), componentName.ShortName())
+		if collisionTimer != nil {
+			planSpan.AddAttributes(trace.Int64Attribute("collision_check_us", collisionTimer.Elapsed().Microseconds()))
+		}
+		planSpan.End()
+		if err != nil {
+			return false, err
+		}
+		ms.plans.put(planKey, output)
+	}
 
 	// move all the components
+	_, executeSpan := trace.StartSpan(ctx, "motion::builtin::Move::execute")
+	defer executeSpan.End()
 	for _, step := range output {
 		// TODO(erh): what order? parallel?
 		for name, inputs := range step {
@@ -114,6 +208,71 @@ func (ms *builtIn) Move(
 	return true, nil
 }
 
+// AddObstacles registers a named set of obstacles that every later Move plans around.
+func (ms *builtIn) AddObstacles(ctx context.Context, name string, obstacles *referenceframe.GeometriesInFrame) error {
+	if obstacles == nil {
+		return errors.New("no obstacles given")
+	}
+	ms.obstaclesMu.Lock()
+	defer ms.obstaclesMu.Unlock()
+	if _, ok := ms.obstacles[name]; ok {
+		return errors.Errorf("obstacles named %q already exist", name)
+	}
+	ms.obstacles[name] = obstacles
+	ms.plans.clear()
+	return nil
+}
+
+// RemoveObstacles unregisters the named set of obstacles.
+func (ms *builtIn) RemoveObstacles(ctx context.Context, name string) error {
+	ms.obstaclesMu.Lock()
+	defer ms.obstaclesMu.Unlock()
+	if _, ok := ms.obstacles[name]; !ok {
+		return errors.Errorf("no obstacles named %q", name)
+	}
+	delete(ms.obstacles, name)
+	ms.plans.clear()
+	return nil
+}
+
+// Obstacles returns the registered sets of obstacles by name.
+func (ms *builtIn) Obstacles(ctx context.Context) (map[string]*referenceframe.GeometriesInFrame, error) {
+	ms.obstaclesMu.Lock()
+	defer ms.obstaclesMu.Unlock()
+	obstacles := make(map[string]*referenceframe.GeometriesInFrame, len(ms.obstacles))
+	for name, gf := range ms.obstacles {
+		obstacles[name] = gf
+	}
+	return obstacles, nil
+}
+
+// withObstacles returns a copy of the world state with the registered obstacles added to it.
+func (ms *builtIn) withObstacles(worldState *referenceframe.WorldState) *referenceframe.WorldState {
+	ms.obstaclesMu.Lock()
+	defer ms.obstaclesMu.Unlock()
+	if worldState == nil {
+		worldState = &referenceframe.WorldState{}
+	}
+	if len(ms.obstacles) == 0 {
+		return worldState
+	}
+	// add the registered obstacles in name order so identical moves get identical world states
+	names := make([]string, 0, len(ms.obstacles))
+	for name := range ms.obstacles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	obstacles := append([]*referenceframe.GeometriesInFrame{}, worldState.Obstacles...)
+	for _, name := range names {
+		obstacles = append(obstacles, ms.obstacles[name])
+	}
+	return &referenceframe.WorldState{
+		Obstacles:         obstacles,
+		InteractionSpaces: worldState.InteractionSpaces,
+		Transforms:        worldState.Transforms,
+	}
+}
+
 // MoveSingleComponent will pass through a move command to a component with a MoveToPosition method that takes a pose. Arms are the only
 // component that supports this. This method will transform the destination pose, given in an arbitrary frame, into the pose of the arm.
 // The arm will then move its most distal link to that pose. If you instead wish to move any other component than the arm end to that pose,
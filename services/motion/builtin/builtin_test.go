@@ -3,26 +3,32 @@ package builtin_test
 import (
 	"context"
 	"math"
+	"sync"
 	"testing"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
+	"go.opencensus.io/trace"
 	"go.viam.com/test"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/gantry"
 	"go.viam.com/rdk/components/gripper"
 
 	// register.
 	commonpb "go.viam.com/api/common/v1"
+	armpb "go.viam.com/api/component/arm/v1"
 	_ "go.viam.com/rdk/components/register"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
 	framesystemparts "go.viam.com/rdk/robot/framesystem/parts"
 	robotimpl "go.viam.com/rdk/robot/impl"
 	"go.viam.com/rdk/services/motion"
 	"go.viam.com/rdk/services/motion/builtin"
 	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
 )
 
 func setupMotionServiceFromConfig(t *testing.T, configFilename string) motion.Service {
@@ -101,6 +107,35 @@ func TestMove1(t *testing.T) {
 	})
 }
 
+func TestMoveInPlanningFrame(t *testing.T) {
+	ctx := context.Background()
+	ms := setupMotionServiceFromConfig(t, "../data/arm_gantry.json")
+
+	// the arm rides on the gantry, so planning in the frame of the gantry leaves the gantry out of the plan
+	start, err := ms.GetPose(ctx, arm.Named("arm1"), "gantry1", nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	gantryStart, err := ms.GetPose(ctx, gantry.Named("gantry1"), referenceframe.World, nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	// the fake arm can only turn its end about z
+	goal := spatialmath.NewPoseFromOrientation(start.Pose().Point(), &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 90})
+	_, err = ms.Move(ctx, arm.Named("arm1"), referenceframe.NewPoseInFrame("gantry1", goal), &referenceframe.WorldState{}, map[string]interface{}{
+		"planning_frame": "gantry1",
+	})
+	test.That(t, err, test.ShouldBeNil)
+
+	end, err := ms.GetPose(ctx, arm.Named("arm1"), "gantry1", nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.PoseAlmostCoincidentEps(end.Pose(), goal, 1e-2), test.ShouldBeTrue)
+	gantryEnd, err := ms.GetPose(ctx, gantry.Named("gantry1"), referenceframe.World, nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.PoseAlmostEqual(gantryEnd.Pose(), gantryStart.Pose()), test.ShouldBeTrue)
+
+	_, err = ms.Move(ctx, arm.Named("arm1"), referenceframe.NewPoseInFrame("gantry1", goal), &referenceframe.WorldState{}, map[string]interface{}{
+		"planning_frame": "nope",
+	})
+	test.That(t, err, test.ShouldBeError, referenceframe.NewFrameMissingError("nope"))
+}
+
 func TestMoveWithObstacles(t *testing.T) {
 	ms := setupMotionServiceFromConfig(t, "../data/moving_arm.json")
 
@@ -149,6 +184,236 @@ func TestMoveWithObstacles(t *testing.T) {
 	})
 }
 
+func TestPersistentObstacles(t *testing.T) {
+	ctx := context.Background()
+	ms := setupMotionServiceFromConfig(t, "../data/moving_arm.json").(motion.LocalService)
+	grabPose := referenceframe.NewPoseInFrame("world", spatialmath.NewPoseFromPoint(r3.Vector{-600, -400, 460}))
+
+	// a large plate between the arm and the destination
+	plate, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 370}), r3.Vector{2000, 2000, 20}, "plate")
+	test.That(t, err, test.ShouldBeNil)
+	table := referenceframe.NewGeometriesInFrame(referenceframe.World, map[string]spatialmath.Geometry{"plate": plate})
+	test.That(t, ms.AddObstacles(ctx, "table", table), test.ShouldBeNil)
+	test.That(t, ms.AddObstacles(ctx, "table", table), test.ShouldNotBeNil)
+	obstacles, err := ms.Obstacles(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, obstacles, test.ShouldResemble, map[string]*referenceframe.GeometriesInFrame{"table": table})
+
+	// the move does not send the plate but is still blocked by it
+	worldState := &referenceframe.WorldState{}
+	_, err = ms.Move(ctx, gripper.Named("pieceArm"), grabPose, worldState, map[string]interface{}{})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, worldState.Obstacles, test.ShouldBeEmpty)
+
+	test.That(t, ms.RemoveObstacles(ctx, "table"), test.ShouldBeNil)
+	test.That(t, ms.RemoveObstacles(ctx, "table"), test.ShouldNotBeNil)
+	obstacles, err = ms.Obstacles(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, obstacles, test.ShouldBeEmpty)
+	_, err = ms.Move(ctx, gripper.Named("pieceArm"), grabPose, worldState, map[string]interface{}{})
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestObstaclesSurviveReconfigure(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	name := motion.Named(resource.DefaultModelName)
+	svc1, err := builtin.NewBuiltIn(ctx, &inject.Robot{}, config.Service{}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	reconfSvc1, err := motion.WrapWithReconfigurable(svc1, name)
+	test.That(t, err, test.ShouldBeNil)
+
+	box, err := spatialmath.NewBox(spatialmath.NewZeroPose(), r3.Vector{10, 10, 10}, "box")
+	test.That(t, err, test.ShouldBeNil)
+	table := referenceframe.NewGeometriesInFrame(referenceframe.World, map[string]spatialmath.Geometry{"box": box})
+	test.That(t, reconfSvc1.(motion.LocalService).AddObstacles(ctx, "table", table), test.ShouldBeNil)
+
+	svc2, err := builtin.NewBuiltIn(ctx, &inject.Robot{}, config.Service{}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	reconfSvc2, err := motion.WrapWithReconfigurable(svc2, name)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reconfSvc1.Reconfigure(ctx, reconfSvc2), test.ShouldBeNil)
+
+	obstacles, err := reconfSvc1.(motion.LocalService).Obstacles(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, obstacles, test.ShouldResemble, map[string]*referenceframe.GeometriesInFrame{"table": table})
+}
+
+func TestGoToHome(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg, err := config.Read(ctx, "../data/moving_arm.json", logger)
+	test.That(t, err, test.ShouldBeNil)
+	myRobot, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer myRobot.Close(context.Background())
+	home := []float64{0, -90, 90, -90, -90, 0}
+	svc, err := builtin.NewBuiltIn(ctx, myRobot, config.Service{
+		ConvertedAttributes: &builtin.Config{HomePositions: map[string][]float64{"pieceArm": home}},
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	ms := svc.(motion.LocalService)
+
+	_, err = ms.GoToHome(ctx, arm.Named("pieceGripper"), nil)
+	test.That(t, err, test.ShouldBeError, `no home position configured for "pieceGripper"`)
+
+	// start away from home
+	grabPose := referenceframe.NewPoseInFrame("world", spatialmath.NewPoseFromPoint(r3.Vector{-600, -400, 460}))
+	_, err = ms.Move(ctx, arm.Named("pieceArm"), grabPose, &referenceframe.WorldState{}, nil)
+	test.That(t, err, test.ShouldBeNil)
+
+	homeArm, err := arm.FromRobot(myRobot, "pieceArm")
+	test.That(t, err, test.ShouldBeNil)
+	model := homeArm.ModelFrame()
+	homePose, err := model.Transform(model.InputFromProtobuf(&armpb.JointPositions{Values: home}))
+	test.That(t, err, test.ShouldBeNil)
+
+	// the home pose itself blocked by an obstacle cannot be reached
+	blocker, err := spatialmath.NewBox(homePose, r3.Vector{50, 50, 50}, "blocker")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ms.AddObstacles(ctx, "blocker", referenceframe.NewGeometriesInFrame(
+		referenceframe.World, map[string]spatialmath.Geometry{"blocker": blocker})), test.ShouldBeNil)
+	_, err = ms.GoToHome(ctx, arm.Named("pieceArm"), nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, ms.RemoveObstacles(ctx, "blocker"), test.ShouldBeNil)
+
+	// an obstacle off to the side is planned around
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{600, 600, 0}), r3.Vector{100, 100, 100}, "box")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ms.AddObstacles(ctx, "box", referenceframe.NewGeometriesInFrame(
+		referenceframe.World, map[string]spatialmath.Geometry{"box": box})), test.ShouldBeNil)
+	moved, err := ms.GoToHome(ctx, arm.Named("pieceArm"), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moved, test.ShouldBeTrue)
+	pose, err := ms.GetPose(ctx, arm.Named("pieceArm"), referenceframe.World, nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.PoseAlmostCoincidentEps(pose.Pose(), homePose, 1), test.ShouldBeTrue)
+}
+
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans map[string]*trace.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(s *trace.SpanData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans[s.Name] = s
+}
+
+func TestMoveTracing(t *testing.T) {
+	ctx := context.Background()
+	ms := setupMotionServiceFromConfig(t, "../data/moving_arm.json")
+	recorder := &spanRecorder{spans: map[string]*trace.SpanData{}}
+	trace.RegisterExporter(recorder)
+	defer trace.UnregisterExporter(recorder)
+
+	// an obstacle off to the side of the move still has to be checked for collisions
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{600, 600, 0}), r3.Vector{100, 100, 100}, "box")
+	test.That(t, err, test.ShouldBeNil)
+	worldState := &referenceframe.WorldState{
+		Obstacles: []*referenceframe.GeometriesInFrame{
+			referenceframe.NewGeometriesInFrame(referenceframe.World, map[string]spatialmath.Geometry{"box": box}),
+		},
+	}
+	grabPose := referenceframe.NewPoseInFrame("world", spatialmath.NewPoseFromPoint(r3.Vector{-600, -400, 460}))
+	_, err = ms.Move(ctx, gripper.Named("pieceArm"), grabPose, worldState, map[string]interface{}{"trace": true})
+	test.That(t, err, test.ShouldBeNil)
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	for _, name := range []string{"motion::builtin::Move", "motion::builtin::Move::plan", "motion::builtin::Move::execute"} {
+		span, ok := recorder.spans[name]
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, span.EndTime.Sub(span.StartTime), test.ShouldBeGreaterThan, 0)
+	}
+	plan := recorder.spans["motion::builtin::Move::plan"]
+	test.That(t, plan.Attributes["collision_check_us"], test.ShouldBeGreaterThan, 0)
+	test.That(t, plan.ParentSpanID, test.ShouldEqual, recorder.spans["motion::builtin::Move"].SpanID)
+}
+
+func TestPlanCache(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg, err := config.Read(ctx, "../data/moving_arm.json", logger)
+	test.That(t, err, test.ShouldBeNil)
+	myRobot, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer myRobot.Close(context.Background())
+	svc, err := builtin.NewBuiltIn(ctx, myRobot, config.Service{ConvertedAttributes: &builtin.Config{PlanCacheSize: 2}}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	ms := svc.(motion.LocalService)
+
+	var plans int
+	builtin.WrapPlanMotion(ms, func(planMotion builtin.PlanMotionFunc) builtin.PlanMotionFunc {
+		return func(
+			ctx context.Context,
+			logger golog.Logger,
+			dst *referenceframe.PoseInFrame,
+			f referenceframe.Frame,
+			seedMap map[string][]referenceframe.Input,
+			fs referenceframe.FrameSystem,
+			worldState *referenceframe.WorldState,
+			planningOpts map[string]interface{},
+		) ([]map[string][]referenceframe.Input, error) {
+			plans++
+			return planMotion(ctx, logger, dst, f, seedMap, fs, worldState, planningOpts)
+		}
+	})
+
+	pieceArm, err := arm.FromRobot(myRobot, "pieceArm")
+	test.That(t, err, test.ShouldBeNil)
+	home, err := pieceArm.(referenceframe.InputEnabled).CurrentInputs(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	// every move starts from the same place, so that the moves are identical
+	move := func(extra map[string]interface{}) {
+		t.Helper()
+		test.That(t, pieceArm.(referenceframe.InputEnabled).GoToInputs(ctx, home), test.ShouldBeNil)
+		grabPose := referenceframe.NewPoseInFrame("c", spatialmath.NewPoseFromPoint(r3.Vector{0, -30, -50}))
+		_, err := ms.Move(ctx, gripper.Named("pieceGripper"), grabPose, &referenceframe.WorldState{}, extra)
+		test.That(t, err, test.ShouldBeNil)
+	}
+
+	move(nil)
+	test.That(t, plans, test.ShouldEqual, 1)
+	move(nil)
+	test.That(t, plans, test.ShouldEqual, 1)
+	move(map[string]interface{}{"max_ik_solutions": 10})
+	test.That(t, plans, test.ShouldEqual, 2)
+
+	// changing the registered obstacles drops the cached plans
+	far, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{5000, 5000, 5000}), r3.Vector{1, 1, 1}, "far")
+	test.That(t, err, test.ShouldBeNil)
+	err = ms.AddObstacles(ctx, "far", referenceframe.NewGeometriesInFrame(referenceframe.World, map[string]spatialmath.Geometry{"far": far}))
+	test.That(t, err, test.ShouldBeNil)
+	move(nil)
+	test.That(t, plans, test.ShouldEqual, 3)
+	move(nil)
+	test.That(t, plans, test.ShouldEqual, 3)
+
+	// a frame system change does not rebuild the service, but a plan made for the old frames is not reused
+	worldPose, err := ms.GetPose(ctx, gripper.Named("pieceGripper"), referenceframe.World, nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	moveInWorld := func() {
+		t.Helper()
+		test.That(t, pieceArm.(referenceframe.InputEnabled).GoToInputs(ctx, home), test.ShouldBeNil)
+		_, err := ms.Move(ctx, gripper.Named("pieceGripper"), worldPose, &referenceframe.WorldState{}, nil)
+		test.That(t, err, test.ShouldBeNil)
+	}
+	moveInWorld()
+	test.That(t, plans, test.ShouldEqual, 4)
+	moveInWorld()
+	test.That(t, plans, test.ShouldEqual, 4)
+	for i, c := range cfg.Components {
+		if c.Name == "pieceGripper" {
+			cfg.Components[i].Frame.Translation = r3.Vector{Z: 10}
+		}
+	}
+	myRobot.Reconfigure(ctx, cfg)
+	moveInWorld()
+	test.That(t, plans, test.ShouldEqual, 5)
+}
+
 func TestMoveSingleComponent(t *testing.T) {
 	var err error
 	ms := setupMotionServiceFromConfig(t, "../data/moving_arm.json")
@@ -0,0 +1,15 @@
+// export_test.go adds functionality to the builtin package that we only want to use and expose during testing.
+package builtin
+
+import (
+	"go.viam.com/rdk/services/motion"
+)
+
+// PlanMotionFunc is the signature of the function the motion service plans moves with.
+type PlanMotionFunc = planMotionFunc
+
+// WrapPlanMotion replaces the function the motion service plans moves with by a wrapper around it.
+func WrapPlanMotion(svc motion.Service, wrap func(PlanMotionFunc) PlanMotionFunc) {
+	ms := svc.(*builtIn)
+	ms.planMotion = wrap(ms.planMotion)
+}
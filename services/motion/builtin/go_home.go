@@ -0,0 +1,55 @@
+package builtin
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	pb "go.viam.com/api/component/arm/v1"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/framesystem"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// GoToHome moves the named arm to the home joint positions configured for it. Unlike homing the
+// joints of an arm directly, the way home is planned like any Move, so it is checked for collisions
+// and goes around the registered obstacles. The goal of the plan is the pose of the arm at its home
+// joint positions; an arm with several joint positions reaching that pose may end up at another of them.
+func (ms *builtIn) GoToHome(ctx context.Context, componentName resource.Name, extra map[string]interface{}) (bool, error) {
+	home, ok := ms.homePositions[componentName.ShortName()]
+	if !ok {
+		return false, errors.Errorf("no home position configured for %q", componentName.ShortName())
+	}
+	frameSys, err := framesystem.RobotFrameSystem(ctx, ms.r, nil)
+	if err != nil {
+		return false, err
+	}
+	fsInputs, _, err := framesystem.RobotFsCurrentInputs(ctx, ms.r, frameSys)
+	if err != nil {
+		return false, err
+	}
+	frame := frameSys.Frame(componentName.ShortName())
+	if frame == nil {
+		return false, referenceframe.NewFrameMissingError(componentName.ShortName())
+	}
+	if len(home) != len(frame.DoF()) {
+		return false, errors.Errorf("home position of %q has %d joints, the arm has %d", componentName.ShortName(), len(home), len(frame.DoF()))
+	}
+
+	// the home pose is where the arm would be at its home joint positions with everything else where it is now
+	homeInputs := make(map[string][]referenceframe.Input, len(fsInputs))
+	for name, inputs := range fsInputs {
+		homeInputs[name] = inputs
+	}
+	homeInputs[componentName.ShortName()] = frame.InputFromProtobuf(&pb.JointPositions{Values: home})
+	tf, err := frameSys.Transform(
+		homeInputs,
+		referenceframe.NewPoseInFrame(componentName.ShortName(), spatialmath.NewZeroPose()),
+		referenceframe.World,
+	)
+	if err != nil {
+		return false, err
+	}
+	return ms.Move(ctx, componentName, tf.(*referenceframe.PoseInFrame), &referenceframe.WorldState{}, extra)
+}
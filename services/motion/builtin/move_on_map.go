@@ -0,0 +1,303 @@
+package builtin
+
+import (
+	"container/heap"
+	"context"
+	"math"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/operation"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/slam"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+const (
+	defaultMapCellSizeMm    = 100.
+	defaultMapClearanceMm   = 300.
+	defaultMapMmPerSec      = 200.
+	defaultMapDegsPerSec    = 45.
+	defaultMapHeadingTolDeg = 5.
+	// maxMapLegs bounds how many times the base is pointed and driven before giving up on the destination.
+	maxMapLegs = 100
+)
+
+// mapMoveOptions are the tunables of MoveOnMap, read from its extra parameters.
+type mapMoveOptions struct {
+	cellSizeMm      float64
+	clearanceMm     float64
+	mmPerSec        float64
+	degsPerSec      float64
+	goalToleranceMm float64
+}
+
+func newMapMoveOptions(ctx context.Context, b base.Base, extra map[string]interface{}) (mapMoveOptions, error) {
+	opts := mapMoveOptions{
+		cellSizeMm:  defaultMapCellSizeMm,
+		clearanceMm: defaultMapClearanceMm,
+		mmPerSec:    defaultMapMmPerSec,
+		degsPerSec:  defaultMapDegsPerSec,
+	}
+	// keep half the width of the base away from obstacles when the base knows how wide it is
+	if lb, ok := b.(base.LocalBase); ok {
+		if props, err := lb.Properties(ctx, nil); err == nil && props.WidthMm > 0 {
+			opts.clearanceMm = float64(props.WidthMm) / 2
+		}
+	}
+	for key, field := range map[string]*float64{
+		"cell_size_mm":      &opts.cellSizeMm,
+		"clearance_mm":      &opts.clearanceMm,
+		"mm_per_sec":        &opts.mmPerSec,
+		"degs_per_sec":      &opts.degsPerSec,
+		"goal_tolerance_mm": &opts.goalToleranceMm,
+	} {
+		if v, ok := extra[key].(float64); ok {
+			if v <= 0 {
+				return mapMoveOptions{}, errors.Errorf("%s must be greater than 0", key)
+			}
+			*field = v
+		}
+	}
+	if opts.goalToleranceMm == 0 {
+		opts.goalToleranceMm = opts.cellSizeMm
+	}
+	return opts, nil
+}
+
+// MoveOnMap drives a base to a destination on the map of a SLAM service. Each leg fetches the
+// current map and position, plans a path over the occupancy grid built from the map, then turns
+// the base toward the first waypoint and drives straight to it. The base is stopped when it arrives
+// or when no path is left.
+func (ms *builtIn) MoveOnMap(
+	ctx context.Context,
+	componentName resource.Name,
+	destination spatialmath.Pose,
+	slamName resource.Name,
+	extra map[string]interface{},
+) (bool, error) {
+	operation.CancelOtherWithLabel(ctx, "motion-service")
+	b, err := base.FromRobot(ms.r, componentName.ShortName())
+	if err != nil {
+		return false, err
+	}
+	slamSvc, err := slam.FromRobot(ms.r, slamName.ShortName())
+	if err != nil {
+		return false, err
+	}
+	opts, err := newMapMoveOptions(ctx, b, extra)
+	if err != nil {
+		return false, err
+	}
+	return moveOnMap(ctx, b, slamSvc, slamName.ShortName(), destination.Point(), opts, ms.logger)
+}
+
+func moveOnMap(
+	ctx context.Context,
+	b base.Base,
+	slamSvc slam.Service,
+	slamName string,
+	goal r3.Vector,
+	opts mapMoveOptions,
+	logger golog.Logger,
+) (bool, error) {
+	for leg := 0; leg < maxMapLegs; leg++ {
+		position, err := slamSvc.Position(ctx, slamName, nil)
+		if err != nil {
+			return false, multierr.Combine(err, b.Stop(ctx, nil))
+		}
+		current := position.Pose()
+		if flatDistance(current.Point(), goal) <= opts.goalToleranceMm {
+			return true, b.Stop(ctx, nil)
+		}
+
+		_, _, slamMap, err := slamSvc.GetMap(ctx, slamName, utils.MimeTypePCD, nil, false, nil)
+		if err != nil {
+			return false, multierr.Combine(err, b.Stop(ctx, nil))
+		}
+		if slamMap == nil || slamMap.PointCloud == nil {
+			return false, multierr.Combine(errors.Errorf("slam service %q returned no point cloud map", slamName), b.Stop(ctx, nil))
+		}
+		grid := newOccupancyGrid(slamMap.PointCloud, opts.cellSizeMm, opts.clearanceMm, current.Point(), goal)
+		path, err := grid.plan(grid.cellOf(current.Point()), grid.cellOf(goal))
+		if err != nil {
+			return false, multierr.Combine(err, b.Stop(ctx, nil))
+		}
+
+		target := goal
+		if waypoint := firstTurn(path); waypoint != path[len(path)-1] {
+			target = grid.center(waypoint)
+		}
+		logger.Debugf("leg %d: driving from %v toward %v", leg, current.Point(), target)
+		if err := driveTo(ctx, b, current, target, opts); err != nil {
+			return false, multierr.Combine(err, b.Stop(ctx, nil))
+		}
+	}
+	return false, multierr.Combine(
+		errors.Errorf("did not reach the destination after %d legs", maxMapLegs),
+		b.Stop(ctx, nil),
+	)
+}
+
+// driveTo turns the base toward the target when its heading is off and drives straight to it.
+// The base drives along its y axis, so a yaw of 0 faces the map's y axis.
+func driveTo(ctx context.Context, b base.Base, current spatialmath.Pose, target r3.Vector, opts mapMoveOptions) error {
+	delta := target.Sub(current.Point())
+	bearing := utils.RadToDeg(math.Atan2(-delta.X, delta.Y))
+	heading := utils.RadToDeg(current.Orientation().EulerAngles().Yaw)
+	turn := math.Remainder(bearing-heading, 360)
+	if math.Abs(turn) > defaultMapHeadingTolDeg {
+		if err := b.Spin(ctx, turn, opts.degsPerSec, nil); err != nil {
+			return err
+		}
+	}
+	return b.MoveStraight(ctx, int(math.Round(math.Hypot(delta.X, delta.Y))), opts.mmPerSec, nil)
+}
+
+func flatDistance(a, b r3.Vector) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+type gridCell struct {
+	x, y int
+}
+
+// occupancyGrid is a 2D grid over the xy plane of a map. A cell is blocked when a point of the
+// map falls in it or is within the clearance of it.
+type occupancyGrid struct {
+	cellSize float64
+	min, max gridCell
+	blocked  map[gridCell]bool
+}
+
+// newOccupancyGrid builds the grid of a map. The grid spans every point of the map and the extra
+// points given, with a margin so a path can go around obstacles at the edge of the map.
+func newOccupancyGrid(pc pointcloud.PointCloud, cellSizeMm, clearanceMm float64, include ...r3.Vector) *occupancyGrid {
+	g := &occupancyGrid{
+		cellSize: cellSizeMm,
+		min:      gridCell{math.MaxInt, math.MaxInt},
+		max:      gridCell{math.MinInt, math.MinInt},
+		blocked:  map[gridCell]bool{},
+	}
+	occupied := map[gridCell]bool{}
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		c := g.cellOf(p)
+		occupied[c] = true
+		g.extend(c)
+		return true
+	})
+	for _, p := range include {
+		g.extend(g.cellOf(p))
+	}
+
+	r := int(math.Ceil(clearanceMm / cellSizeMm))
+	for c := range occupied {
+		for dx := -r; dx <= r; dx++ {
+			for dy := -r; dy <= r; dy++ {
+				if dx*dx+dy*dy <= r*r {
+					g.blocked[gridCell{c.x + dx, c.y + dy}] = true
+				}
+			}
+		}
+	}
+	g.min = gridCell{g.min.x - r - 1, g.min.y - r - 1}
+	g.max = gridCell{g.max.x + r + 1, g.max.y + r + 1}
+	return g
+}
+
+func (g *occupancyGrid) extend(c gridCell) {
+	g.min = gridCell{utils.MinInt(g.min.x, c.x), utils.MinInt(g.min.y, c.y)}
+	g.max = gridCell{utils.MaxInt(g.max.x, c.x), utils.MaxInt(g.max.y, c.y)}
+}
+
+func (g *occupancyGrid) cellOf(p r3.Vector) gridCell {
+	return gridCell{int(math.Floor(p.X / g.cellSize)), int(math.Floor(p.Y / g.cellSize))}
+}
+
+func (g *occupancyGrid) center(c gridCell) r3.Vector {
+	return r3.Vector{X: (float64(c.x) + 0.5) * g.cellSize, Y: (float64(c.y) + 0.5) * g.cellSize}
+}
+
+func (g *occupancyGrid) free(c gridCell) bool {
+	return c.x >= g.min.x && c.x <= g.max.x && c.y >= g.min.y && c.y <= g.max.y && !g.blocked[c]
+}
+
+// plan finds the shortest 8-connected path of free cells from start to goal with A*. The start
+// cell may be blocked since the base is already there, diagonal steps never cut a blocked corner.
+func (g *occupancyGrid) plan(start, goal gridCell) ([]gridCell, error) {
+	if g.blocked[goal] {
+		return nil, errors.New("destination is too close to an obstacle on the map")
+	}
+	estimate := func(c gridCell) float64 {
+		return math.Hypot(float64(c.x-goal.x), float64(c.y-goal.y))
+	}
+	cost := map[gridCell]float64{start: 0}
+	from := map[gridCell]gridCell{}
+	open := &cellQueue{{cell: start, priority: estimate(start)}}
+	for open.Len() > 0 {
+		c := heap.Pop(open).(cellItem).cell
+		if c == goal {
+			path := []gridCell{c}
+			for c != start {
+				c = from[c]
+				path = append([]gridCell{c}, path...)
+			}
+			return path, nil
+		}
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				next := gridCell{c.x + dx, c.y + dy}
+				if next == c || !g.free(next) {
+					continue
+				}
+				if dx != 0 && dy != 0 && (!g.free(gridCell{c.x + dx, c.y}) || !g.free(gridCell{c.x, c.y + dy})) {
+					continue
+				}
+				nextCost := cost[c] + math.Hypot(float64(dx), float64(dy))
+				if known, ok := cost[next]; ok && known <= nextCost {
+					continue
+				}
+				cost[next] = nextCost
+				from[next] = c
+				heap.Push(open, cellItem{cell: next, priority: nextCost + estimate(next)})
+			}
+		}
+	}
+	return nil, errors.New("no path to the destination on the map, it may be blocked by an obstacle")
+}
+
+// firstTurn returns the last cell of the path before it changes direction for the first time.
+func firstTurn(path []gridCell) gridCell {
+	for i := 1; i < len(path)-1; i++ {
+		if path[i].x-path[i-1].x != path[i+1].x-path[i].x || path[i].y-path[i-1].y != path[i+1].y-path[i].y {
+			return path[i]
+		}
+	}
+	return path[len(path)-1]
+}
+
+type cellItem struct {
+	cell     gridCell
+	priority float64
+}
+
+// cellQueue is a min heap of cells by priority.
+type cellQueue []cellItem
+
+func (q cellQueue) Len() int            { return len(q) }
+func (q cellQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q cellQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *cellQueue) Push(x interface{}) { *q = append(*q, x.(cellItem)) }
+
+func (q *cellQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,169 @@
+package builtin_test
+
+import (
+	"context"
+	"image"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/components/base/fake"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/services/motion/builtin"
+	"go.viam.com/rdk/services/slam"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision"
+)
+
+// simulatedRover is a base that moves exactly as told and reports where it is through a SLAM service.
+type simulatedRover struct {
+	mu      sync.Mutex
+	pos     r3.Vector
+	yawDeg  float64
+	legs    [][2]r3.Vector
+	stopped int
+}
+
+func (sr *simulatedRover) spin(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.yawDeg += angleDeg
+	return nil
+}
+
+func (sr *simulatedRover) moveStraight(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	yaw := utils.DegToRad(sr.yawDeg)
+	next := sr.pos.Add(r3.Vector{X: -math.Sin(yaw), Y: math.Cos(yaw)}.Mul(float64(distanceMm)))
+	sr.legs = append(sr.legs, [2]r3.Vector{sr.pos, next})
+	sr.pos = next
+	return nil
+}
+
+func (sr *simulatedRover) stop(ctx context.Context, extra map[string]interface{}) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.stopped++
+	return nil
+}
+
+func (sr *simulatedRover) position(ctx context.Context, name string, extra map[string]interface{}) (*referenceframe.PoseInFrame, error) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	pose := spatialmath.NewPoseFromOrientation(sr.pos, &spatialmath.EulerAngles{Yaw: utils.DegToRad(sr.yawDeg)})
+	return referenceframe.NewPoseInFrame(name, pose), nil
+}
+
+func setupMoveOnMap(t *testing.T, mapPoints []r3.Vector) (motion.LocalService, *simulatedRover) {
+	t.Helper()
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	rover := &simulatedRover{}
+	injectBase := &inject.Base{LocalBase: &fake.Base{}}
+	injectBase.SpinFunc = rover.spin
+	injectBase.MoveStraightFunc = rover.moveStraight
+	injectBase.StopFunc = rover.stop
+
+	pc := pointcloud.New()
+	for _, p := range mapPoints {
+		test.That(t, pc.Set(p, pointcloud.NewBasicData()), test.ShouldBeNil)
+	}
+	injectSlam := &inject.SLAMService{}
+	injectSlam.PositionFunc = rover.position
+	injectSlam.GetMapFunc = func(ctx context.Context, name, mimeType string, cp *referenceframe.PoseInFrame,
+		include bool, extra map[string]interface{},
+	) (string, image.Image, *vision.Object, error) {
+		test.That(t, mimeType, test.ShouldEqual, utils.MimeTypePCD)
+		return mimeType, nil, &vision.Object{PointCloud: pc}, nil
+	}
+
+	injectRobot := &inject.Robot{}
+	injectRobot.ResourceByNameFunc = func(name resource.Name) (interface{}, error) {
+		switch name {
+		case base.Named("rover"):
+			return injectBase, nil
+		case slam.Named("slam"):
+			return injectSlam, nil
+		default:
+			return nil, utils.NewResourceNotFoundError(name)
+		}
+	}
+	svc, err := builtin.NewBuiltIn(ctx, injectRobot, config.Service{}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	return svc.(motion.LocalService), rover
+}
+
+func TestMoveOnMap(t *testing.T) {
+	ctx := context.Background()
+	destination := spatialmath.NewPoseFromPoint(r3.Vector{Y: 2000})
+	extra := map[string]interface{}{"clearance_mm": 200.}
+
+	t.Run("drives around a wall", func(t *testing.T) {
+		// a wall across the way to the destination, only open past x = 500
+		var wall []r3.Vector
+		for x := -1500.; x <= 500; x += 50 {
+			wall = append(wall, r3.Vector{X: x, Y: 1000})
+		}
+		svc, rover := setupMoveOnMap(t, wall)
+
+		success, err := svc.MoveOnMap(ctx, base.Named("rover"), destination, slam.Named("slam"), extra)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, success, test.ShouldBeTrue)
+
+		rover.mu.Lock()
+		defer rover.mu.Unlock()
+		test.That(t, rover.pos.Distance(destination.Point()), test.ShouldBeLessThanOrEqualTo, 100)
+		test.That(t, rover.stopped, test.ShouldEqual, 1)
+		test.That(t, len(rover.legs), test.ShouldBeGreaterThan, 1)
+		for _, leg := range rover.legs {
+			from, to := leg[0], leg[1]
+			if (from.Y-1000)*(to.Y-1000) > 0 {
+				continue
+			}
+			crossing := from.X + (to.X-from.X)*(1000-from.Y)/(to.Y-from.Y)
+			test.That(t, crossing, test.ShouldBeGreaterThan, 500)
+		}
+	})
+
+	t.Run("stops when the destination is walled off", func(t *testing.T) {
+		var box []r3.Vector
+		for d := -500.; d <= 500; d += 50 {
+			box = append(box,
+				r3.Vector{X: d, Y: 1500}, r3.Vector{X: d, Y: 2500},
+				r3.Vector{X: -500, Y: 2000 + d}, r3.Vector{X: 500, Y: 2000 + d},
+			)
+		}
+		svc, rover := setupMoveOnMap(t, box)
+
+		success, err := svc.MoveOnMap(ctx, base.Named("rover"), destination, slam.Named("slam"), extra)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "no path to the destination")
+		test.That(t, success, test.ShouldBeFalse)
+
+		rover.mu.Lock()
+		defer rover.mu.Unlock()
+		test.That(t, rover.legs, test.ShouldBeEmpty)
+		test.That(t, rover.stopped, test.ShouldEqual, 1)
+	})
+
+	t.Run("bad options", func(t *testing.T) {
+		svc, _ := setupMoveOnMap(t, nil)
+		_, err := svc.MoveOnMap(ctx, base.Named("rover"), destination, slam.Named("slam"), map[string]interface{}{"cell_size_mm": -1.})
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = svc.MoveOnMap(ctx, base.Named("nope"), destination, slam.Named("slam"), nil)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,130 @@
+package builtin
+
+import (
+	"context"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/gripper"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/framesystem"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+// Pick picks up an object with the named gripper, which must be mounted on the named arm. It opens
+// the gripper, moves it to the grasp pose offset by approachOffset, moves in to the grasp pose,
+// grabs, and moves back out to the grasp pose offset by retreatOffset. The offsets are in the frame
+// of the grasp pose, so an approachOffset of {0, 0, 100} comes down from 100mm above in a world
+// frame with Z up. Every move is planned like any Move, so it is checked for collisions with the
+// registered obstacles. Pick stops at the first stage that fails, including a grab that closes on
+// nothing, and returns its error.
+func (ms *builtIn) Pick(
+	ctx context.Context,
+	armName, gripperName resource.Name,
+	grasp *referenceframe.PoseInFrame,
+	approachOffset, retreatOffset r3.Vector,
+	extra map[string]interface{},
+) error {
+	if err := ms.checkMounted(ctx, armName, gripperName); err != nil {
+		return err
+	}
+	g, err := gripper.FromRobot(ms.r, gripperName.ShortName())
+	if err != nil {
+		return err
+	}
+	if err := g.Open(ctx, extra); err != nil {
+		return errors.Wrap(err, "opening gripper")
+	}
+	if _, err := ms.Move(ctx, gripperName, offsetPose(grasp, approachOffset), &referenceframe.WorldState{}, extra); err != nil {
+		return errors.Wrap(err, "moving to approach")
+	}
+	if _, err := ms.Move(ctx, gripperName, grasp, &referenceframe.WorldState{}, extra); err != nil {
+		return errors.Wrap(err, "moving to grasp")
+	}
+	grabbed, err := g.Grab(ctx, extra)
+	if err != nil {
+		return errors.Wrap(err, "grabbing")
+	}
+	if !grabbed {
+		return errors.New("gripper grabbed nothing")
+	}
+	if _, err := ms.Move(ctx, gripperName, offsetPose(grasp, retreatOffset), &referenceframe.WorldState{}, extra); err != nil {
+		return errors.Wrap(err, "retreating")
+	}
+	return nil
+}
+
+// Place puts down the object held by the named gripper, which must be mounted on the named arm. It
+// moves the gripper to the place pose offset by approachOffset, moves in to the place pose, opens,
+// and moves back out to the place pose offset by retreatOffset. The offsets are in the frame of the
+// place pose, and every move is planned like any Move, as for Pick. A gripper that can tell whether
+// it is holding an object must be holding one; Place fails before moving if it is not. Place stops
+// at the first stage that fails and returns its error.
+func (ms *builtIn) Place(
+	ctx context.Context,
+	armName, gripperName resource.Name,
+	place *referenceframe.PoseInFrame,
+	approachOffset, retreatOffset r3.Vector,
+	extra map[string]interface{},
+) error {
+	if err := ms.checkMounted(ctx, armName, gripperName); err != nil {
+		return err
+	}
+	g, err := gripper.FromRobot(ms.r, gripperName.ShortName())
+	if err != nil {
+		return err
+	}
+	if checker, ok := utils.UnwrapProxy(g).(gripper.HoldingChecker); ok {
+		holding, err := checker.HoldingObject(ctx)
+		if err != nil {
+			return errors.Wrap(err, "checking gripper")
+		}
+		if !holding {
+			return errors.New("gripper is not holding anything")
+		}
+	}
+
+	if _, err := ms.Move(ctx, gripperName, offsetPose(place, approachOffset), &referenceframe.WorldState{}, extra); err != nil {
+		return errors.Wrap(err, "moving to approach")
+	}
+	if _, err := ms.Move(ctx, gripperName, place, &referenceframe.WorldState{}, extra); err != nil {
+		return errors.Wrap(err, "moving to place")
+	}
+	if err := g.Open(ctx, extra); err != nil {
+		return errors.Wrap(err, "opening gripper")
+	}
+	if _, err := ms.Move(ctx, gripperName, offsetPose(place, retreatOffset), &referenceframe.WorldState{}, extra); err != nil {
+		return errors.Wrap(err, "retreating")
+	}
+	return nil
+}
+
+// offsetPose returns the pose moved by the offset, in its own frame, keeping its orientation.
+func offsetPose(pose *referenceframe.PoseInFrame, by r3.Vector) *referenceframe.PoseInFrame {
+	return referenceframe.NewPoseInFrame(pose.FrameName(), spatialmath.Compose(spatialmath.NewPoseFromPoint(by), pose.Pose()))
+}
+
+// checkMounted returns an error unless the gripper's frame is a descendant of the arm's.
+func (ms *builtIn) checkMounted(ctx context.Context, armName, gripperName resource.Name) error {
+	frameSys, err := framesystem.RobotFrameSystem(ctx, ms.r, nil)
+	if err != nil {
+		return err
+	}
+	gripperFrame := frameSys.Frame(gripperName.ShortName())
+	if gripperFrame == nil {
+		return referenceframe.NewFrameMissingError(gripperName.ShortName())
+	}
+	parents, err := frameSys.TracebackFrame(gripperFrame)
+	if err != nil {
+		return err
+	}
+	for _, parent := range parents {
+		if parent.Name() == armName.ShortName() {
+			return nil
+		}
+	}
+	return errors.Errorf("gripper %q is not mounted on arm %q", gripperName.ShortName(), armName.ShortName())
+}
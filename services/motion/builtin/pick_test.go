@@ -0,0 +1,180 @@
+package builtin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/gripper"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	robotimpl "go.viam.com/rdk/robot/impl"
+	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/services/motion/builtin"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+// recordingArm records each time the arm is moved.
+type recordingArm struct {
+	arm.LocalArm
+	calls *[]string
+}
+
+func (a *recordingArm) GoToInputs(ctx context.Context, goal []referenceframe.Input) error {
+	if n := len(*a.calls); n == 0 || (*a.calls)[n-1] != "move" {
+		*a.calls = append(*a.calls, "move")
+	}
+	return a.LocalArm.GoToInputs(ctx, goal)
+}
+
+// holdingGripper is a gripper that can tell whether it is holding an object.
+type holdingGripper struct {
+	*inject.Gripper
+	holding bool
+}
+
+func (g *holdingGripper) HoldingObject(ctx context.Context) (bool, error) {
+	return g.holding, nil
+}
+
+// setupPickAndPlace returns a motion service moving the arm and gripper of moving_arm.json, with a
+// table registered as an obstacle, and the calls made to them. Open and Grab of the gripper are
+// recorded; Grab has to be injected.
+func setupPickAndPlace(t *testing.T) (robot.LocalRobot, motion.LocalService, *holdingGripper, *[]string) {
+	t.Helper()
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg, err := config.Read(ctx, "../data/moving_arm.json", logger)
+	test.That(t, err, test.ShouldBeNil)
+	myRobot, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	calls := &[]string{}
+	realArm, err := arm.FromRobot(myRobot, "pieceArm")
+	test.That(t, err, test.ShouldBeNil)
+	recordedArm := &recordingArm{LocalArm: realArm.(arm.LocalArm), calls: calls}
+	realGripper, err := gripper.FromRobot(myRobot, "pieceGripper")
+	test.That(t, err, test.ShouldBeNil)
+	g := &holdingGripper{Gripper: &inject.Gripper{LocalGripper: realGripper.(gripper.LocalGripper)}}
+	g.OpenFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		*calls = append(*calls, "open")
+		return nil
+	}
+	injectRobot := &inject.Robot{LocalRobot: myRobot}
+	injectRobot.ResourceByNameFunc = func(name resource.Name) (interface{}, error) {
+		switch name {
+		case arm.Named("pieceArm"):
+			return recordedArm, nil
+		case gripper.Named("pieceGripper"):
+			return g, nil
+		}
+		return myRobot.ResourceByName(name)
+	}
+	svc, err := builtin.NewBuiltIn(ctx, injectRobot, config.Service{}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	ms := svc.(motion.LocalService)
+
+	table, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{-600, -400, 150}), r3.Vector{300, 300, 100}, "table")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ms.AddObstacles(ctx, "table", referenceframe.NewGeometriesInFrame(
+		referenceframe.World, map[string]spatialmath.Geometry{"table": table})), test.ShouldBeNil)
+	return myRobot, ms, g, calls
+}
+
+func gripperPose(t *testing.T, ms motion.Service) spatialmath.Pose {
+	t.Helper()
+	pose, err := ms.GetPose(context.Background(), gripper.Named("pieceGripper"), referenceframe.World, nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	return pose.Pose()
+}
+
+// onTable returns the pose of the gripper reaching for something on the table at x, y.
+func onTable(x, y float64) *referenceframe.PoseInFrame {
+	return referenceframe.NewPoseInFrame(referenceframe.World, spatialmath.NewPoseFromOrientation(
+		r3.Vector{x, y, 360},
+		&spatialmath.OrientationVectorDegrees{OY: -1, Theta: 90},
+	))
+}
+
+func TestPick(t *testing.T) {
+	ctx := context.Background()
+	myRobot, ms, g, calls := setupPickAndPlace(t)
+	defer myRobot.Close(context.Background())
+
+	// a duck on the table, approached from above and lifted back up
+	grasp := onTable(-600, -400)
+	approach, retreat := r3.Vector{Z: 100}, r3.Vector{Z: 150}
+	g.GrabFunc = func(ctx context.Context, extra map[string]interface{}) (bool, error) {
+		*calls = append(*calls, "grab")
+		test.That(t, spatialmath.PoseAlmostCoincidentEps(gripperPose(t, ms), grasp.Pose(), 1), test.ShouldBeTrue)
+		return true, nil
+	}
+
+	t.Run("gripper not on the arm", func(t *testing.T) {
+		err := ms.Pick(ctx, arm.Named("c"), gripper.Named("pieceGripper"), grasp, approach, retreat, nil)
+		test.That(t, err, test.ShouldBeError, `gripper "pieceGripper" is not mounted on arm "c"`)
+		test.That(t, *calls, test.ShouldBeEmpty)
+	})
+
+	t.Run("pick", func(t *testing.T) {
+		*calls = nil
+		err := ms.Pick(ctx, arm.Named("pieceArm"), gripper.Named("pieceGripper"), grasp, approach, retreat, nil)
+		test.That(t, err, test.ShouldBeNil)
+		// approach and grasp are both moves of the arm
+		test.That(t, *calls, test.ShouldResemble, []string{"open", "move", "grab", "move"})
+		test.That(t, spatialmath.PoseAlmostCoincidentEps(gripperPose(t, ms), spatialmath.NewPoseFromOrientation(
+			r3.Vector{-600, -400, 510},
+			&spatialmath.OrientationVectorDegrees{OY: -1, Theta: 90},
+		), 1), test.ShouldBeTrue)
+	})
+
+	t.Run("nothing grabbed", func(t *testing.T) {
+		*calls = nil
+		g.GrabFunc = func(ctx context.Context, extra map[string]interface{}) (bool, error) {
+			*calls = append(*calls, "grab")
+			return false, nil
+		}
+		err := ms.Pick(ctx, arm.Named("pieceArm"), gripper.Named("pieceGripper"), grasp, approach, retreat, nil)
+		test.That(t, err, test.ShouldBeError, "gripper grabbed nothing")
+		test.That(t, *calls, test.ShouldResemble, []string{"open", "move", "grab"})
+	})
+}
+
+func TestPlace(t *testing.T) {
+	ctx := context.Background()
+	myRobot, ms, g, calls := setupPickAndPlace(t)
+	defer myRobot.Close(context.Background())
+
+	// the duck goes down on the other side of the table
+	place := onTable(-500, -300)
+	approach, retreat := r3.Vector{Z: 100}, r3.Vector{Z: 150}
+	g.OpenFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		*calls = append(*calls, "open")
+		test.That(t, spatialmath.PoseAlmostCoincidentEps(gripperPose(t, ms), place.Pose(), 1), test.ShouldBeTrue)
+		return nil
+	}
+
+	t.Run("not holding anything", func(t *testing.T) {
+		err := ms.Place(ctx, arm.Named("pieceArm"), gripper.Named("pieceGripper"), place, approach, retreat, nil)
+		test.That(t, err, test.ShouldBeError, "gripper is not holding anything")
+		test.That(t, *calls, test.ShouldBeEmpty)
+	})
+
+	t.Run("place", func(t *testing.T) {
+		g.holding = true
+		err := ms.Place(ctx, arm.Named("pieceArm"), gripper.Named("pieceGripper"), place, approach, retreat, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, *calls, test.ShouldResemble, []string{"move", "open", "move"})
+		test.That(t, spatialmath.PoseAlmostCoincidentEps(gripperPose(t, ms), spatialmath.NewPoseFromOrientation(
+			r3.Vector{-500, -300, 510},
+			&spatialmath.OrientationVectorDegrees{OY: -1, Theta: 90},
+		), 1), test.ShouldBeTrue)
+	})
+}
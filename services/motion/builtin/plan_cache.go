@@ -0,0 +1,188 @@
+package builtin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+)
+
+type plan = []map[string][]referenceframe.Input
+
+// planCache holds the plans of recent moves by a hash of everything planning depends on. When it is full the
+// oldest plan is dropped. A nil planCache holds nothing.
+type planCache struct {
+	mu    sync.Mutex
+	size  int
+	plans map[string]plan
+	order []string
+}
+
+func newPlanCache(size int) *planCache {
+	if size <= 0 {
+		return nil
+	}
+	return &planCache{size: size, plans: map[string]plan{}}
+}
+
+func (pc *planCache) get(key string) (plan, bool) {
+	if pc == nil {
+		return nil, false
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	p, ok := pc.plans[key]
+	return p, ok
+}
+
+func (pc *planCache) put(key string, p plan) {
+	if pc == nil {
+		return
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if _, ok := pc.plans[key]; !ok {
+		if len(pc.order) == pc.size {
+			delete(pc.plans, pc.order[0])
+			pc.order = pc.order[1:]
+		}
+		pc.order = append(pc.order, key)
+	}
+	pc.plans[key] = p
+}
+
+func (pc *planCache) clear() {
+	if pc == nil {
+		return
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.plans = map[string]plan{}
+	pc.order = nil
+}
+
+type geometriesKey struct {
+	Frame      string
+	Geometries map[string][]byte
+}
+
+// frameKey describes a frame of the frame system. Frames marshal without their geometries, so those are
+// added as they are at zero inputs.
+type frameKey struct {
+	Parent     string
+	Frame      json.RawMessage
+	Geometries *geometriesKey
+}
+
+var marshalDeterministic = proto.MarshalOptions{Deterministic: true}
+
+func newGeometriesKey(gf *referenceframe.GeometriesInFrame) (*geometriesKey, error) {
+	key := &geometriesKey{Frame: gf.FrameName(), Geometries: map[string][]byte{}}
+	for name, g := range gf.Geometries() {
+		b, err := marshalDeterministic.Marshal(g.ToProtobuf())
+		if err != nil {
+			return nil, err
+		}
+		key.Geometries[name] = b
+	}
+	return key, nil
+}
+
+func newGeometriesKeys(gfs []*referenceframe.GeometriesInFrame) ([]*geometriesKey, error) {
+	keys := make([]*geometriesKey, 0, len(gfs))
+	for _, gf := range gfs {
+		key, err := newGeometriesKey(gf)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// newFrameSystemKeys describes every frame of the frame system by name, so that a plan is not reused
+// once a frame, its parent or its geometry changes.
+func newFrameSystemKeys(fs referenceframe.FrameSystem) (map[string]frameKey, error) {
+	keys := map[string]frameKey{}
+	for _, name := range fs.FrameNames() {
+		frame := fs.Frame(name)
+		var key frameKey
+		if parent, err := fs.Parent(frame); err == nil {
+			key.Parent = parent.Name()
+		}
+		frameJSON, err := frame.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		key.Frame = frameJSON
+		// frames without geometries return an error, and are described by their JSON alone
+		if gf, err := frame.Geometries(make([]referenceframe.Input, len(frame.DoF()))); err == nil && gf != nil {
+			if key.Geometries, err = newGeometriesKey(gf); err != nil {
+				return nil, err
+			}
+		}
+		keys[name] = key
+	}
+	return keys, nil
+}
+
+// planCacheKey hashes everything a plan for moving a component to a goal depends on. Messages are marshaled
+// deterministically and maps are marshaled in key order, so identical requests hash the same.
+func planCacheKey(
+	componentName resource.Name,
+	goal *referenceframe.PoseInFrame,
+	seedMap map[string][]referenceframe.Input,
+	fs referenceframe.FrameSystem,
+	worldState *referenceframe.WorldState,
+	extra map[string]interface{},
+) (string, error) {
+	goalBytes, err := marshalDeterministic.Marshal(referenceframe.PoseInFrameToProtobuf(goal))
+	if err != nil {
+		return "", err
+	}
+	frames, err := newFrameSystemKeys(fs)
+	if err != nil {
+		return "", err
+	}
+	obstacles, err := newGeometriesKeys(worldState.Obstacles)
+	if err != nil {
+		return "", err
+	}
+	interactionSpaces, err := newGeometriesKeys(worldState.InteractionSpaces)
+	if err != nil {
+		return "", err
+	}
+	transformProtos, err := referenceframe.PoseInFramesToTransformProtobuf(worldState.Transforms)
+	if err != nil {
+		return "", err
+	}
+	transforms := make([][]byte, 0, len(transformProtos))
+	for _, transform := range transformProtos {
+		b, err := marshalDeterministic.Marshal(transform)
+		if err != nil {
+			return "", err
+		}
+		transforms = append(transforms, b)
+	}
+
+	request, err := json.Marshal(struct {
+		Component         string
+		Goal              []byte
+		Seed              map[string][]referenceframe.Input
+		Frames            map[string]frameKey
+		Obstacles         []*geometriesKey
+		InteractionSpaces []*geometriesKey
+		Transforms        [][]byte
+		Extra             map[string]interface{}
+	}{componentName.String(), goalBytes, seedMap, frames, obstacles, interactionSpaces, transforms, extra})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(request)
+	return hex.EncodeToString(sum[:]), nil
+}
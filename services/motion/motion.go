@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
 	servicepb "go.viam.com/api/service/motion/v1"
 	goutils "go.viam.com/utils"
 	"go.viam.com/utils/rpc"
@@ -14,6 +15,7 @@ import (
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/subtype"
 	"go.viam.com/rdk/utils"
 )
@@ -61,8 +63,53 @@ type Service interface {
 	) (*referenceframe.PoseInFrame, error)
 }
 
+// A LocalService is a motion service that can also drive a base across a SLAM map and keep
+// obstacles that every Move plans around.
+type LocalService interface {
+	Service
+	// MoveOnMap drives the named base to the destination, given in the frame of the SLAM
+	// service's map, planning around what the map shows as occupied.
+	MoveOnMap(
+		ctx context.Context,
+		componentName resource.Name,
+		destination spatialmath.Pose,
+		slamName resource.Name,
+		extra map[string]interface{},
+	) (bool, error)
+	// AddObstacles registers a named set of obstacles that is added to the world state of every
+	// later Move, so static surroundings do not have to be sent with each call.
+	AddObstacles(ctx context.Context, name string, obstacles *referenceframe.GeometriesInFrame) error
+	// RemoveObstacles unregisters the named set of obstacles.
+	RemoveObstacles(ctx context.Context, name string) error
+	// Obstacles returns the registered sets of obstacles by name.
+	Obstacles(ctx context.Context) (map[string]*referenceframe.GeometriesInFrame, error)
+	// GoToHome moves the named arm to its configured home position along a path checked for
+	// collisions, to retreat safely after a failed or aborted move.
+	GoToHome(ctx context.Context, componentName resource.Name, extra map[string]interface{}) (bool, error)
+	// Pick opens the named gripper, mounted on the named arm, moves it in to the grasp pose by way
+	// of the grasp offset by approachOffset, grabs and moves back out to the grasp offset by
+	// retreatOffset, checking every move for collisions.
+	Pick(
+		ctx context.Context,
+		armName, gripperName resource.Name,
+		grasp *referenceframe.PoseInFrame,
+		approachOffset, retreatOffset r3.Vector,
+		extra map[string]interface{},
+	) error
+	// Place moves the named gripper, mounted on the named arm, in to the place pose by way of the
+	// place pose offset by approachOffset, opens it and moves back out to the place pose offset by
+	// retreatOffset, checking every move for collisions.
+	Place(
+		ctx context.Context,
+		armName, gripperName resource.Name,
+		place *referenceframe.PoseInFrame,
+		approachOffset, retreatOffset r3.Vector,
+		extra map[string]interface{},
+	) error
+}
+
 var (
-	_ = Service(&reconfigurableMotionService{})
+	_ = LocalService(&reconfigurableMotionService{})
 	_ = resource.Reconfigurable(&reconfigurableMotionService{})
 	_ = goutils.ContextCloser(&reconfigurableMotionService{})
 )
@@ -146,6 +193,102 @@ func (svc *reconfigurableMotionService) GetPose(
 	return svc.actual.GetPose(ctx, componentName, destinationFrame, supplementalTransforms, extra)
 }
 
+func (svc *reconfigurableMotionService) MoveOnMap(
+	ctx context.Context,
+	componentName resource.Name,
+	destination spatialmath.Pose,
+	slamName resource.Name,
+	extra map[string]interface{},
+) (bool, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	local, ok := svc.actual.(LocalService)
+	if !ok {
+		return false, utils.NewUnimplementedInterfaceError((*LocalService)(nil), svc.actual)
+	}
+	return local.MoveOnMap(ctx, componentName, destination, slamName, extra)
+}
+
+func (svc *reconfigurableMotionService) GoToHome(
+	ctx context.Context,
+	componentName resource.Name,
+	extra map[string]interface{},
+) (bool, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	local, ok := svc.actual.(LocalService)
+	if !ok {
+		return false, utils.NewUnimplementedInterfaceError((*LocalService)(nil), svc.actual)
+	}
+	return local.GoToHome(ctx, componentName, extra)
+}
+
+func (svc *reconfigurableMotionService) Pick(
+	ctx context.Context,
+	armName, gripperName resource.Name,
+	grasp *referenceframe.PoseInFrame,
+	approachOffset, retreatOffset r3.Vector,
+	extra map[string]interface{},
+) error {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	local, ok := svc.actual.(LocalService)
+	if !ok {
+		return utils.NewUnimplementedInterfaceError((*LocalService)(nil), svc.actual)
+	}
+	return local.Pick(ctx, armName, gripperName, grasp, approachOffset, retreatOffset, extra)
+}
+
+func (svc *reconfigurableMotionService) Place(
+	ctx context.Context,
+	armName, gripperName resource.Name,
+	place *referenceframe.PoseInFrame,
+	approachOffset, retreatOffset r3.Vector,
+	extra map[string]interface{},
+) error {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	local, ok := svc.actual.(LocalService)
+	if !ok {
+		return utils.NewUnimplementedInterfaceError((*LocalService)(nil), svc.actual)
+	}
+	return local.Place(ctx, armName, gripperName, place, approachOffset, retreatOffset, extra)
+}
+
+func (svc *reconfigurableMotionService) AddObstacles(
+	ctx context.Context,
+	name string,
+	obstacles *referenceframe.GeometriesInFrame,
+) error {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	local, ok := svc.actual.(LocalService)
+	if !ok {
+		return utils.NewUnimplementedInterfaceError((*LocalService)(nil), svc.actual)
+	}
+	return local.AddObstacles(ctx, name, obstacles)
+}
+
+func (svc *reconfigurableMotionService) RemoveObstacles(ctx context.Context, name string) error {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	local, ok := svc.actual.(LocalService)
+	if !ok {
+		return utils.NewUnimplementedInterfaceError((*LocalService)(nil), svc.actual)
+	}
+	return local.RemoveObstacles(ctx, name)
+}
+
+func (svc *reconfigurableMotionService) Obstacles(ctx context.Context) (map[string]*referenceframe.GeometriesInFrame, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	local, ok := svc.actual.(LocalService)
+	if !ok {
+		return nil, utils.NewUnimplementedInterfaceError((*LocalService)(nil), svc.actual)
+	}
+	return local.Obstacles(ctx)
+}
+
 func (svc *reconfigurableMotionService) Close(ctx context.Context) error {
 	svc.mu.RLock()
 	defer svc.mu.RUnlock()
@@ -160,6 +303,7 @@ func (svc *reconfigurableMotionService) Reconfigure(ctx context.Context, newSvc
 	if !ok {
 		return utils.NewUnexpectedTypeError(svc, newSvc)
 	}
+	carryObstacles(ctx, svc.actual, rSvc.actual)
 	if err := goutils.TryClose(ctx, svc.actual); err != nil {
 		golog.Global().Errorw("error closing old", "error", err)
 	}
@@ -167,6 +311,37 @@ func (svc *reconfigurableMotionService) Reconfigure(ctx context.Context, newSvc
 	return nil
 }
 
+// carryObstacles registers the obstacles of the old service with the new one, so that they outlive a
+// reconfigure. Obstacles the new service already has under the same name are kept.
+func carryObstacles(ctx context.Context, oldSvc, newSvc Service) {
+	oldLocal, ok := oldSvc.(LocalService)
+	if !ok {
+		return
+	}
+	newLocal, ok := newSvc.(LocalService)
+	if !ok {
+		return
+	}
+	obstacles, err := oldLocal.Obstacles(ctx)
+	if err != nil {
+		golog.Global().Errorw("error getting obstacles to carry over", "error", err)
+		return
+	}
+	existing, err := newLocal.Obstacles(ctx)
+	if err != nil {
+		golog.Global().Errorw("error getting obstacles to carry over", "error", err)
+		return
+	}
+	for name, gf := range obstacles {
+		if _, ok := existing[name]; ok {
+			continue
+		}
+		if err := newLocal.AddObstacles(ctx, name, gf); err != nil {
+			golog.Global().Errorw("error carrying over obstacles", "name", name, "error", err)
+		}
+	}
+}
+
 // WrapWithReconfigurable wraps a Motion Service as a Reconfigurable.
 func WrapWithReconfigurable(s interface{}, name resource.Name) (resource.Reconfigurable, error) {
 	svc, ok := s.(Service)
@@ -16,6 +16,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/edaniels/gostream"
@@ -809,6 +810,44 @@ func TestCartographerDataProcess(t *testing.T) {
 		test.That(t, err, test.ShouldBeNil)
 	})
 
+	t.Run("Cartographer Data Process reads a fast lidar at the data rate", func(t *testing.T) {
+		var mu sync.Mutex
+		reads := 0
+		// the lidar always has a new scan ready, far faster than the data rate
+		fastCam := &inject.Camera{}
+		fastCam.NextPointCloudFunc = func(ctx context.Context) (pointcloud.PointCloud, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reads++
+			return pointcloud.New(), nil
+		}
+		fastCam.PropertiesFunc = func(ctx context.Context) (camera.Properties, error) {
+			return camera.Properties{}, nil
+		}
+		cams := []camera.Camera{fastCam}
+		camStreams := []gostream.VideoStream{gostream.NewEmbeddedVideoStream(fastCam)}
+		defer func() {
+			for _, stream := range camStreams {
+				test.That(t, stream.Close(context.Background()), test.ShouldBeNil)
+			}
+		}()
+
+		cancelCtx, cancelFunc := context.WithCancel(context.Background())
+		c := make(chan int, 100)
+		start := time.Now()
+		slamSvc.StartDataProcess(cancelCtx, cams, camStreams, c)
+
+		for i := 0; i < 3; i++ {
+			<-c
+		}
+		elapsed := time.Since(start)
+		cancelFunc()
+		test.That(t, elapsed, test.ShouldBeGreaterThanOrEqualTo, 3*validDataRateMS*time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		test.That(t, reads, test.ShouldBeBetweenOrEqual, 3, 4)
+	})
+
 	t.Run("Cartographer Data Process with lidar that errors during call to NextPointCloud", func(t *testing.T) {
 		badCam := &inject.Camera{}
 		badCam.NextPointCloudFunc = func(ctx context.Context) (pointcloud.PointCloud, error) {
@@ -177,6 +177,11 @@ func (b *box) EncompassedBy(g Geometry) (bool, error) {
 	return false, newCollisionTypeUnsupportedError(b, g)
 }
 
+// Contains returns whether the given point lies inside or on the surface of the box.
+func (b *box) Contains(pt r3.Vector) bool {
+	return pointVsBoxCollision(b, pt)
+}
+
 // closestPoint returns the closest point on the specified box to the specified point
 // Reference: https://github.com/gszauer/GamePhysicsCookbook/blob/a0b8ee0c39fed6d4b90bb6d2195004dfcf5a1115/Code/Geometry3D.cpp#L165
 func (b *box) closestPoint(pt r3.Vector) r3.Vector {
@@ -3,8 +3,10 @@ package spatialmath
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 
 	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
 )
 
@@ -25,6 +27,8 @@ type Geometry interface {
 	CollidesWith(Geometry) (bool, error)
 	DistanceFrom(Geometry) (float64, error)
 	EncompassedBy(Geometry) (bool, error)
+	// Contains returns whether the given point lies inside or on the surface of the geometry.
+	Contains(r3.Vector) bool
 	Label() string
 }
 
@@ -134,3 +138,29 @@ func NewGeometryFromProto(geometry *commonpb.Geometry) (Geometry, error) {
 	}
 	return nil, ErrGeometryTypeUnsupported
 }
+
+// BoundingBox returns the smallest axis-aligned box that holds all the given geometries.
+func BoundingBox(geometries []Geometry, label string) (Geometry, error) {
+	if len(geometries) == 0 {
+		return nil, errors.New("cannot bound an empty set of geometries")
+	}
+	lo := r3.Vector{X: math.Inf(1), Y: math.Inf(1), Z: math.Inf(1)}
+	hi := r3.Vector{X: math.Inf(-1), Y: math.Inf(-1), Z: math.Inf(-1)}
+	extend := func(pt r3.Vector, margin float64) {
+		lo = r3.Vector{X: math.Min(lo.X, pt.X-margin), Y: math.Min(lo.Y, pt.Y-margin), Z: math.Min(lo.Z, pt.Z-margin)}
+		hi = r3.Vector{X: math.Max(hi.X, pt.X+margin), Y: math.Max(hi.Y, pt.Y+margin), Z: math.Max(hi.Z, pt.Z+margin)}
+	}
+	for _, g := range geometries {
+		switch geometry := g.(type) {
+		case *box, *point:
+			for _, v := range geometry.Vertices() {
+				extend(v, 0)
+			}
+		case *sphere:
+			extend(geometry.pose.Point(), geometry.radius)
+		default:
+			return nil, fmt.Errorf("%w %s", ErrGeometryTypeUnsupported, fmt.Sprintf("%T", g))
+		}
+	}
+	return NewBox(NewPoseFromPoint(lo.Add(hi).Mul(0.5)), hi.Sub(lo), label)
+}
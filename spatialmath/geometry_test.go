@@ -530,3 +530,47 @@ func TestSphereVsPointEncompassed(t *testing.T) {
 	}
 	testGeometryEncompassed(t, cases)
 }
+
+func TestGeometryContains(t *testing.T) {
+	box := makeTestBox(&EulerAngles{0, 0, math.Pi / 4}, r3.Vector{10, 0, 0}, r3.Vector{2, 2, 2}, "")
+	test.That(t, box.Contains(r3.Vector{10, 0, 0}), test.ShouldBeTrue)
+	test.That(t, box.Contains(r3.Vector{11, 0, 1}), test.ShouldBeTrue)
+	test.That(t, box.Contains(r3.Vector{11, 1, 0}), test.ShouldBeFalse)
+	test.That(t, box.Contains(r3.Vector{10, 0, 1.1}), test.ShouldBeFalse)
+
+	sphere := makeTestSphere(r3.Vector{0, 0, 5}, 2, "")
+	test.That(t, sphere.Contains(r3.Vector{0, 1, 5}), test.ShouldBeTrue)
+	test.That(t, sphere.Contains(r3.Vector{0, 2, 5}), test.ShouldBeTrue)
+	test.That(t, sphere.Contains(r3.Vector{0, 2, 6}), test.ShouldBeFalse)
+
+	pt := NewPoint(r3.Vector{1, 2, 3}, "")
+	test.That(t, pt.Contains(r3.Vector{1, 2, 3}), test.ShouldBeTrue)
+	test.That(t, pt.Contains(r3.Vector{1, 2, 3.1}), test.ShouldBeFalse)
+
+	// containment agrees with collision against a point geometry
+	for _, p := range []r3.Vector{{10, 0, 0}, {11, 1, 0}, {0, 1, 5}, {0, 2, 6}} {
+		for _, g := range []Geometry{box, sphere} {
+			collides, err := g.CollidesWith(NewPoint(p, ""))
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, g.Contains(p), test.ShouldEqual, collides)
+		}
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	_, err := BoundingBox(nil, "")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	geometries := []Geometry{
+		makeTestBox(&EulerAngles{0, 0, math.Pi / 4}, r3.Vector{}, r3.Vector{2, 2, 2}, ""),
+		makeTestSphere(r3.Vector{5, 0, 0}, 1, ""),
+		NewPoint(r3.Vector{0, 0, -4}, ""),
+	}
+	bbox, err := BoundingBox(geometries, "bounds")
+	test.That(t, err, test.ShouldBeNil)
+	lo := r3.Vector{-math.Sqrt2, -math.Sqrt2, -4}
+	hi := r3.Vector{6, math.Sqrt2, 1}
+	expected := makeTestBox(NewZeroOrientation(), lo.Add(hi).Mul(0.5), hi.Sub(lo), "bounds")
+	test.That(t, bbox.AlmostEqual(expected), test.ShouldBeTrue)
+	test.That(t, bbox.Label(), test.ShouldEqual, "bounds")
+}
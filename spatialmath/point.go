@@ -125,6 +125,11 @@ func (pt *point) EncompassedBy(g Geometry) (bool, error) {
 	return pt.CollidesWith(g)
 }
 
+// Contains returns whether the given point is the same as this one.
+func (pt *point) Contains(other r3.Vector) bool {
+	return R3VectorAlmostEqual(pt.pose.Point(), other, CollisionBuffer)
+}
+
 // pointVsBoxCollision takes a box and a point as arguments and returns a bool describing if they are in collision. \
 // true == collision / false == no collision.
 func pointVsBoxCollision(b *box, pt r3.Vector) bool {
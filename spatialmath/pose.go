@@ -123,6 +123,12 @@ func PoseInverse(p Pose) Pose {
 	return newDualQuaternionFromPose(p).Invert()
 }
 
+// TransformPoint returns where a point given in the frame of a pose lands in the frame the pose is relative to, i.e. the point is
+// rotated by the orientation of the pose and then translated by its point.
+func TransformPoint(p Pose, pt r3.Vector) r3.Vector {
+	return Compose(p, NewPoseFromPoint(pt)).Point()
+}
+
 // Interpolate will return a new Pose that has been interpolated the set amount between two poses.
 // Note that position and orientation are interpolated separately, then the two are combined.
 // Note that slerp(q1, q2) != slerp(q2, q1)
@@ -127,6 +127,26 @@ func TestPoseAlmostEqual(t *testing.T) {
 	test.That(t, PoseAlmostCoincident(p1, p3), test.ShouldBeFalse)
 }
 
+func TestComposeInverseTransformPoint(t *testing.T) {
+	// a frame 10 along x and turned a quarter turn about z
+	p := NewPoseFromOrientation(r3.Vector{10, 0, 0}, &EulerAngles{Yaw: math.Pi / 2})
+	q := NewPoseFromOrientation(r3.Vector{1, 2, 3}, &OrientationVectorDegrees{OX: 1, Theta: 30})
+
+	test.That(t, R3VectorAlmostEqual(TransformPoint(p, r3.Vector{1, 0, 0}), r3.Vector{10, 1, 0}, 1e-8), test.ShouldBeTrue)
+	test.That(t, R3VectorAlmostEqual(TransformPoint(p, r3.Vector{0, 1, 5}), r3.Vector{9, 0, 5}, 1e-8), test.ShouldBeTrue)
+	test.That(t, R3VectorAlmostEqual(TransformPoint(NewZeroPose(), r3.Vector{1, 2, 3}), r3.Vector{1, 2, 3}, 1e-8), test.ShouldBeTrue)
+
+	// composing with the inverse gives back the identity, from either side
+	test.That(t, PoseAlmostEqual(Compose(p, PoseInverse(p)), NewZeroPose()), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqual(Compose(PoseInverse(q), q), NewZeroPose()), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqual(Compose(Compose(p, q), PoseInverse(q)), p), test.ShouldBeTrue)
+
+	// transforming by a composition is transforming by each pose in turn, and the inverse undoes it
+	pt := r3.Vector{4, -5, 6}
+	test.That(t, R3VectorAlmostEqual(TransformPoint(Compose(p, q), pt), TransformPoint(p, TransformPoint(q, pt)), 1e-8), test.ShouldBeTrue)
+	test.That(t, R3VectorAlmostEqual(TransformPoint(PoseInverse(q), TransformPoint(q, pt)), pt, 1e-8), test.ShouldBeTrue)
+}
+
 var (
 	ov  = &OrientationVector{math.Pi / 2, 0, 0, -1}
 	p1b = NewPoseFromOrientation(r3.Vector{1, 2, 3}, ov)
@@ -142,6 +142,11 @@ func (s *sphere) EncompassedBy(g Geometry) (bool, error) {
 	return true, newCollisionTypeUnsupportedError(s, g)
 }
 
+// Contains returns whether the given point lies inside or on the surface of the sphere.
+func (s *sphere) Contains(pt r3.Vector) bool {
+	return sphereVsPointDistance(s, pt) <= 0
+}
+
 // sphereVsPointDistance takes a sphere and a point as arguments and returns a floating point number.  If this number is nonpositive it
 // represents the penetration depth of the point within the sphere.  If the returned float is positive it represents the separation
 // distance between the point and the sphere, which are not in collision.
@@ -16,10 +16,12 @@ type Base struct {
 	MoveStraightFunc func(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error
 	SpinFunc         func(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error
 	WidthFunc        func(ctx context.Context) (int, error)
+	PropertiesFunc   func(ctx context.Context, extra map[string]interface{}) (*base.Properties, error)
 	StopFunc         func(ctx context.Context, extra map[string]interface{}) error
 	IsMovingFunc     func(context.Context) (bool, error)
 	CloseFunc        func(ctx context.Context) error
 	SetPowerFunc     func(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error
+	SetVelocityFunc  func(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error
 }
 
 // MoveStraight calls the injected MoveStraight or the real version.
@@ -46,6 +48,14 @@ func (b *Base) Width(ctx context.Context) (int, error) {
 	return b.WidthFunc(ctx)
 }
 
+// Properties calls the injected Properties or the real version.
+func (b *Base) Properties(ctx context.Context, extra map[string]interface{}) (*base.Properties, error) {
+	if b.PropertiesFunc == nil {
+		return b.LocalBase.Properties(ctx, extra)
+	}
+	return b.PropertiesFunc(ctx, extra)
+}
+
 // Stop calls the injected Stop or the real version.
 func (b *Base) Stop(ctx context.Context, extra map[string]interface{}) error {
 	if b.StopFunc == nil {
@@ -85,3 +95,11 @@ func (b *Base) SetPower(ctx context.Context, linear, angular r3.Vector, extra ma
 	}
 	return b.SetPowerFunc(ctx, linear, angular, extra)
 }
+
+// SetVelocity calls the injected SetVelocity or the real version.
+func (b *Base) SetVelocity(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+	if b.SetVelocityFunc == nil {
+		return b.LocalBase.SetVelocity(ctx, linear, angular, extra)
+	}
+	return b.SetVelocityFunc(ctx, linear, angular, extra)
+}
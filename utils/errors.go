@@ -1,16 +1,94 @@
 package utils
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.viam.com/rdk/resource"
 )
 
+var (
+	// ErrResourceNotFound is what errors.Is matches every error about a missing resource against.
+	ErrResourceNotFound = errors.New("resource not found")
+	// ErrResourceWrongType is what errors.Is matches every error about a resource of the wrong type against.
+	ErrResourceWrongType = errors.New("resource is of the wrong type")
+)
+
+// lookupError keeps the message of a failed resource lookup while matching the kind of failure with errors.Is.
+type lookupError struct {
+	error
+	kind error
+}
+
+func (e *lookupError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *lookupError) Unwrap() error {
+	return e.error
+}
+
 // NewResourceNotFoundError is used when a resource is not found.
 func NewResourceNotFoundError(name resource.Name) error {
-	return errors.Errorf("resource %q not found", name)
+	return &lookupError{errors.Errorf("resource %q not found", name), ErrResourceNotFound}
+}
+
+// NotFoundByNameError is returned when a subtype has no resource of the given name. Kind describes
+// the resources of the subtype, like "arm".
+type NotFoundByNameError struct {
+	Kind string
+	Name string
+}
+
+func (e *NotFoundByNameError) Error() string {
+	return fmt.Sprintf("no %s with name (%s)", e.Kind, e.Name)
+}
+
+// Is makes the error match ErrResourceNotFound.
+func (e *NotFoundByNameError) Is(target error) bool {
+	return target == ErrResourceNotFound
+}
+
+// GRPCStatus lets clients tell a missing resource apart from other errors by its code.
+func (e *NotFoundByNameError) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// WrongTypeByNameError is returned when the resource of the given name is not of the subtype looked
+// up. Kind describes the subtype with its article, like "an arm".
+type WrongTypeByNameError struct {
+	Kind string
+	Name string
+}
+
+func (e *WrongTypeByNameError) Error() string {
+	return fmt.Sprintf("resource with name (%s) is not %s", e.Name, e.Kind)
+}
+
+// Is makes the error match ErrResourceWrongType.
+func (e *WrongTypeByNameError) Is(target error) bool {
+	return target == ErrResourceWrongType
+}
+
+// GRPCStatus lets clients tell a resource of the wrong type apart from other errors by its code.
+func (e *WrongTypeByNameError) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// NewNotFoundByNameError is used when a subtype has no resource of the given name. kind describes
+// the resources of the subtype, like "arm".
+func NewNotFoundByNameError(kind, name string) error {
+	return &NotFoundByNameError{Kind: kind, Name: name}
+}
+
+// NewWrongTypeByNameError is used when the resource of the given name is not of the subtype looked up.
+// kind describes the subtype with its article, like "an arm".
+func NewWrongTypeByNameError(kind, name string) error {
+	return &WrongTypeByNameError{Kind: kind, Name: name}
 }
 
 // NewResourceNotAvailableError is used when a resource is not available because of some error.
@@ -25,17 +103,20 @@ func NewRemoteResourceClashError(name string) error {
 
 // DependencyNotFoundError is used when a resource is not found in a dependencies.
 func DependencyNotFoundError(name string) error {
-	return errors.Errorf("%q missing from dependencies", name)
+	return &lookupError{errors.Errorf("%q missing from dependencies", name), ErrResourceNotFound}
 }
 
 // DependencyTypeError is used when a resource doesn't implement the expected interface.
 func DependencyTypeError(name string, expected, actual interface{}) error {
-	return errors.Errorf("dependency %q should be an implementation of %s but it was a %T", name, typeStr(expected), actual)
+	return &lookupError{
+		errors.Errorf("dependency %q should be an implementation of %s but it was a %T", name, typeStr(expected), actual),
+		ErrResourceWrongType,
+	}
 }
 
 // NewUnexpectedTypeError is used when there is a type mismatch.
 func NewUnexpectedTypeError(expected, actual interface{}) error {
-	return errors.Errorf("expected %s but got %T", typeStr(expected), actual)
+	return &lookupError{errors.Errorf("expected %s but got %T", typeStr(expected), actual), ErrResourceWrongType}
 }
 
 func typeStr(of interface{}) string {
@@ -57,5 +138,5 @@ func typeStr(of interface{}) string {
 // NewUnimplementedInterfaceError is used when there is a failed interface check.
 // Future: This should also tell you that expected is not even an interface.
 func NewUnimplementedInterfaceError(expected, actual interface{}) error {
-	return errors.Errorf("expected implementation of %s but got %T", typeStr(expected), actual)
+	return &lookupError{errors.Errorf("expected implementation of %s but got %T", typeStr(expected), actual), ErrResourceWrongType}
 }
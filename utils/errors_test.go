@@ -3,7 +3,12 @@ package utils
 import (
 	"testing"
 
+	"github.com/pkg/errors"
 	"go.viam.com/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/resource"
 )
 
 func TestDependencyTypeError(t *testing.T) {
@@ -91,3 +96,39 @@ type (
 	someStruct struct{}
 	someIfc    interface{}
 )
+
+func TestLookupErrors(t *testing.T) {
+	notFound := []error{
+		NewResourceNotFoundError(resource.NameFromSubtype(resource.NewSubtype("rdk", "component", "arm"), "arm1")),
+		NewNotFoundByNameError("arm", "arm1"),
+		DependencyNotFoundError("arm1"),
+	}
+	for _, err := range notFound {
+		test.That(t, errors.Is(err, ErrResourceNotFound), test.ShouldBeTrue)
+		test.That(t, errors.Is(err, ErrResourceWrongType), test.ShouldBeFalse)
+	}
+	test.That(t, NewNotFoundByNameError("arm", "arm1").Error(), test.ShouldEqual, "no arm with name (arm1)")
+
+	wrongType := []error{
+		NewWrongTypeByNameError("an arm", "arm1"),
+		DependencyTypeError("arm1", (*someIfc)(nil), 1),
+		NewUnexpectedTypeError((*someIfc)(nil), 1),
+		NewUnimplementedInterfaceError((*someIfc)(nil), 1),
+	}
+	for _, err := range wrongType {
+		test.That(t, errors.Is(err, ErrResourceWrongType), test.ShouldBeTrue)
+		test.That(t, errors.Is(err, ErrResourceNotFound), test.ShouldBeFalse)
+	}
+	test.That(t, NewWrongTypeByNameError("an arm", "arm1").Error(), test.ShouldEqual, "resource with name (arm1) is not an arm")
+
+	// wrapping keeps the kind
+	test.That(t, errors.Is(errors.Wrap(NewNotFoundByNameError("arm", "arm1"), "moving"), ErrResourceNotFound), test.ShouldBeTrue)
+
+	// lookups by name tell clients what went wrong by their code
+	test.That(t, status.Code(NewNotFoundByNameError("arm", "arm1")), test.ShouldEqual, codes.NotFound)
+	test.That(t, status.Code(NewWrongTypeByNameError("an arm", "arm1")), test.ShouldEqual, codes.InvalidArgument)
+	var byName *NotFoundByNameError
+	test.That(t, errors.As(NewNotFoundByNameError("arm", "arm1"), &byName), test.ShouldBeTrue)
+	test.That(t, byName.Kind, test.ShouldEqual, "arm")
+	test.That(t, byName.Name, test.ShouldEqual, "arm1")
+}
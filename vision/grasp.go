@@ -0,0 +1,55 @@
+package vision
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// GraspConfig controls how a top down grasp approaches an object.
+type GraspConfig struct {
+	// ApproachHeightMm is how far above the top of the object the gripper lines up before it descends.
+	ApproachHeightMm float64
+	// DepthMm is how far below the top of the object the gripper closes. Defaults to half of the object's height.
+	DepthMm float64
+}
+
+// TopDownGrasp proposes poses for grasping an object from above, given its bounding geometry and the pose of
+// the geometry's frame in the arm's frame. The approach pose is straight above the center of the object and
+// the grasp pose is where the gripper closes. Both point the gripper down, and theta is picked so that
+// theta 0 lines up with the narrower horizontal side of the object and 90 degrees with the wider one.
+func TopDownGrasp(object spatialmath.Geometry, objectToArm spatialmath.Pose, cfg GraspConfig) (spatialmath.Pose, spatialmath.Pose, error) {
+	if object == nil {
+		return nil, nil, errors.New("cannot grasp an object without a geometry")
+	}
+	if cfg.ApproachHeightMm < 0 || cfg.DepthMm < 0 {
+		return nil, nil, errors.New("approach height and depth cannot be negative")
+	}
+	vertices := object.Transform(objectToArm).Vertices()
+	if len(vertices) == 0 {
+		return nil, nil, errors.New("cannot grasp an object without vertices")
+	}
+	lo, hi := vertices[0], vertices[0]
+	for _, v := range vertices[1:] {
+		lo = r3.Vector{X: math.Min(lo.X, v.X), Y: math.Min(lo.Y, v.Y), Z: math.Min(lo.Z, v.Z)}
+		hi = r3.Vector{X: math.Max(hi.X, v.X), Y: math.Max(hi.Y, v.Y), Z: math.Max(hi.Z, v.Z)}
+	}
+
+	depth := cfg.DepthMm
+	if depth == 0 {
+		depth = (hi.Z - lo.Z) / 2
+	}
+	theta := 0.
+	if hi.X-lo.X > hi.Y-lo.Y {
+		theta = 90
+	}
+	orientation := &spatialmath.OrientationVectorDegrees{OZ: -1, Theta: theta}
+	center := lo.Add(hi).Mul(0.5)
+
+	approach := spatialmath.NewPoseFromOrientation(r3.Vector{X: center.X, Y: center.Y, Z: hi.Z + cfg.ApproachHeightMm}, orientation)
+	grasp := spatialmath.NewPoseFromOrientation(r3.Vector{X: center.X, Y: center.Y, Z: hi.Z - depth}, orientation)
+	return approach, grasp, nil
+}
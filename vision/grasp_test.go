@@ -0,0 +1,38 @@
+package vision
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+func TestTopDownGrasp(t *testing.T) {
+	// a 40x100x60 box whose center is at (0, 0, 30) in the camera frame, the camera being 500 in front of the arm
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 30}), r3.Vector{40, 100, 60}, "duck")
+	test.That(t, err, test.ShouldBeNil)
+	cameraToArm := spatialmath.NewPoseFromPoint(r3.Vector{500, 0, 0})
+
+	approach, grasp, err := TopDownGrasp(box, cameraToArm, GraspConfig{ApproachHeightMm: 100})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.R3VectorAlmostEqual(approach.Point(), r3.Vector{500, 0, 160}, 1e-6), test.ShouldBeTrue)
+	test.That(t, spatialmath.R3VectorAlmostEqual(grasp.Point(), r3.Vector{500, 0, 30}, 1e-6), test.ShouldBeTrue)
+	ov := grasp.Orientation().OrientationVectorDegrees()
+	test.That(t, ov.OZ, test.ShouldAlmostEqual, -1)
+	test.That(t, ov.Theta, test.ShouldAlmostEqual, 0)
+
+	// the wider side along x turns the gripper
+	box, err = spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 30}), r3.Vector{100, 40, 60}, "duck")
+	test.That(t, err, test.ShouldBeNil)
+	_, grasp, err = TopDownGrasp(box, cameraToArm, GraspConfig{ApproachHeightMm: 100, DepthMm: 10})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.R3VectorAlmostEqual(grasp.Point(), r3.Vector{500, 0, 50}, 1e-6), test.ShouldBeTrue)
+	test.That(t, grasp.Orientation().OrientationVectorDegrees().Theta, test.ShouldAlmostEqual, 90)
+
+	_, _, err = TopDownGrasp(box, cameraToArm, GraspConfig{ApproachHeightMm: -1})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = TopDownGrasp(nil, cameraToArm, GraspConfig{})
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -152,6 +152,7 @@ func SegmentPlane(ctx context.Context, cloud pc.PointCloud, nIterations int, thr
 	for i, result := range bestResults {
 		if result.inliers > bestInliers {
 			bestIdx = i
+			bestInliers = result.inliers
 		}
 	}
 	bestEquation = bestResults[bestIdx].equation
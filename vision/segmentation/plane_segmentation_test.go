@@ -93,6 +93,38 @@ func TestSegmentPlane(t *testing.T) {
 	test.That(t, math.Abs(dot), test.ShouldBeGreaterThanOrEqualTo, tol)
 }
 
+func TestSegmentGroundPlane(t *testing.T) {
+	// a 20x20 floor with a 5x5x5 box sitting above it
+	cloud := pc.New()
+	for x := 0.; x < 20; x++ {
+		for y := 0.; y < 20; y++ {
+			test.That(t, cloud.Set(pc.NewVector(x, y, 0), nil), test.ShouldBeNil)
+		}
+	}
+	for x := 5.; x < 10; x++ {
+		for y := 5.; y < 10; y++ {
+			for z := 10.; z < 15; z++ {
+				test.That(t, cloud.Set(pc.NewVector(x, y, z), nil), test.ShouldBeNil)
+			}
+		}
+	}
+
+	plane, nonPlane, err := SegmentPlane(context.Background(), cloud, 1000, 0.1)
+	test.That(t, err, test.ShouldBeNil)
+	eq := plane.Equation()
+	test.That(t, math.Abs(eq[2]), test.ShouldAlmostEqual, 1)
+	test.That(t, eq[3], test.ShouldAlmostEqual, 0)
+
+	inliers, err := plane.PointCloud()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inliers.Size(), test.ShouldEqual, 400)
+	test.That(t, nonPlane.Size(), test.ShouldEqual, 125)
+	nonPlane.Iterate(0, 0, func(p r3.Vector, d pc.Data) bool {
+		test.That(t, p.Z, test.ShouldBeGreaterThanOrEqualTo, 10)
+		return true
+	})
+}
+
 func TestDepthMapToPointCloud(t *testing.T) {
 	d, err := rimage.NewDepthMapFromFile(
 		context.Background(),
@@ -90,11 +90,19 @@ func (rcc *RadiusClusteringConfig) RadiusClustering(ctx context.Context, c camer
 		}
 	}
 	// do the segmentation
-	segments, err := segmentPointCloudObjects(nonPlane, rcc.ClusteringRadiusMm, rcc.MinPtsInSegment)
+	return ClusterObjects(nonPlane, rcc.ClusteringRadiusMm, rcc.MinPtsInSegment, rcc.Label)
+}
+
+// ClusterObjects groups the points of a cloud into objects, where each point is within radius of another
+// point of its object (euclidean clustering). Objects with fewer than nMin points are dropped, and every
+// object comes with its bounding box. Remove the planes from the cloud first so that objects resting on a
+// surface aren't merged through it. An empty cloud has no objects.
+func ClusterObjects(cloud pc.PointCloud, radius float64, nMin int, label string) ([]*vision.Object, error) {
+	segments, err := segmentPointCloudObjects(cloud, radius, nMin)
 	if err != nil {
 		return nil, err
 	}
-	objects, err := NewSegmentsFromSlice(segments, rcc.Label)
+	objects, err := NewSegmentsFromSlice(segments, label)
 	if err != nil {
 		return nil, err
 	}
@@ -2,9 +2,11 @@ package segmentation_test
 
 import (
 	"context"
+	"sort"
 	"testing"
 
 	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
 	"go.viam.com/test"
 	"go.viam.com/utils/artifact"
 
@@ -91,3 +93,34 @@ func testSegmentation(t *testing.T, segments []*vision.Object, expectedLabel str
 		test.That(t, box.Label(), test.ShouldEqual, expectedLabel)
 	}
 }
+
+func TestClusterObjects(t *testing.T) {
+	// two 3x3x3 blobs with points 1 apart, 20 apart from each other, and a lone point
+	cloud := pc.New()
+	for _, offset := range []r3.Vector{{0, 0, 0}, {20, 0, 0}} {
+		for x := 0.; x < 3; x++ {
+			for y := 0.; y < 3; y++ {
+				for z := 0.; z < 3; z++ {
+					test.That(t, cloud.Set(offset.Add(r3.Vector{x, y, z}), nil), test.ShouldBeNil)
+				}
+			}
+		}
+	}
+	test.That(t, cloud.Set(pc.NewVector(10, 10, 10), nil), test.ShouldBeNil)
+
+	objects, err := segmentation.ClusterObjects(cloud, 1.5, 5, "blob")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, objects, test.ShouldHaveLength, 2)
+	centers := []r3.Vector{}
+	for _, o := range objects {
+		test.That(t, o.Size(), test.ShouldEqual, 27)
+		test.That(t, o.Geometry.Label(), test.ShouldEqual, "blob")
+		centers = append(centers, o.Geometry.Pose().Point())
+	}
+	sort.Slice(centers, func(i, j int) bool { return centers[i].X < centers[j].X })
+	test.That(t, centers, test.ShouldResemble, []r3.Vector{{1, 1, 1}, {21, 1, 1}})
+
+	objects, err = segmentation.ClusterObjects(pc.New(), 1.5, 5, "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, objects, test.ShouldBeEmpty)
+}
@@ -19,7 +19,9 @@ import (
 	"go.viam.com/utils/perf"
 	"go.viam.com/utils/rpc"
 
+	"go.viam.com/rdk/commandlog"
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/robot"
 	robotimpl "go.viam.com/rdk/robot/impl"
 	"go.viam.com/rdk/robot/web"
 	weboptions "go.viam.com/rdk/robot/web/options"
@@ -30,11 +32,16 @@ type Arguments struct {
 	AllowInsecureCreds         bool   `flag:"allow-insecure-creds,usage=allow connections to send credentials over plaintext"`
 	ConfigFile                 string `flag:"config,usage=robot config file"`
 	CPUProfile                 string `flag:"cpuprofile,usage=write cpu profile to file"`
+	CommandLog                 string `flag:"command-log,usage=record every actuation commanded to file"`
 	Debug                      bool   `flag:"debug"`
 	SharedDir                  string `flag:"shareddir,usage=web resource directory"`
 	Version                    bool   `flag:"version,usage=print version"`
 	WebProfile                 bool   `flag:"webprofile,usage=include profiler in http server"`
+	WebHealth                  bool   `flag:"webhealth,usage=include unauthenticated resource health report in http server"`
+	WebMetrics                 bool   `flag:"webmetrics,usage=include unauthenticated prometheus metrics in http server"`
+	WebDescribe                bool   `flag:"webdescribe,usage=include unauthenticated resource descriptions in http server"`
 	WebRTC                     bool   `flag:"webrtc,usage=force webrtc connections instead of direct"`
+	ReadyTimeout               int    `flag:"ready-timeout,usage=seconds to wait for resources to be ready before serving"`
 	RevealSensitiveConfigDiffs bool   `flag:"reveal-sensitive-config-diffs,usage=show config diffs"`
 	UntrustedEnv               bool   `flag:"untrusted-env,usage=disable processes and shell from running in a untrusted environment"`
 }
@@ -89,6 +96,18 @@ func RunServer(ctx context.Context, args []string, _ golog.Logger) (err error) {
 		defer pprof.StopCPUProfile()
 	}
 
+	if argsParsed.CommandLog != "" {
+		commands, err := commandlog.Open(argsParsed.CommandLog)
+		if err != nil {
+			return err
+		}
+		commandlog.Enable(commands)
+		defer func() {
+			commandlog.Enable(nil)
+			err = multierr.Combine(err, commands.Close())
+		}()
+	}
+
 	// Read the config from disk and use it to initialize the remote logger.
 	initialReadCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 	cfgFromDisk, err := config.ReadLocalConfig(initialReadCtx, argsParsed.ConfigFile, logger)
@@ -159,6 +178,9 @@ func (s *robotServer) createWebOptions(cfg *config.Config) (weboptions.Options,
 		return weboptions.Options{}, err
 	}
 	options.Pprof = s.args.WebProfile
+	options.HealthCheck = s.args.WebHealth
+	options.Metrics = s.args.WebMetrics
+	options.Describe = s.args.WebDescribe
 	options.SharedDir = s.args.SharedDir
 	options.Debug = s.args.Debug || cfg.Debug
 	options.WebRTC = s.args.WebRTC
@@ -313,6 +335,14 @@ func (s *robotServer) serveWeb(ctx context.Context, cfg *config.Config) (err err
 	}()
 	defer cancel()
 
+	if s.args.ReadyTimeout > 0 {
+		readyCtx, readyCancel := context.WithTimeout(ctx, time.Duration(s.args.ReadyTimeout)*time.Second)
+		if err := robot.WaitForReady(readyCtx, myRobot); err != nil {
+			s.logger.Warnw("serving before all resources are ready", "error", err)
+		}
+		readyCancel()
+	}
+
 	options, err := s.createWebOptions(processedConfig)
 	if err != nil {
 		return err